@@ -22,11 +22,13 @@ import (
 	"log"
 	"net/http"
 	_ "net/http/pprof" // Needed to allow pprof server to accept requests
+	"os"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/contexts"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/kccmanager"
 	controllermetrics "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/metrics"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/ratelimiter"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp/profiler"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/krmtotf"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/logging"
@@ -61,6 +63,8 @@ func main() {
 		rateLimitQps             float32
 		rateLimitBurst           int
 		leaderElectionMode       string
+		shards                   int
+		shardLeaseNamespace      string
 	)
 	flag.StringVar(&prometheusScrapeEndpoint, "prometheus-scrape-endpoint", ":8888", "configure the Prometheus scrape endpoint; :8888 as default")
 	flag.BoolVar(&controllermetrics.ResourceNameLabel, "resource-name-label", false, "option to enable the resource name label on some Prometheus metrics; false by default")
@@ -72,6 +76,8 @@ func main() {
 	flag.Float32Var(&rateLimitQps, "qps", 20.0, "The client-side token bucket rate limit qps.")
 	flag.IntVar(&rateLimitBurst, "burst", 30, "The client-side token bucket rate limit burst.")
 	flag.StringVar(&leaderElectionMode, "leader-election-type", "disabled", "Leader election mode. One of: default, multicluster.")
+	flag.IntVar(&shards, "shards", 0, "The number of shards to split resources into across the replicas of the manager; each replica reconciles the resources of the shards it holds. 0 or 1 disables sharding.")
+	flag.StringVar(&shardLeaseNamespace, "shard-lease-namespace", "cnrm-system", "The namespace of the leases used to assign shards to replicas, if --shards is greater than 1.")
 	profiler.AddFlag(flag.CommandLine)
 	flag.CommandLine.AddGoFlagSet(goflag.CommandLine)
 	flag.Parse()
@@ -113,10 +119,18 @@ func main() {
 		logging.Fatal(err, "fatal getting configuration from APIServer.")
 	}
 
+	var shardingOpts *sharding.Options
+	if shards > 1 {
+		shardingOpts, err = newShardingOptions(shards, shardLeaseNamespace, scopedNamespace)
+		if err != nil {
+			logging.Fatal(err, "error configuring sharding")
+		}
+	}
+
 	// Set client site rate limiter to optimize the configconnector re-reconciliation performance.
 	ratelimiter.SetMasterRateLimiter(restCfg, rateLimitQps, rateLimitBurst)
 	logger.Info("Creating the manager")
-	mgr, err := newManager(ctx, restCfg, scopedNamespace, userProjectOverride, billingProject, multiClusterElection)
+	mgr, err := newManager(ctx, restCfg, scopedNamespace, userProjectOverride, billingProject, multiClusterElection, shardingOpts)
 	if err != nil {
 		logging.Fatal(err, "error creating the manager")
 	}
@@ -172,7 +186,7 @@ func main() {
 	logging.ExitInfo("main.go finished execution; exiting ...")
 }
 
-func newManager(ctx context.Context, restCfg *rest.Config, scopedNamespace string, userProjectOverride bool, billingProject string, multiclusterlease bool) (manager.Manager, error) {
+func newManager(ctx context.Context, restCfg *rest.Config, scopedNamespace string, userProjectOverride bool, billingProject string, multiclusterlease bool, shardingOpts *sharding.Options) (manager.Manager, error) {
 	krmtotf.SetUserAgentForTerraformProvider()
 	controllersCfg := kccmanager.Config{
 		ManagerOptions: manager.Options{
@@ -183,6 +197,7 @@ func newManager(ctx context.Context, restCfg *rest.Config, scopedNamespace strin
 			},
 		},
 		MultiClusterLease: multiclusterlease,
+		Sharding:          shardingOpts,
 	}
 
 	controllersCfg.UserProjectOverride = userProjectOverride
@@ -195,3 +210,27 @@ func newManager(ctx context.Context, restCfg *rest.Config, scopedNamespace strin
 	}
 	return mgr, nil
 }
+
+// newShardingOptions identifies this replica by its pod name, and the group of replicas sharing the shards
+// by the namespace they watch, since the managers of all namespaces keep their leases in the same namespace.
+func newShardingOptions(shards int, leaseNamespace, scopedNamespace string) (*sharding.Options, error) {
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname to identify this replica: %w", err)
+		}
+		identity = hostname
+	}
+	group := "cnrm-controller-manager"
+	if scopedNamespace != "" {
+		group = fmt.Sprintf("%v-%v", group, scopedNamespace)
+	}
+	return &sharding.Options{
+		Shards:         shards,
+		LeaseNamespace: leaseNamespace,
+		Group:          group,
+		Identity:       identity,
+		WatchNamespace: scopedNamespace,
+	}, nil
+}
@@ -437,7 +437,8 @@ func NewHarness(ctx context.Context, t *testing.T, opts ...HarnessOption) *Harne
 	if h.GCPTarget == GCPTargetModeMock {
 		t.Logf("creating mock gcp")
 
-		mockCloud := mockgcp.NewMockRoundTripperForTest(t, h.client, storage.NewInMemoryStorage())
+		mockStorage := storage.NewForTest(ctx, t)
+		mockCloud := mockgcp.NewMockRoundTripperForTest(t, h.client, mockStorage)
 
 		mockCloudGRPCClientConnection = mockCloud.NewGRPCConnection(ctx)
 		h.MockGCP = mockCloud
@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/server"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	rootCmd := &cobra.Command{
+		Use:   "mockgcp",
+		Short: "mockgcp is a fake implementation of GCP APIs, for testing",
+	}
+
+	opt := server.Options{
+		HTTPAddress: "127.0.0.1:8080",
+		GRPCAddress: "127.0.0.1:9090",
+	}
+	storageDir := ""
+	snapshotPath := ""
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "serves the mock GCP APIs over HTTP and grpc",
+		Long: `Serves the mock GCP APIs over HTTP and grpc, until interrupted.
+
+HTTP requests are routed to the mock service by their Host header, or by a
+host prefix on the path, so both of these are equivalent:
+
+  curl -H "Host: pubsub.googleapis.com" http://127.0.0.1:8080/v1/projects/p/topics
+  curl http://127.0.0.1:8080/pubsub.googleapis.com/v1/projects/p/topics
+
+State is held in memory, and is lost when the server stops, unless
+--storage-dir is set.  The state can be seeded from a snapshot with
+--restore-snapshot.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd.Context(), opt, storageDir, snapshotPath)
+		},
+	}
+	serveCmd.Flags().StringVar(&opt.HTTPAddress, "http-address", opt.HTTPAddress, "address for the HTTP (REST) server to listen on")
+	serveCmd.Flags().StringVar(&opt.GRPCAddress, "grpc-address", opt.GRPCAddress, "address for the grpc server to listen on")
+	serveCmd.Flags().StringVar(&storageDir, "storage-dir", storageDir, "directory to persist state in; state is held in memory if not set")
+	serveCmd.Flags().StringVar(&snapshotPath, "restore-snapshot", snapshotPath, "snapshot file to restore the state from on startup, replacing any existing state")
+	rootCmd.AddCommand(serveCmd)
+
+	return rootCmd.ExecuteContext(ctx)
+}
+
+func runServe(ctx context.Context, opt server.Options, storageDir string, snapshotPath string) error {
+	// A few services (e.g. secretmanager) store data in kubernetes; we don't need a real apiserver for that.
+	k8sClient := fake.NewClientBuilder().Build()
+
+	mockStorage, err := storage.New(storageDir)
+	if err != nil {
+		return err
+	}
+	if snapshotPath != "" {
+		if err := storage.RestoreSnapshotFile(ctx, mockStorage, snapshotPath); err != nil {
+			return err
+		}
+	}
+
+	s, err := server.New(ctx, opt, k8sClient, mockStorage)
+	if err != nil {
+		return err
+	}
+	return s.Run(ctx)
+}
@@ -17,12 +17,20 @@ package common
 import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/orgpolicy"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/projects"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/workflows"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/quota"
 )
 
 type MockEnvironment struct {
 	Projects   projects.ProjectStore
 	KubeClient client.Client
 	Workflows  *workflows.Engine
+
+	// OrgPolicies evaluates organization policy constraints; mocks should check the constraints that apply to their resources.
+	OrgPolicies *orgpolicy.Evaluator
+
+	// Quotas holds the simulated per-project quota limits, which mockcloudquota and mockserviceusage expose.
+	Quotas *quota.Manager
 }
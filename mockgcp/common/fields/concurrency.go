@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The helpers in this file implement optimistic concurrency, as most GCP APIs do:
+// objects carry an etag that changes on every write, and writes that carry an etag
+// are rejected if the etag does not match the current object.
+//
+// A typical mock stamps the etag on writes (and reads, for objects stored without one),
+// and checks the etag before Update or Delete:
+//
+//	if err := fields.CheckEtag(codes.Aborted, fqn, fields.RequestEtag(req), fields.EtagOf(existing)); err != nil {
+//		return nil, err
+//	}
+//	...
+//	fields.StampEtag(updated)
+
+// etagFieldName is the conventional name of the etag field, on resources and on Delete requests.
+const etagFieldName protoreflect.Name = "etag"
+
+// StampEtag sets the etag field of obj (if it has one) to the weak etag of the rest of the object.
+func StampEtag(obj proto.Message) {
+	msg := obj.ProtoReflect()
+	fd := etagField(msg)
+	if fd == nil {
+		return
+	}
+	msg.Clear(fd)
+	if fd.Kind() == protoreflect.BytesKind {
+		msg.Set(fd, protoreflect.ValueOfBytes(computeEtagBytes(obj)))
+	} else {
+		msg.Set(fd, protoreflect.ValueOfString(ComputeWeakEtag(obj)))
+	}
+}
+
+// EtagOf returns the etag of obj: the etag field if it is set, otherwise the weak etag of the object.
+// The fallback means we can check etags of objects that were stored without one, as long as reads stamp them.
+func EtagOf(obj proto.Message) string {
+	msg := obj.ProtoReflect()
+	if etag := etagValue(msg); etag != "" {
+		return etag
+	}
+	if fd := etagField(msg); fd != nil && fd.Kind() == protoreflect.BytesKind {
+		return base64.StdEncoding.EncodeToString(computeEtagBytes(obj))
+	}
+	return ComputeWeakEtag(obj)
+}
+
+// computeEtagBytes computes the etag for objects with a bytes etag field (such as IAM policies).
+func computeEtagBytes(obj proto.Message) []byte {
+	b, err := proto.Marshal(obj)
+	if err != nil {
+		panic(fmt.Sprintf("converting to proto: %v", err))
+	}
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+// RequestEtag returns the etag that a request is conditional on, or "" if the request is unconditional.
+// This is the etag field of the request (as in most Delete requests) or else of the resource in the request (as in most Update requests).
+func RequestEtag(req proto.Message) string {
+	msg := req.ProtoReflect()
+	if etag := etagValue(msg); etag != "" {
+		return etag
+	}
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf.") {
+			continue
+		}
+		if etag := etagValue(msg.Get(fd).Message()); etag != "" {
+			return etag
+		}
+	}
+	return ""
+}
+
+// CheckEtag returns an error if the request carries an etag that does not match the current etag of the object.
+// GCP APIs differ in the code they return for a stale etag; most use codes.Aborted, some codes.FailedPrecondition.
+func CheckEtag(code codes.Code, name string, requestEtag string, currentEtag string) error {
+	if requestEtag == "" || requestEtag == currentEtag {
+		return nil
+	}
+	return status.Errorf(code, "etag %q does not match the current etag of %q; the resource was modified concurrently", requestEtag, name)
+}
+
+func etagField(msg protoreflect.Message) protoreflect.FieldDescriptor {
+	fd := msg.Descriptor().Fields().ByName(etagFieldName)
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return nil
+	}
+	if fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind {
+		return nil
+	}
+	return fd
+}
+
+// etagValue returns the value of the etag field of msg, or "" if it is not set.
+// Bytes etags are base64 encoded, as they are in JSON.
+func etagValue(msg protoreflect.Message) string {
+	fd := etagField(msg)
+	if fd == nil || !msg.Has(fd) {
+		return ""
+	}
+	if fd.Kind() == protoreflect.BytesKind {
+		return base64.StdEncoding.EncodeToString(msg.Get(fd).Bytes())
+	}
+	return msg.Get(fd).String()
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/secretmanager/v1"
+)
+
+func TestStampEtag(t *testing.T) {
+	secret := &pb.Secret{Name: "projects/p/secrets/s"}
+	unstamped := EtagOf(secret)
+
+	StampEtag(secret)
+	if secret.Etag == "" {
+		t.Fatalf("expected etag to be set")
+	}
+	if secret.Etag != unstamped {
+		t.Errorf("stamped etag %q does not match etag %q computed before stamping", secret.Etag, unstamped)
+	}
+
+	// Stamping is stable, and changes when the object changes
+	etag := secret.Etag
+	StampEtag(secret)
+	if secret.Etag != etag {
+		t.Errorf("etag changed on restamp: %q -> %q", etag, secret.Etag)
+	}
+	secret.Labels = map[string]string{"a": "b"}
+	StampEtag(secret)
+	if secret.Etag == etag {
+		t.Errorf("etag did not change when object changed")
+	}
+
+	// Bytes etags are stamped with raw bytes, and reported base64 encoded
+	policy := &iampb.Policy{Version: 1}
+	StampEtag(policy)
+	if len(policy.Etag) == 0 {
+		t.Fatalf("expected policy etag to be set")
+	}
+	if got, want := RequestEtag(&iampb.SetIamPolicyRequest{Policy: policy}), EtagOf(policy); got != want {
+		t.Errorf("unexpected request etag %q, want %q", got, want)
+	}
+}
+
+func TestCheckEtag(t *testing.T) {
+	secret := &pb.Secret{Name: "projects/p/secrets/s"}
+	StampEtag(secret)
+	current := EtagOf(secret)
+
+	if err := CheckEtag(codes.Aborted, secret.Name, RequestEtag(&pb.DeleteSecretRequest{Name: secret.Name}), current); err != nil {
+		t.Errorf("unconditional request should succeed, got %v", err)
+	}
+	if err := CheckEtag(codes.Aborted, secret.Name, RequestEtag(&pb.DeleteSecretRequest{Name: secret.Name, Etag: current}), current); err != nil {
+		t.Errorf("request with current etag should succeed, got %v", err)
+	}
+	if err := CheckEtag(codes.Aborted, secret.Name, RequestEtag(&pb.DeleteSecretRequest{Name: secret.Name, Etag: `W/"stale"`}), current); status.Code(err) != codes.Aborted {
+		t.Errorf("request with stale etag should be aborted, got %v", err)
+	}
+	update := &pb.UpdateSecretRequest{Secret: &pb.Secret{Name: secret.Name, Etag: `W/"stale"`}}
+	if err := CheckEtag(codes.FailedPrecondition, secret.Name, RequestEtag(update), current); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("update with stale etag should fail precondition, got %v", err)
+	}
+}
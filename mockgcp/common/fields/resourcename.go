@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fields
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RequestResourceName returns the name of the resource that a grpc request acts on.
+// This is the name field of the request, falling back to parent, resource or the first field that looks like a resource path,
+// because many requests name the field after the resource (e.g. GetTopicRequest.topic).
+func RequestResourceName(req proto.Message) string {
+	msg := req.ProtoReflect()
+	if name := StringField(msg, "name", "parent", "resource"); name != "" {
+		return name
+	}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if s := msg.Get(fd).String(); strings.Contains(s, "/") {
+			return s
+		}
+	}
+	return ""
+}
+
+// StringField returns the value of the first of the named string fields that is set.
+func StringField(msg protoreflect.Message, fieldNames ...string) string {
+	fields := msg.Descriptor().Fields()
+	for _, fieldName := range fieldNames {
+		fd := fields.ByName(protoreflect.Name(fieldName))
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if s := msg.Get(fd).String(); s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcsobjects stores GCS objects (metadata and contents) in mockgcp storage.
+// It is shared so that other mocks can write to mock GCS (for example exports), and mockstorage can serve the objects.
+package gcsobjects
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/storage/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// ParseGCSURI splits a gs://bucket/object uri into the bucket and object names.
+func ParseGCSURI(uri string) (string, string, error) {
+	path, ok := strings.CutPrefix(uri, "gs://")
+	if !ok {
+		return "", "", status.Errorf(codes.InvalidArgument, "GCS uri %q must start with gs://", uri)
+	}
+	bucket, object, ok := strings.Cut(path, "/")
+	if !ok || bucket == "" || object == "" {
+		return "", "", status.Errorf(codes.InvalidArgument, "GCS uri %q must be of the form gs://<bucket>/<object>", uri)
+	}
+	return bucket, object, nil
+}
+
+func bucketFQN(bucket string) string {
+	return "buckets/" + bucket
+}
+
+func objectFQN(bucket string, object string) string {
+	return "buckets/" + bucket + "/objects/" + object
+}
+
+// Write creates or replaces the object, returning its metadata.
+// The bucket must exist, as in GCS.
+func Write(ctx context.Context, store storage.Storage, bucket string, object string, contentType string, data []byte) (*pb.Object, error) {
+	if err := store.Get(ctx, bucketFQN(bucket), &pb.Bucket{}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "bucket %q not found", bucket)
+		}
+		return nil, err
+	}
+
+	fqn := objectFQN(bucket, object)
+	now := time.Now()
+
+	obj := &pb.Object{}
+	exists := true
+	if err := store.Get(ctx, fqn, obj); err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		exists = false
+		obj = &pb.Object{
+			TimeCreated: timestamppb.New(now),
+		}
+	}
+
+	generation := now.UnixMicro()
+	md5Hash := md5.Sum(data)
+	crc := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+	crcBytes := []byte{byte(crc >> 24), byte(crc >> 16), byte(crc >> 8), byte(crc)}
+
+	obj.Kind = proto.String("storage#object")
+	obj.Bucket = proto.String(bucket)
+	obj.Name = proto.String(object)
+	obj.Id = proto.String(fmt.Sprintf("%s/%s/%d", bucket, object, generation))
+	obj.Generation = proto.Int64(generation)
+	obj.Metageneration = proto.Int64(1)
+	obj.ContentType = proto.String(contentType)
+	obj.Size = proto.Uint64(uint64(len(data)))
+	obj.Md5Hash = proto.String(base64.StdEncoding.EncodeToString(md5Hash[:]))
+	obj.Crc32C = proto.String(base64.StdEncoding.EncodeToString(crcBytes))
+	obj.Etag = proto.String(base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(generation, 10))))
+	obj.StorageClass = proto.String("STANDARD")
+	obj.SelfLink = proto.String("https://www.googleapis.com/storage/v1/b/" + bucket + "/o/" + object)
+	obj.MediaLink = proto.String("https://storage.googleapis.com/download/storage/v1/b/" + bucket + "/o/" + object + "?generation=" + strconv.FormatInt(generation, 10) + "&alt=media")
+	obj.Updated = timestamppb.New(now)
+	obj.TimeFinalized = timestamppb.New(now)
+	obj.TimeStorageClassUpdated = timestamppb.New(now)
+
+	contents := &httpbody.HttpBody{
+		ContentType: contentType,
+		Data:        data,
+	}
+
+	if exists {
+		if err := store.Update(ctx, fqn, obj); err != nil {
+			return nil, err
+		}
+		if err := store.Update(ctx, fqn, contents); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := store.Create(ctx, fqn, obj); err != nil {
+			return nil, err
+		}
+		if err := store.Create(ctx, fqn, contents); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// Get returns the metadata of the object.
+func Get(ctx context.Context, store storage.Storage, bucket string, object string) (*pb.Object, error) {
+	obj := &pb.Object{}
+	if err := store.Get(ctx, objectFQN(bucket, object), obj); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "No such object: %s/%s", bucket, object)
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Read returns the contents of the object.
+func Read(ctx context.Context, store storage.Storage, bucket string, object string) (*httpbody.HttpBody, error) {
+	contents := &httpbody.HttpBody{}
+	if err := store.Get(ctx, objectFQN(bucket, object), contents); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "No such object: %s/%s", bucket, object)
+		}
+		return nil, err
+	}
+	return contents, nil
+}
+
+// List returns the metadata of the objects in the bucket whose names start with prefix.
+func List(ctx context.Context, store storage.Storage, bucket string, prefix string) ([]*pb.Object, error) {
+	var objects []*pb.Object
+	findPrefix := objectFQN(bucket, prefix)
+	if err := store.List(ctx, (&pb.Object{}).ProtoReflect().Descriptor(), storage.ListOptions{Prefix: findPrefix}, func(obj proto.Message) error {
+		objects = append(objects, obj.(*pb.Object))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Delete deletes the object and its contents.
+func Delete(ctx context.Context, store storage.Storage, bucket string, object string) error {
+	fqn := objectFQN(bucket, object)
+	if err := store.Delete(ctx, fqn, &pb.Object{}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "No such object: %s/%s", bucket, object)
+		}
+		return err
+	}
+	if err := store.Delete(ctx, fqn, &httpbody.HttpBody{}); err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	return nil
+}
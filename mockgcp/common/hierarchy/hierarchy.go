@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hierarchy navigates the GCP resource hierarchy (organizations, folders and projects) stored by mockresourcemanager,
+// for features that inherit down the hierarchy, such as IAM and organization policies.
+package hierarchy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// maxDepth bounds the walk up the hierarchy, in case of cycles.
+const maxDepth = 20
+
+// Hierarchy reads projects and folders from storage.
+type Hierarchy struct {
+	storage storage.Storage
+}
+
+// New constructs a Hierarchy over the objects in storage.
+func New(storage storage.Storage) *Hierarchy {
+	return &Hierarchy{storage: storage}
+}
+
+// Parent returns the parent of a project or folder (e.g. folders/123 or organizations/456),
+// or "" if it has no parent (or is not a known project or folder).
+func (h *Hierarchy) Parent(ctx context.Context, resource string) (string, error) {
+	tokens := strings.Split(resource, "/")
+	if len(tokens) != 2 {
+		return "", nil
+	}
+	switch tokens[0] {
+	case "projects":
+		project, err := h.GetProject(ctx, tokens[1])
+		if err != nil || project == nil {
+			return "", err
+		}
+		return project.Parent, nil
+
+	case "folders":
+		folder := &pb.Folder{}
+		if err := h.storage.Get(ctx, resource, folder); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return "", nil
+			}
+			return "", err
+		}
+		return folder.Parent, nil
+	}
+	return "", nil
+}
+
+// Ancestors returns the resource and its ancestors, nearest first,
+// e.g. projects/p/locations/l/buckets/b, projects/p/locations/l, projects/p, folders/123, organizations/456
+func (h *Hierarchy) Ancestors(ctx context.Context, resource string) ([]string, error) {
+	var ancestors []string
+
+	tokens := strings.Split(strings.Trim(resource, "/"), "/")
+	for n := len(tokens); n >= 2; n -= 2 {
+		ancestors = append(ancestors, strings.Join(tokens[:n], "/"))
+	}
+	if len(ancestors) == 0 {
+		return []string{resource}, nil
+	}
+
+	current := ancestors[len(ancestors)-1]
+	for i := 0; i < maxDepth; i++ {
+		parent, err := h.Parent(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent of %q: %w", current, err)
+		}
+		if parent == "" {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+	return ancestors, nil
+}
+
+// Aliases returns the names by which a resource of the hierarchy may be known:
+// projects can be named by id or number, so projects/my-project is also projects/123456.
+func (h *Hierarchy) Aliases(ctx context.Context, resource string) ([]string, error) {
+	projectIDOrNumber, ok := strings.CutPrefix(resource, "projects/")
+	if !ok || strings.Contains(projectIDOrNumber, "/") {
+		return []string{resource}, nil
+	}
+	project, err := h.GetProject(ctx, projectIDOrNumber)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return []string{resource}, nil
+	}
+	return []string{"projects/" + project.ProjectId, project.Name}, nil
+}
+
+// GetProject returns the project with the id or number, or nil if not found.
+func (h *Hierarchy) GetProject(ctx context.Context, projectIDOrNumber string) (*pb.Project, error) {
+	project := &pb.Project{}
+	if err := h.storage.Get(ctx, "projects/"+projectIDOrNumber, project); err == nil {
+		return project, nil
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	// Projects are stored by id, so we must search for a project number
+	var found *pb.Project
+	projectKind := (&pb.Project{}).ProtoReflect().Descriptor()
+	if err := h.storage.List(ctx, projectKind, storage.ListOptions{}, func(obj proto.Message) error {
+		if project := obj.(*pb.Project); project.Name == "projects/"+projectIDOrNumber {
+			found = project
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
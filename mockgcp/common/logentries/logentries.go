@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logentries stores Cloud Logging entries in mockgcp storage.
+// It is shared so that mockgcp can write log entries (for example audit logs), and mocklogging can serve them.
+package logentries
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	pb "cloud.google.com/go/logging/apiv2/loggingpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// LogName builds the log name for a log in a project, folder or organization,
+// e.g. projects/p/logs/cloudaudit.googleapis.com%2Factivity for the cloudaudit.googleapis.com/activity log.
+func LogName(parent string, logID string) string {
+	return parent + "/logs/" + url.PathEscape(logID)
+}
+
+// ParseLogName splits a log name into the parent (e.g. projects/p) and the (unescaped) log id.
+func ParseLogName(logName string) (string, string, error) {
+	parent, logID, ok := strings.Cut(logName, "/logs/")
+	if !ok || parent == "" || logID == "" {
+		return "", "", status.Errorf(codes.InvalidArgument, "log name %q is not valid", logName)
+	}
+	unescaped, err := url.PathUnescape(logID)
+	if err != nil {
+		return "", "", status.Errorf(codes.InvalidArgument, "log name %q is not valid", logName)
+	}
+	return parent, unescaped, nil
+}
+
+// Write stores the log entry, filling in the insert id and timestamps if they are not set.
+// The log name is normalized so the log id is escaped, as returned by the real API.
+func Write(ctx context.Context, store storage.Storage, entry *pb.LogEntry) error {
+	parent, logID, err := ParseLogName(entry.GetLogName())
+	if err != nil {
+		return err
+	}
+	entry.LogName = LogName(parent, logID)
+
+	now := time.Now()
+	if entry.InsertId == "" {
+		entry.InsertId = strings.ReplaceAll(string(uuid.NewUUID()), "-", "")[:16]
+	}
+	if entry.Timestamp == nil {
+		entry.Timestamp = timestamppb.New(now)
+	}
+	entry.ReceiveTimestamp = timestamppb.New(now)
+
+	fqn := entry.GetLogName() + "/entries/" + entry.GetInsertId()
+	return store.Create(ctx, fqn, entry)
+}
+
+// List returns the log entries under the parent (e.g. projects/p) that match the filter, in storage order.
+func List(ctx context.Context, store storage.Storage, parent string, filter string) ([]*pb.LogEntry, error) {
+	var entries []*pb.LogEntry
+	kind := (&pb.LogEntry{}).ProtoReflect().Descriptor()
+	if err := store.List(ctx, kind, storage.ListOptions{Prefix: parent + "/logs/", Filter: filter}, func(obj proto.Message) error {
+		entries = append(entries, obj.(*pb.LogEntry))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -109,8 +109,17 @@ func (s *Operations) StartLROWithOptions(ctx context.Context, prefix string, met
 		return nil, status.Errorf(codes.Internal, "error creating LRO: %v", err)
 	}
 
+	timing := timingFromContext(ctx)
+	rule := timing.ruleFor(grpcMethod(ctx))
+
+	// The operation outlives the request
+	ctx = context.WithoutCancel(ctx)
+
 	go func() {
+		timing.wait(fqn, rule.Duration)
+
 		result, err := callback()
+
 		finished := &pb.Operation{}
 		if err2 := s.storage.Get(ctx, fqn, finished); err2 != nil {
 			klog.Warningf("error getting LRO: %v", err2)
@@ -218,5 +227,17 @@ func (s *Operations) GetOperation(ctx context.Context, req *pb.GetOperationReque
 		return nil, err
 	}
 
+	if !op.Done {
+		if fraction, ok := timingFromContext(ctx).progress(fqn); ok {
+			setProgress(op, fraction)
+		}
+	}
+
 	return op, nil
 }
+
+// grpcMethod returns the grpc method being served, or "" if we are not serving a grpc request.
+func grpcMethod(ctx context.Context) string {
+	method, _ := grpc.Method(ctx)
+	return method
+}
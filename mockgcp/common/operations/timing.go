@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	pb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// Timing controls how long long-running operations take to complete.
+// Without a Timing (or without a matching rule), operations complete as soon as their work is done.
+// Operations are failed with fault injection rules (see faults.Rule.FailOperation), not with Timing.
+//
+// Timing is passed to the operations via the request context, by UnaryServerInterceptor,
+// so that it can be configured per mockgcp instance (i.e. per test).
+type Timing struct {
+	clock clock.Clock
+
+	mutex sync.Mutex
+	rules []TimingRule
+
+	// pending holds the operations that are waiting to complete, by name.
+	pending map[string]pendingOperation
+}
+
+// TimingRule configures the operations started by matching methods.
+type TimingRule struct {
+	// Method matches the grpc method that started the operation, using path.Match syntax,
+	// e.g. /mockgcp.cloud.redis.v1.CloudRedis/* or /mockgcp.cloud.redis.v1.CloudRedis/CreateInstance.
+	// An empty Method matches all operations.
+	Method string
+
+	// Duration is how long the operation takes to complete, as measured by the Timing clock.
+	Duration time.Duration
+}
+
+type pendingOperation struct {
+	start    time.Time
+	duration time.Duration
+}
+
+// NewTiming constructs a Timing with no rules.
+// Tests should use a fake clock (from k8s.io/utils/clock/testing), so that they control when operations complete.
+// If c is nil, we use the real clock.
+func NewTiming(c clock.Clock) *Timing {
+	if c == nil {
+		c = clock.RealClock{}
+	}
+	return &Timing{
+		clock:   c,
+		pending: make(map[string]pendingOperation),
+	}
+}
+
+// AddRule adds a rule; the most recently added matching rule applies.
+func (t *Timing) AddRule(rule TimingRule) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.rules = append(t.rules, rule)
+}
+
+// Reset removes all rules.
+func (t *Timing) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.rules = nil
+}
+
+// Clock returns the clock used to time operations.
+func (t *Timing) Clock() clock.Clock {
+	return t.clock
+}
+
+// ruleFor returns the rule for operations started by the grpc method.
+func (t *Timing) ruleFor(method string) TimingRule {
+	if t == nil {
+		return TimingRule{}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for i := len(t.rules) - 1; i >= 0; i-- {
+		rule := t.rules[i]
+		if rule.Method == "" {
+			return rule
+		}
+		match, err := path.Match(rule.Method, method)
+		if err != nil {
+			klog.Warningf("invalid method pattern %q in operation timing rule: %v", rule.Method, err)
+			continue
+		}
+		if match {
+			return rule
+		}
+	}
+	return TimingRule{}
+}
+
+// wait blocks until the operation should complete.
+func (t *Timing) wait(name string, duration time.Duration) {
+	if t == nil || duration == 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	t.pending[name] = pendingOperation{start: t.clock.Now(), duration: duration}
+	t.mutex.Unlock()
+
+	<-t.clock.After(duration)
+
+	t.mutex.Lock()
+	delete(t.pending, name)
+	t.mutex.Unlock()
+}
+
+// progress returns the fraction of the operation that is complete, if it is pending.
+func (t *Timing) progress(name string) (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	pending, found := t.pending[name]
+	if !found {
+		return 0, false
+	}
+	fraction := float64(t.clock.Since(pending.start)) / float64(pending.duration)
+	return min(fraction, 1), true
+}
+
+type timingContextKey struct{}
+
+// UnaryServerInterceptor is a grpc interceptor that makes the Timing available to operations started by the request.
+func (t *Timing) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(context.WithValue(ctx, timingContextKey{}, t), req)
+}
+
+func timingFromContext(ctx context.Context) *Timing {
+	t, _ := ctx.Value(timingContextKey{}).(*Timing)
+	return t
+}
+
+// progressFieldNames are the names of the (numeric) fields that operation metadata commonly uses for progress.
+var progressFieldNames = []protoreflect.Name{"progress_percent", "progress_percentage", "progress"}
+
+// setProgress sets the progress field of the operation metadata (if there is one), to the fraction complete.
+func setProgress(op *pb.Operation, fraction float64) {
+	if op.GetMetadata() == nil {
+		return
+	}
+	metadata := storage.UnpackAny(op.GetMetadata())
+	if metadata == nil {
+		return
+	}
+
+	msg := metadata.ProtoReflect()
+	for _, name := range progressFieldNames {
+		fd := msg.Descriptor().Fields().ByName(name)
+		if fd == nil || fd.Cardinality() == protoreflect.Repeated {
+			continue
+		}
+		percent := fraction * 100
+		switch fd.Kind() {
+		case protoreflect.Int32Kind:
+			msg.Set(fd, protoreflect.ValueOfInt32(int32(percent)))
+		case protoreflect.Int64Kind:
+			msg.Set(fd, protoreflect.ValueOfInt64(int64(percent)))
+		case protoreflect.DoubleKind:
+			msg.Set(fd, protoreflect.ValueOfFloat64(percent))
+		case protoreflect.FloatKind:
+			msg.Set(fd, protoreflect.ValueOfFloat32(float32(percent)))
+		default:
+			continue
+		}
+
+		b, err := proto.Marshal(metadata)
+		if err != nil {
+			klog.Warningf("error serializing operation metadata: %v", err)
+			return
+		}
+		op.Metadata = &anypb.Any{TypeUrl: op.GetMetadata().GetTypeUrl(), Value: b}
+		return
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	testingclock "k8s.io/utils/clock/testing"
+
+	speechpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/speech/v2"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// fakeServerTransportStream lets us set the grpc method in the context, as the grpc server would.
+type fakeServerTransportStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (s *fakeServerTransportStream) Method() string {
+	return s.method
+}
+
+// startLRO starts an operation as if it were started by a request to the grpc method.
+func startLRO(t *testing.T, s *Operations, timing *Timing, method string) *pb.Operation {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &fakeServerTransportStream{method: method})
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	resp, err := timing.UnaryServerInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.StartLRO(ctx, "", &speechpb.OperationMetadata{}, func() (proto.Message, error) {
+			return wrapperspb.String("done"), nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("StartLRO failed: %v", err)
+	}
+	return resp.(*pb.Operation)
+}
+
+// getOperation gets the operation, as if served by a grpc request (so that progress is populated).
+func getOperation(t *testing.T, s *Operations, timing *Timing, name string) *pb.Operation {
+	ctx := context.WithValue(context.Background(), timingContextKey{}, timing)
+	op, err := s.GetOperation(ctx, &pb.GetOperationRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetOperation failed: %v", err)
+	}
+	return op
+}
+
+func waitForDone(t *testing.T, s *Operations, name string) *pb.Operation {
+	op, err := s.Wait(context.Background(), name, 10*time.Second)
+	if err != nil {
+		t.Fatalf("waiting for operation: %v", err)
+	}
+	return op
+}
+
+// waitForWaiters waits until the operation is blocked on the fake clock, so stepping the clock is deterministic.
+func waitForWaiters(t *testing.T, clock *testingclock.FakeClock) {
+	deadline := time.Now().Add(10 * time.Second)
+	for !clock.HasWaiters() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for operation to wait on clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTimingDelaysOperations(t *testing.T) {
+	clock := testingclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	timing := NewTiming(clock)
+	timing.AddRule(TimingRule{Method: "/mockgcp.cloud.speech.v2.Speech/*", Duration: 10 * time.Minute})
+
+	s := NewOperationsService(storage.NewInMemoryStorage())
+
+	op := startLRO(t, s, timing, "/mockgcp.cloud.speech.v2.Speech/CreateRecognizer")
+	waitForWaiters(t, clock)
+
+	clock.Step(5 * time.Minute)
+	op = getOperation(t, s, timing, op.GetName())
+	if op.GetDone() {
+		t.Fatalf("operation should not be done after 5 minutes")
+	}
+	metadata, ok := storage.UnpackAny(op.GetMetadata()).(*speechpb.OperationMetadata)
+	if !ok {
+		t.Fatalf("unable to unpack metadata %v", op.GetMetadata())
+	}
+	if got := metadata.GetProgressPercent(); got != 50 {
+		t.Errorf("unexpected progress %d, want 50", got)
+	}
+
+	clock.Step(5 * time.Minute)
+	op = waitForDone(t, s, op.GetName())
+	if op.GetError() != nil {
+		t.Errorf("unexpected operation error: %v", op.GetError())
+	}
+
+	// Other methods are not delayed
+	op = startLRO(t, s, timing, "/mockgcp.pubsub.v1.Publisher/CreateTopic")
+	op = waitForDone(t, s, op.GetName())
+	if op.GetResponse() == nil {
+		t.Errorf("expected operation response, got %v", op)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orgpolicy evaluates the organization policies stored by mockorgpolicy,
+// so that mock services can reject requests that violate a constraint, as GCP does.
+package orgpolicy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/orgpolicy/v2"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// Evaluator evaluates boolean and list constraints for a resource, using the policies set on the resource and its ancestors.
+// Only the spec is enforced (not the dry-run spec), and rules with conditions are ignored.
+type Evaluator struct {
+	storage   storage.Storage
+	hierarchy *hierarchy.Hierarchy
+}
+
+// NewEvaluator constructs an Evaluator over the policies in storage.
+func NewEvaluator(storage storage.Storage) *Evaluator {
+	return &Evaluator{
+		storage:   storage,
+		hierarchy: hierarchy.New(storage),
+	}
+}
+
+// CheckBoolean returns a FailedPrecondition error if the boolean constraint (e.g. iam.disableServiceAccountKeyCreation)
+// is enforced on the resource (e.g. projects/my-project).  The nearest policy in the hierarchy applies.
+func (e *Evaluator) CheckBoolean(ctx context.Context, resource string, constraint string) error {
+	if e == nil {
+		return nil
+	}
+	constraint = strings.TrimPrefix(constraint, "constraints/")
+
+	specs, err := e.effectiveSpecs(ctx, resource, constraint)
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 || specs[0].GetReset_() {
+		return nil
+	}
+	for _, rule := range specs[0].GetRules() {
+		if rule.GetCondition() == nil && rule.GetEnforce() {
+			return violation(constraint, resource, fmt.Sprintf("Request violates constraint 'constraints/%s' on '%s'.", constraint, resource))
+		}
+	}
+	return nil
+}
+
+// CheckListValue returns a FailedPrecondition error if the value is not allowed by the list constraint
+// (e.g. a location for gcp.resourceLocations) on the resource (e.g. projects/my-project).
+// Policies are merged up the hierarchy while they set inherit_from_parent.
+func (e *Evaluator) CheckListValue(ctx context.Context, resource string, constraint string, value string) error {
+	if e == nil {
+		return nil
+	}
+	constraint = strings.TrimPrefix(constraint, "constraints/")
+
+	specs, err := e.effectiveSpecs(ctx, resource, constraint)
+	if err != nil {
+		return err
+	}
+
+	denied := violation(constraint, resource, fmt.Sprintf("'%s' violates constraint 'constraints/%s' on '%s'.", value, constraint, resource))
+
+	var allowedValues []string
+	for _, spec := range specs {
+		if spec.GetReset_() {
+			break
+		}
+		for _, rule := range spec.GetRules() {
+			if rule.GetCondition() != nil {
+				continue
+			}
+			if matchesAny(rule.GetValues().GetDeniedValues(), value) {
+				return denied
+			}
+		}
+		for _, rule := range spec.GetRules() {
+			if rule.GetCondition() != nil {
+				continue
+			}
+			if rule.GetDenyAll() {
+				return denied
+			}
+			if rule.GetAllowAll() {
+				return nil
+			}
+			allowedValues = append(allowedValues, rule.GetValues().GetAllowedValues()...)
+		}
+		if !spec.GetInheritFromParent() {
+			break
+		}
+	}
+
+	if len(allowedValues) != 0 && !matchesAny(allowedValues, value) {
+		return denied
+	}
+	return nil
+}
+
+// effectiveSpecs returns the specs of the policies for the constraint, nearest first.
+func (e *Evaluator) effectiveSpecs(ctx context.Context, resource string, constraint string) ([]*pb.PolicySpec, error) {
+	ancestors, err := e.hierarchy.Ancestors(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []*pb.PolicySpec
+	for _, ancestor := range ancestors {
+		// Policies of a project could have been set using the project id or number
+		aliases, err := e.hierarchy.Aliases(ctx, ancestor)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range aliases {
+			policy := &pb.Policy{}
+			if err := e.storage.Get(ctx, alias+"/policies/"+constraint, policy); err != nil {
+				if status.Code(err) == codes.NotFound {
+					continue
+				}
+				return nil, err
+			}
+			if policy.GetSpec() != nil {
+				specs = append(specs, policy.GetSpec())
+			}
+			break
+		}
+	}
+	return specs, nil
+}
+
+// matchesAny returns true if the value matches any of the policy values.
+// Values can use the is: prefix, in: for value groups (e.g. in:us-locations) and under: for resource hierarchy subtrees.
+func matchesAny(policyValues []string, value string) bool {
+	for _, policyValue := range policyValues {
+		if matches(policyValue, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(policyValue string, value string) bool {
+	switch {
+	case strings.HasPrefix(policyValue, "in:"):
+		// e.g. in:us-locations matches us, us-central1 and us-central1-a
+		locations, ok := valueGroup(strings.TrimPrefix(policyValue, "in:"))
+		if !ok {
+			return false
+		}
+		value = strings.ToLower(value)
+		region := zoneRegion.ReplaceAllString(value, "")
+		for _, location := range locations {
+			if value == location || region == location {
+				return true
+			}
+		}
+		return false
+	case strings.HasPrefix(policyValue, "under:"):
+		subtree := strings.TrimPrefix(policyValue, "under:")
+		return value == subtree || strings.HasPrefix(value, subtree+"/")
+	default:
+		return strings.EqualFold(strings.TrimPrefix(policyValue, "is:"), value)
+	}
+}
+
+// zoneRegion matches the zone suffix of a location, e.g. -a in us-central1-a
+var zoneRegion = regexp.MustCompile(`-[a-z]$`)
+
+// valueGroups holds the locations in the value groups we support for gcp.resourceLocations.
+// Each region is also a value group of its own, e.g. in:us-central1-locations.
+var valueGroups = map[string][]string{
+	"us-locations": {
+		"us", "nam4", "nam5", "nam-eur-asia1",
+		"us-central1", "us-central2", "us-east1", "us-east4", "us-east5", "us-south1",
+		"us-west1", "us-west2", "us-west3", "us-west4",
+	},
+	"eu-locations": {
+		"eu", "eur3", "eur4", "eur5", "eur6",
+		"europe-central2", "europe-north1", "europe-southwest1", "europe-west1", "europe-west2", "europe-west3",
+		"europe-west4", "europe-west6", "europe-west8", "europe-west9", "europe-west10", "europe-west12",
+	},
+	"asia-locations": {
+		"asia", "asia1",
+		"asia-east1", "asia-east2", "asia-northeast1", "asia-northeast2", "asia-northeast3",
+		"asia-south1", "asia-south2", "asia-southeast1", "asia-southeast2",
+	},
+}
+
+// valueGroup returns the locations in the value group, and false if we don't know the group.
+func valueGroup(group string) ([]string, bool) {
+	if locations, ok := valueGroups[group]; ok {
+		return locations, true
+	}
+	region, ok := strings.CutSuffix(group, "-locations")
+	if !ok {
+		return nil, false
+	}
+	for _, locations := range valueGroups {
+		if slices.Contains(locations, region) {
+			return []string{region}, true
+		}
+	}
+	return nil, false
+}
+
+// ValidateSpec returns an InvalidArgument error if the spec uses a value group (in:) that we don't know,
+// so that a typo in a policy is reported rather than silently matching nothing.
+func ValidateSpec(spec *pb.PolicySpec) error {
+	for _, rule := range spec.GetRules() {
+		values := append(append([]string(nil), rule.GetValues().GetAllowedValues()...), rule.GetValues().GetDeniedValues()...)
+		for _, value := range values {
+			group, ok := strings.CutPrefix(value, "in:")
+			if !ok {
+				continue
+			}
+			if _, ok := valueGroup(group); !ok {
+				return status.Errorf(codes.InvalidArgument, "value group %q is not supported", value)
+			}
+		}
+	}
+	return nil
+}
+
+// violation builds the error GCP returns for a constraint violation, with the constraint in the details.
+func violation(constraint string, resource string, message string) error {
+	st := status.New(codes.FailedPrecondition, message)
+	withDetails, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{
+				Type:        "constraints/" + constraint,
+				Subject:     resource,
+				Description: message,
+			},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orgpolicy
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/orgpolicy/v2"
+	resourcemanagerpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func create(t *testing.T, s storage.Storage, fqn string, obj proto.Message) {
+	if err := s.Create(context.Background(), fqn, obj); err != nil {
+		t.Fatalf("creating %q: %v", fqn, err)
+	}
+}
+
+func listRule(allowed []string, denied []string) *pb.PolicySpec_PolicyRule {
+	return &pb.PolicySpec_PolicyRule{
+		Kind: &pb.PolicySpec_PolicyRule_Values{
+			Values: &pb.PolicySpec_PolicyRule_StringValues{AllowedValues: allowed, DeniedValues: denied},
+		},
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewInMemoryStorage()
+
+	create(t, s, "projects/my-project", &resourcemanagerpb.Project{Name: "projects/123", ProjectId: "my-project", Parent: "folders/100"})
+	create(t, s, "projects/other-project", &resourcemanagerpb.Project{Name: "projects/456", ProjectId: "other-project", Parent: "organizations/1"})
+	create(t, s, "folders/100", &resourcemanagerpb.Folder{Name: "folders/100", Parent: "organizations/1"})
+
+	// Boolean constraint enforced on the organization, and turned off for one project (set by project number)
+	create(t, s, "organizations/1/policies/iam.disableServiceAccountKeyCreation", &pb.Policy{
+		Spec: &pb.PolicySpec{Rules: []*pb.PolicySpec_PolicyRule{{Kind: &pb.PolicySpec_PolicyRule_Enforce{Enforce: true}}}},
+	})
+	create(t, s, "projects/123/policies/iam.disableServiceAccountKeyCreation", &pb.Policy{
+		Spec: &pb.PolicySpec{Rules: []*pb.PolicySpec_PolicyRule{{Kind: &pb.PolicySpec_PolicyRule_Enforce{Enforce: false}}}},
+	})
+
+	// List constraint allowing US locations on the organization, with the folder denying one region and inheriting
+	create(t, s, "organizations/1/policies/gcp.resourceLocations", &pb.Policy{
+		Spec: &pb.PolicySpec{Rules: []*pb.PolicySpec_PolicyRule{listRule([]string{"in:us-locations"}, nil)}},
+	})
+	create(t, s, "folders/100/policies/gcp.resourceLocations", &pb.Policy{
+		Spec: &pb.PolicySpec{InheritFromParent: true, Rules: []*pb.PolicySpec_PolicyRule{listRule(nil, []string{"is:us-east1"})}},
+	})
+
+	e := NewEvaluator(s)
+
+	grid := []struct {
+		name     string
+		check    func() error
+		wantCode codes.Code
+	}{
+		{
+			name: "boolean overridden on project",
+			check: func() error {
+				return e.CheckBoolean(ctx, "projects/my-project", "iam.disableServiceAccountKeyCreation")
+			},
+		},
+		{
+			name: "boolean inherited from organization",
+			check: func() error {
+				return e.CheckBoolean(ctx, "projects/other-project", "constraints/iam.disableServiceAccountKeyCreation")
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:  "boolean not set",
+			check: func() error { return e.CheckBoolean(ctx, "projects/other-project", "storage.uniformBucketLevelAccess") },
+		},
+		{
+			name: "list value allowed by organization group",
+			check: func() error {
+				return e.CheckListValue(ctx, "projects/my-project", "gcp.resourceLocations", "us-central1")
+			},
+		},
+		{
+			name:     "list value denied by folder",
+			check:    func() error { return e.CheckListValue(ctx, "projects/my-project", "gcp.resourceLocations", "us-east1") },
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "list value denied by folder does not affect other projects",
+			check: func() error {
+				return e.CheckListValue(ctx, "projects/other-project", "gcp.resourceLocations", "us-east1")
+			},
+		},
+		{
+			name: "list value not allowed",
+			check: func() error {
+				return e.CheckListValue(ctx, "projects/other-project", "gcp.resourceLocations", "europe-west1")
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "list constraint not set",
+			check: func() error {
+				return e.CheckListValue(ctx, "projects/my-project", "compute.vmExternalIpAccess", "anything")
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := g.check()
+			if got := status.Code(err); got != g.wantCode {
+				t.Fatalf("unexpected result, got %v (%v), want %v", got, err, g.wantCode)
+			}
+			if err == nil {
+				return
+			}
+			var violation *errdetails.PreconditionFailure
+			for _, detail := range status.Convert(err).Details() {
+				if d, ok := detail.(*errdetails.PreconditionFailure); ok {
+					violation = d
+				}
+			}
+			if violation == nil || len(violation.GetViolations()) != 1 {
+				t.Errorf("expected precondition failure details, got %v", status.Convert(err).Details())
+			}
+		})
+	}
+}
+
+func TestMatchesValueGroup(t *testing.T) {
+	grid := []struct {
+		policyValue string
+		value       string
+		want        bool
+	}{
+		{policyValue: "in:us-locations", value: "us", want: true},
+		{policyValue: "in:us-locations", value: "us-central1", want: true},
+		{policyValue: "in:us-locations", value: "US-CENTRAL1-A", want: true},
+		{policyValue: "in:us-locations", value: "europe-west1", want: false},
+		{policyValue: "in:us-locations", value: "us-notaregion1", want: false},
+		{policyValue: "in:eu-locations", value: "europe-west1-b", want: true},
+		{policyValue: "in:us-central1-locations", value: "us-central1-a", want: true},
+		{policyValue: "in:us-central1-locations", value: "us-east1", want: false},
+		{policyValue: "in:us", value: "us-central1", want: false},
+		{policyValue: "in:mars-locations", value: "mars-north1", want: false},
+	}
+	for _, g := range grid {
+		if got := matches(g.policyValue, g.value); got != g.want {
+			t.Errorf("matches(%q, %q) = %v, want %v", g.policyValue, g.value, got, g.want)
+		}
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	if err := ValidateSpec(&pb.PolicySpec{Rules: []*pb.PolicySpec_PolicyRule{listRule([]string{"in:us-locations", "is:europe-west1"}, nil)}}); err != nil {
+		t.Errorf("expected known value groups to be valid, got %v", err)
+	}
+	err := ValidateSpec(&pb.PolicySpec{Rules: []*pb.PolicySpec_PolicyRule{listRule(nil, []string{"in:us-locatons"})}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an unknown value group to be invalid, got %v", err)
+	}
+}
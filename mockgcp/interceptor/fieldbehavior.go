@@ -0,0 +1,390 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// FieldBehaviorEnvVar enables field behavior enforcement, for a comma-separated list of grpc service patterns.
+// For example MOCKGCP_ENFORCE_FIELD_BEHAVIOR=mockgcp.pubsub.v1.*,mockgcp.cloud.redis.v1.CloudRedis
+const FieldBehaviorEnvVar = "MOCKGCP_ENFORCE_FIELD_BEHAVIOR"
+
+// FieldBehaviorInterceptor is a gRPC unary interceptor that enforces the google.api.field_behavior annotations
+// on Create and Update requests, as GCP does:
+//   - REQUIRED fields must be set
+//   - OUTPUT_ONLY fields must not be set
+//   - IMMUTABLE fields must not be changed by an Update, compared to the stored resource
+//
+// Many mocks (and the protos they use) don't yet agree with GCP on every field, so enforcement is opt-in per service.
+type FieldBehaviorInterceptor struct {
+	// services holds the grpc service names (as path.Match patterns) for which we enforce field behavior.
+	services []string
+
+	// storage holds the existing resources, which we compare IMMUTABLE fields against.
+	storage storage.Storage
+}
+
+// NewFieldBehaviorInterceptor builds a FieldBehaviorInterceptor that enforces field behavior for the given grpc services,
+// which are path.Match patterns, e.g. mockgcp.pubsub.v1.Publisher or mockgcp.pubsub.v1.*
+func NewFieldBehaviorInterceptor(services []string, storage storage.Storage) *FieldBehaviorInterceptor {
+	return &FieldBehaviorInterceptor{services: services, storage: storage}
+}
+
+// FieldBehaviorServicesFromEnv returns the services for which field behavior should be enforced, from FieldBehaviorEnvVar.
+func FieldBehaviorServicesFromEnv() []string {
+	var services []string
+	for _, service := range strings.Split(os.Getenv(FieldBehaviorEnvVar), ",") {
+		service = strings.TrimSpace(service)
+		if service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+func (i *FieldBehaviorInterceptor) isEnabled(service string) bool {
+	for _, pattern := range i.services {
+		match, err := path.Match(pattern, service)
+		if err != nil {
+			klog.Warningf("invalid service pattern %q for field behavior enforcement: %v", pattern, err)
+			continue
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryServerInterceptor checks the field behavior of Create and Update requests, for the enabled services.
+func (i *FieldBehaviorInterceptor) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	if !i.isEnabled(service) {
+		return handler(ctx, req)
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(method, "Create"):
+		err = validateCreate(msg.ProtoReflect())
+	case strings.HasPrefix(method, "Update") || strings.HasPrefix(method, "Patch"):
+		err = i.validateUpdate(ctx, msg.ProtoReflect())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func validateCreate(req protoreflect.Message) error {
+	resource, prefix, err := validateRequest(req)
+	if resource == nil || err != nil {
+		return err
+	}
+	// The server assigns the name, from the parent and id in the request
+	return checkRequired(resource, prefix, prefix+"name")
+}
+
+func (i *FieldBehaviorInterceptor) validateUpdate(ctx context.Context, req protoreflect.Message) error {
+	resource, prefix, err := validateRequest(req)
+	if resource == nil || err != nil {
+		return err
+	}
+	existing := i.existingResource(ctx, resource)
+
+	updateMask := findUpdateMask(req)
+	if updateMask == nil || (len(updateMask) == 1 && updateMask[0] == "*") {
+		// A full replacement must set all the required fields, and must not change the immutable fields.
+		// Without an update_mask, only the fields that are set are updated, so required fields can be omitted (AIP-134).
+		if updateMask != nil {
+			if err := checkRequired(resource, prefix, ""); err != nil {
+				return err
+			}
+		}
+		if existing == nil {
+			return nil
+		}
+		return checkImmutable(resource, existing, prefix, updateMask == nil)
+	}
+
+	for _, p := range updateMask {
+		parent, fd, err := resolvePath(resource, p)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid update_mask path %q: %v", p, err)
+		}
+		if fd == nil {
+			// e.g. a map key; we don't check below this level
+			continue
+		}
+		behaviors := fieldBehaviors(fd)
+		if behaviors[annotations.FieldBehavior_IMMUTABLE] && existing != nil {
+			existingParent, _, err := resolvePath(existing, p)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid update_mask path %q: %v", p, err)
+			}
+			if !parent.Get(fd).Equal(existingParent.Get(fd)) {
+				return status.Errorf(codes.InvalidArgument, "field %q is immutable and cannot be updated", prefix+p)
+			}
+		}
+		if behaviors[annotations.FieldBehavior_OUTPUT_ONLY] {
+			return status.Errorf(codes.InvalidArgument, "field %q is output only and cannot be updated", prefix+p)
+		}
+		if behaviors[annotations.FieldBehavior_REQUIRED] && !parent.Has(fd) {
+			return status.Errorf(codes.InvalidArgument, "field %q is required", prefix+p)
+		}
+	}
+	return nil
+}
+
+// existingResource returns the stored version of the resource being updated, found by its name.
+// It returns nil if the resource is not found; the mock will then report the error.
+func (i *FieldBehaviorInterceptor) existingResource(ctx context.Context, resource protoreflect.Message) protoreflect.Message {
+	if i.storage == nil {
+		return nil
+	}
+	nameField := resource.Descriptor().Fields().ByName("name")
+	if nameField == nil || nameField.Kind() != protoreflect.StringKind {
+		return nil
+	}
+	name := resource.Get(nameField).String()
+	if name == "" {
+		return nil
+	}
+	existing := resource.New().Interface()
+	if err := i.storage.Get(ctx, name, existing); err != nil {
+		klog.V(2).Infof("not checking immutable fields of %q, could not get existing resource: %v", name, err)
+		return nil
+	}
+	return existing.ProtoReflect()
+}
+
+// checkImmutable checks that the IMMUTABLE fields of msg are unchanged from existing, recursing into set messages.
+// If onlySet is true, we only check the fields that are set in msg.
+func checkImmutable(msg protoreflect.Message, existing protoreflect.Message, prefix string, onlySet bool) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := prefix + string(fd.Name())
+		if onlySet && !msg.Has(fd) {
+			continue
+		}
+		if fieldBehaviors(fd)[annotations.FieldBehavior_IMMUTABLE] {
+			if !msg.Get(fd).Equal(existing.Get(fd)) {
+				return status.Errorf(codes.InvalidArgument, "field %q is immutable and cannot be updated", fieldPath)
+			}
+			continue
+		}
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			continue
+		}
+		if strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf.") {
+			continue
+		}
+		if err := checkImmutable(msg.Get(fd).Message(), existing.Get(fd).Message(), fieldPath+".", onlySet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRequest checks the fields of the request itself, and the OUTPUT_ONLY fields of the resource.
+// It returns the resource (if found) and the prefix for its field paths.
+func validateRequest(req protoreflect.Message) (protoreflect.Message, string, error) {
+	// Some methods (e.g. pubsub CreateTopic) take the resource as the request
+	if !strings.HasSuffix(string(req.Descriptor().Name()), "Request") {
+		return req, "", checkOutputOnly(req, "")
+	}
+
+	if err := checkRequestRequired(req); err != nil {
+		return nil, "", err
+	}
+	resourceField, resource := findResource(req)
+	if resource == nil {
+		return nil, "", nil
+	}
+	prefix := string(resourceField.Name()) + "."
+	return resource, prefix, checkOutputOnly(resource, prefix)
+}
+
+// findResource returns the resource in a Create or Update request: the first set message field that is not a well-known type.
+func findResource(req protoreflect.Message) (protoreflect.FieldDescriptor, protoreflect.Message) {
+	fields := req.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !req.Has(fd) {
+			continue
+		}
+		if strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf.") {
+			continue
+		}
+		return fd, req.Get(fd).Message()
+	}
+	return nil, nil
+}
+
+// findUpdateMask returns the paths in the update_mask field (if set).
+func findUpdateMask(req protoreflect.Message) []string {
+	fd := req.Descriptor().Fields().ByName("update_mask")
+	if fd == nil || fd.Kind() != protoreflect.MessageKind || !req.Has(fd) {
+		return nil
+	}
+	mask := req.Get(fd).Message()
+	pathsField := mask.Descriptor().Fields().ByName("paths")
+	if pathsField == nil {
+		return nil
+	}
+	var paths []string
+	list := mask.Get(pathsField).List()
+	for i := 0; i < list.Len(); i++ {
+		for _, p := range strings.Split(list.Get(i).String(), ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return paths
+}
+
+// resolvePath finds the field for an update_mask path, and returns it with the message that contains it.
+// Path segments can be proto or json names.  If the path goes into a map, we return a nil field descriptor.
+func resolvePath(msg protoreflect.Message, p string) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
+	tokens := strings.Split(p, ".")
+	for i, token := range tokens {
+		fields := msg.Descriptor().Fields()
+		fd := fields.ByName(protoreflect.Name(token))
+		if fd == nil {
+			fd = fields.ByJSONName(token)
+		}
+		if fd == nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "field %q not found in %v", token, msg.Descriptor().FullName())
+		}
+		if i == len(tokens)-1 {
+			return msg, fd, nil
+		}
+		if fd.IsMap() {
+			return nil, nil, nil
+		}
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "cannot traverse into field %q", token)
+		}
+		msg = msg.Get(fd).Message()
+	}
+	return nil, nil, nil
+}
+
+func fieldBehaviors(fd protoreflect.FieldDescriptor) map[annotations.FieldBehavior]bool {
+	behaviors := make(map[annotations.FieldBehavior]bool)
+	if fd.Options() == nil {
+		return behaviors
+	}
+	for _, behavior := range proto.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior) {
+		behaviors[behavior] = true
+	}
+	return behaviors
+}
+
+// checkRequestRequired checks that the REQUIRED top-level fields of the request are set.
+// The fields of the resource are checked separately, because some are assigned by the server.
+func checkRequestRequired(req protoreflect.Message) error {
+	fields := req.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !req.Has(fd) && fieldBehaviors(fd)[annotations.FieldBehavior_REQUIRED] {
+			return status.Errorf(codes.InvalidArgument, "field %q is required", fd.Name())
+		}
+	}
+	return nil
+}
+
+// checkRequired checks that the REQUIRED fields of msg are set, recursing into set messages.
+// The field at exceptPath (if any) is not checked.
+func checkRequired(msg protoreflect.Message, prefix string, exceptPath string) error {
+	return walkFields(msg, prefix, func(fd protoreflect.FieldDescriptor, fieldPath string, isSet bool) error {
+		if fieldPath == exceptPath {
+			return nil
+		}
+		if !isSet && fieldBehaviors(fd)[annotations.FieldBehavior_REQUIRED] {
+			return status.Errorf(codes.InvalidArgument, "field %q is required", fieldPath)
+		}
+		return nil
+	})
+}
+
+// checkOutputOnly checks that no OUTPUT_ONLY fields are set, recursing into set messages.
+// The name of the resource is often marked OUTPUT_ONLY, but identifies the resource, so is allowed.
+func checkOutputOnly(msg protoreflect.Message, prefix string) error {
+	return walkFields(msg, prefix, func(fd protoreflect.FieldDescriptor, fieldPath string, isSet bool) error {
+		if fieldPath == prefix+"name" {
+			return nil
+		}
+		if isSet && fieldBehaviors(fd)[annotations.FieldBehavior_OUTPUT_ONLY] {
+			return status.Errorf(codes.InvalidArgument, "field %q is output only and must not be set", fieldPath)
+		}
+		return nil
+	})
+}
+
+// walkFields calls fn for every field of msg, recursing into set (non-map) message fields.
+func walkFields(msg protoreflect.Message, prefix string, fn func(fd protoreflect.FieldDescriptor, fieldPath string, isSet bool) error) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := prefix + string(fd.Name())
+		isSet := msg.Has(fd)
+		if err := fn(fd, fieldPath, isSet); err != nil {
+			return err
+		}
+		if !isSet || fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+			continue
+		}
+		if strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf.") {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				if err := walkFields(list.Get(j).Message(), fieldPath+".", fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := walkFields(msg.Get(fd).Message(), fieldPath+".", fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
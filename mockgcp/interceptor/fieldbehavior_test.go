@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	secretmanagerpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/secretmanager/v1"
+	loggingpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/logging/v2"
+	pubsubpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func TestFieldBehaviorInterceptor(t *testing.T) {
+	grid := []struct {
+		name     string
+		method   string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{
+			name:   "create topic",
+			method: "/mockgcp.pubsub.v1.Publisher/CreateTopic",
+			req:    &pubsubpb.Topic{Name: "projects/p/topics/t"},
+		},
+		{
+			name:     "create topic with output only field",
+			method:   "/mockgcp.pubsub.v1.Publisher/CreateTopic",
+			req:      &pubsubpb.Topic{Name: "projects/p/topics/t", State: pubsubpb.Topic_ACTIVE},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "create secret",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/CreateSecret",
+			req: &secretmanagerpb.CreateSecretRequest{
+				Parent:   "projects/p",
+				SecretId: "s",
+				Secret:   &secretmanagerpb.Secret{Replication: &secretmanagerpb.Replication{}},
+			},
+		},
+		{
+			name:     "create secret without required field",
+			method:   "/mockgcp.cloud.secretmanager.v1.SecretManagerService/CreateSecret",
+			req:      &secretmanagerpb.CreateSecretRequest{Parent: "projects/p", Secret: &secretmanagerpb.Secret{}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "create secret with output only field",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/CreateSecret",
+			req: &secretmanagerpb.CreateSecretRequest{
+				Parent:   "projects/p",
+				SecretId: "s",
+				Secret:   &secretmanagerpb.Secret{CreateTime: timestamppb.Now()},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "update secret",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Labels: map[string]string{"a": "b"}},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"labels"}},
+			},
+		},
+		{
+			name:   "update secret immutable field",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Replication: &secretmanagerpb.Replication{}},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"replication"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "update secret immutable field without changing it",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Replication: automaticReplication()},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"replication", "labels"}},
+			},
+		},
+		{
+			name:   "replace secret",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Replication: automaticReplication(), Labels: map[string]string{"a": "b"}},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+		},
+		{
+			name:   "update sink without update_mask",
+			method: "/mockgcp.logging.v2.ConfigServiceV2/UpdateSink",
+			req: &loggingpb.UpdateSinkRequest{
+				SinkName: "projects/p/sinks/s",
+				Sink:     &loggingpb.LogSink{Filter: "severity>=ERROR"},
+			},
+		},
+		{
+			name:   "replace sink without required field",
+			method: "/mockgcp.logging.v2.ConfigServiceV2/UpdateSink",
+			req: &loggingpb.UpdateSinkRequest{
+				SinkName:   "projects/p/sinks/s",
+				Sink:       &loggingpb.LogSink{Filter: "severity>=ERROR"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "replace secret changing immutable field",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Replication: &secretmanagerpb.Replication{}},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "update secret unknown field",
+			method: "/mockgcp.cloud.secretmanager.v1.SecretManagerService/UpdateSecret",
+			req: &secretmanagerpb.UpdateSecretRequest{
+				Secret:     &secretmanagerpb.Secret{Name: "projects/p/secrets/s"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"notAField"}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "service not enabled",
+			method: "/mockgcp.pubsub.v1.Subscriber/CreateSubscription",
+			req:    &pubsubpb.Subscription{Name: "projects/p/subscriptions/s", State: pubsubpb.Subscription_ACTIVE},
+		},
+	}
+
+	store := storage.NewInMemoryStorage()
+	existing := &secretmanagerpb.Secret{Name: "projects/p/secrets/s", Replication: automaticReplication()}
+	if err := store.Create(context.Background(), existing.Name, existing); err != nil {
+		t.Fatalf("creating secret: %v", err)
+	}
+
+	i := NewFieldBehaviorInterceptor([]string{"mockgcp.pubsub.v1.Publisher", "mockgcp.cloud.secretmanager.*", "mockgcp.logging.v2.ConfigServiceV2"}, store)
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			info := &grpc.UnaryServerInfo{FullMethod: g.method}
+			_, err := i.UnaryServerInterceptor(context.Background(), g.req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return req, nil
+			})
+			if got := status.Code(err); got != g.wantCode {
+				t.Errorf("unexpected result, got %v (%v), want %v", got, err, g.wantCode)
+			}
+		})
+	}
+}
+
+func automaticReplication() *secretmanagerpb.Replication {
+	return &secretmanagerpb.Replication{Replication: &secretmanagerpb.Replication_Automatic_{Automatic: &secretmanagerpb.Replication_Automatic{}}}
+}
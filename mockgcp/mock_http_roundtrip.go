@@ -27,6 +27,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/operations"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/orgpolicy"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/workflows"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/interceptor"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockapigee"
@@ -81,6 +83,10 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockvpcaccess"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockworkflows"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockworkstations"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/auditlog"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/authz"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/faults"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/quota"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
 )
 
@@ -96,17 +102,93 @@ type Interface interface {
 
 	// We can dispatch test commands
 	SupportsTestCommands
+
+	// FaultInjector returns the fault injector, which tests can use to simulate GCP failures
+	FaultInjector() *faults.Injector
+
+	// OperationTiming returns the timing of long-running operations, which tests can use to delay or fail operations
+	OperationTiming() *operations.Timing
+
+	// Authorizer returns the authorizer, which tests can use to map tokens to principals and enforce IAM permissions
+	Authorizer() *authz.Authorizer
+
+	// Quotas returns the quota manager, which tests can use to set per-project quota limits
+	Quotas() *quota.Manager
+}
+
+// Options configures a mock GCP built by NewMockRoundTripperWithOptions.
+type Options struct {
+	// GRPCListenAddress is the address the grpc server listens on.
+	// By default we listen on a random port on 127.0.0.2.
+	GRPCListenAddress string
+
+	// FaultInjector injects faults into requests.
+	// If not set, we create an injector with no rules, which tests can configure via FaultInjector().
+	FaultInjector *faults.Injector
+
+	// OperationTiming controls how long long-running operations take.
+	// If not set, operations complete immediately (using the real clock), unless configured via OperationTiming().
+	OperationTiming *operations.Timing
+
+	// FieldBehaviorServices lists the grpc services (as path.Match patterns) for which we enforce the
+	// google.api.field_behavior annotations, e.g. mockgcp.pubsub.v1.*
+	// If nil, we read the list from the MOCKGCP_ENFORCE_FIELD_BEHAVIOR env var.
+	FieldBehaviorServices []string
+
+	// Authorizer authenticates requests and checks their IAM permissions.
+	// If not set, we create an authorizer with no principals, which allows all requests until configured via Authorizer().
+	Authorizer *authz.Authorizer
+
+	// Quotas simulates per-project quota limits.
+	// If not set, we create a manager with no limits, which allows all requests until configured via Quotas().
+	Quotas *quota.Manager
 }
 
 func NewMockRoundTripper(ctx context.Context, k8sClient client.Client, storage storage.Storage) (Interface, error) {
+	return NewMockRoundTripperWithOptions(ctx, k8sClient, storage, Options{})
+}
+
+func NewMockRoundTripperWithOptions(ctx context.Context, k8sClient client.Client, storage storage.Storage, options Options) (Interface, error) {
 	log := klog.FromContext(ctx)
 
-	mockRoundTripper := &mockRoundTripper{}
+	faultInjector := options.FaultInjector
+	if faultInjector == nil {
+		faultInjector = faults.NewInjector()
+	}
+
+	operationTiming := options.OperationTiming
+	if operationTiming == nil {
+		operationTiming = operations.NewTiming(nil)
+	}
+
+	fieldBehaviorServices := options.FieldBehaviorServices
+	if fieldBehaviorServices == nil {
+		fieldBehaviorServices = interceptor.FieldBehaviorServicesFromEnv()
+	}
+	fieldBehavior := interceptor.NewFieldBehaviorInterceptor(fieldBehaviorServices, storage)
+
+	authorizer := options.Authorizer
+	if authorizer == nil {
+		authorizer = authz.NewAuthorizer()
+	}
+
+	quotas := options.Quotas
+	if quotas == nil {
+		quotas = quota.NewManager(storage)
+	}
+
+	// Mutating calls are recorded as audit logs, which are served by mocklogging
+	auditLog := auditlog.NewRecorder(storage, authorizer)
+
+	mockRoundTripper := &mockRoundTripper{faults: faultInjector, operationTiming: operationTiming, authorizer: authorizer, auditLog: auditLog, quotas: quotas}
+	// Requests that mockgcp makes to itself are allowed by the authorizer
 	mockHTTPClient := &http.Client{
-		Transport: mockRoundTripper,
+		Transport: authz.InternalTransport(mockRoundTripper),
 	}
 	env := &common.MockEnvironment{
-		KubeClient: k8sClient,
+		KubeClient:  k8sClient,
+		OrgPolicies: orgpolicy.NewEvaluator(storage),
+		Quotas:      quotas,
 	}
 
 	workflowEngine, err := workflows.NewEngine(mockHTTPClient)
@@ -116,7 +198,7 @@ func NewMockRoundTripper(ctx context.Context, k8sClient client.Client, storage s
 	env.Workflows = workflowEngine
 
 	var serverOpts []grpc.ServerOption
-	serverOpts = append(serverOpts, grpc.UnaryInterceptor(interceptor.LabelValidationInterceptor))
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(faultInjector.UnaryServerInterceptor, authorizer.UnaryServerInterceptor, auditLog.UnaryServerInterceptor, quotas.UnaryServerInterceptor, operationTiming.UnaryServerInterceptor, fieldBehavior.UnaryServerInterceptor, interceptor.LabelValidationInterceptor))
 	server := grpc.NewServer(serverOpts...)
 
 	var services []mockgcpregistry.MockService
@@ -194,8 +276,12 @@ func NewMockRoundTripper(ctx context.Context, k8sClient client.Client, storage s
 
 	mockRoundTripper.server = server
 
-	// We listen on a random port on 127.0.0.2, to avoid conflicts with the webhook server which starts on a random port on "default" localhost
-	listener, err := net.Listen("tcp", "127.0.0.2:0")
+	// By default we listen on a random port on 127.0.0.2, to avoid conflicts with the webhook server which starts on a random port on "default" localhost
+	grpcListenAddress := options.GRPCListenAddress
+	if grpcListenAddress == "" {
+		grpcListenAddress = "127.0.0.2:0"
+	}
+	listener, err := net.Listen("tcp", grpcListenAddress)
 	if err != nil {
 		return nil, fmt.Errorf("net.Listen failed: %w", err)
 	}
@@ -228,7 +314,8 @@ func NewMockRoundTripper(ctx context.Context, k8sClient client.Client, storage s
 		})
 	}
 
-	mockRoundTripper.iamPolicies = newMockIAMPolicies()
+	mockRoundTripper.iamPolicies = newMockIAMPolicies(storage)
+	authorizer.SetPolicySource(mockRoundTripper.iamPolicies)
 
 	return mockRoundTripper, nil
 }
@@ -0,0 +1,370 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockasset
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/asset/v1"
+)
+
+// resourceType describes how a stored proto message appears in the asset inventory.
+type resourceType struct {
+	// Host is the API host, e.g. pubsub.googleapis.com
+	Host string
+	// AssetType is the asset type, e.g. pubsub.googleapis.com/Topic
+	AssetType string
+	// Version is the API version, e.g. v1
+	Version string
+}
+
+func newResourceType(host string, kind string, version string) resourceType {
+	return resourceType{Host: host, AssetType: host + "/" + kind, Version: version}
+}
+
+// resourceTypes maps the stored proto messages to their asset types.
+// The asset type doesn't always follow from the proto (e.g. a sql DatabaseInstance is a sqladmin.googleapis.com/Instance),
+// so only the types listed here appear in the asset inventory.
+var resourceTypes = map[protoreflect.FullName]resourceType{
+	"mockgcp.cloud.asset.v1.Feed": newResourceType("cloudasset.googleapis.com", "Feed", "v1"),
+
+	"mockgcp.cloud.compute.v1.Address":    newResourceType("compute.googleapis.com", "Address", "v1"),
+	"mockgcp.cloud.compute.v1.Disk":       newResourceType("compute.googleapis.com", "Disk", "v1"),
+	"mockgcp.cloud.compute.v1.Firewall":   newResourceType("compute.googleapis.com", "Firewall", "v1"),
+	"mockgcp.cloud.compute.v1.Instance":   newResourceType("compute.googleapis.com", "Instance", "v1"),
+	"mockgcp.cloud.compute.v1.Network":    newResourceType("compute.googleapis.com", "Network", "v1"),
+	"mockgcp.cloud.compute.v1.Subnetwork": newResourceType("compute.googleapis.com", "Subnetwork", "v1"),
+
+	"mockgcp.cloud.kms.v1.CryptoKey": newResourceType("cloudkms.googleapis.com", "CryptoKey", "v1"),
+	"mockgcp.cloud.kms.v1.KeyRing":   newResourceType("cloudkms.googleapis.com", "KeyRing", "v1"),
+
+	"mockgcp.cloud.resourcemanager.v3.Folder":   newResourceType("cloudresourcemanager.googleapis.com", "Folder", "v3"),
+	"mockgcp.cloud.resourcemanager.v3.Project":  newResourceType("cloudresourcemanager.googleapis.com", "Project", "v3"),
+	"mockgcp.cloud.resourcemanager.v3.TagKey":   newResourceType("cloudresourcemanager.googleapis.com", "TagKey", "v3"),
+	"mockgcp.cloud.resourcemanager.v3.TagValue": newResourceType("cloudresourcemanager.googleapis.com", "TagValue", "v3"),
+
+	"mockgcp.cloud.secretmanager.v1.Secret":        newResourceType("secretmanager.googleapis.com", "Secret", "v1"),
+	"mockgcp.cloud.secretmanager.v1.SecretVersion": newResourceType("secretmanager.googleapis.com", "SecretVersion", "v1"),
+
+	"mockgcp.cloud.sql.v1beta4.DatabaseInstance": newResourceType("sqladmin.googleapis.com", "Instance", "v1beta4"),
+
+	"mockgcp.container.v1beta1.Cluster":  newResourceType("container.googleapis.com", "Cluster", "v1beta1"),
+	"mockgcp.container.v1beta1.NodePool": newResourceType("container.googleapis.com", "NodePool", "v1beta1"),
+
+	"mockgcp.iam.admin.v1.Role":           newResourceType("iam.googleapis.com", "Role", "v1"),
+	"mockgcp.iam.admin.v1.ServiceAccount": newResourceType("iam.googleapis.com", "ServiceAccount", "v1"),
+
+	"mockgcp.logging.v2.LogBucket": newResourceType("logging.googleapis.com", "LogBucket", "v2"),
+	"mockgcp.logging.v2.LogMetric": newResourceType("logging.googleapis.com", "LogMetric", "v2"),
+	"mockgcp.logging.v2.LogSink":   newResourceType("logging.googleapis.com", "LogSink", "v2"),
+
+	"mockgcp.pubsub.v1.Schema":       newResourceType("pubsub.googleapis.com", "Schema", "v1"),
+	"mockgcp.pubsub.v1.Subscription": newResourceType("pubsub.googleapis.com", "Subscription", "v1"),
+	"mockgcp.pubsub.v1.Topic":        newResourceType("pubsub.googleapis.com", "Topic", "v1"),
+
+	"mockgcp.storage.v1.Bucket": newResourceType("storage.googleapis.com", "Bucket", "v1"),
+}
+
+// resourceNameOf returns the relative resource name of a stored object, e.g. projects/p/topics/t
+func resourceNameOf(fqn string, obj proto.Message) string {
+	// GCS buckets have a global namespace, so the asset name is just the bucket name
+	if obj.ProtoReflect().Descriptor().FullName() == "mockgcp.storage.v1.Bucket" {
+		return strings.TrimPrefix(fqn, "buckets/")
+	}
+	// Prefer the name of the resource (for example, projects are stored by id but named by number)
+	if name := fields.StringField(obj.ProtoReflect(), "name"); strings.Contains(name, "/") {
+		return name
+	}
+	return fqn
+}
+
+// inventoryAsset is a resource in the asset inventory, with the information we need to search and export it.
+type inventoryAsset struct {
+	Asset *pb.Asset
+
+	// Object is the stored object
+	Object proto.Message
+
+	// Project is the project number that contains the resource, as projects/<number>
+	Project string
+	// Folders holds the folders that contain the resource, nearest first
+	Folders []string
+	// Organization is the organization that contains the resource, as organizations/<number>
+	Organization string
+}
+
+// inventory builds the asset inventory from everything in mockgcp storage.
+// Only assets under scope (e.g. projects/p, folders/123 or organizations/456) and matching assetTypes are returned.
+func (s *MockService) inventory(ctx context.Context, scope string, assetTypes []string, readTime time.Time) ([]*inventoryAsset, error) {
+	matchers, err := compileAssetTypes(assetTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hierarchy.New(s.storage)
+	scopes, err := h.Aliases(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.storage.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []*inventoryAsset
+	for i := range snapshot.Objects {
+		o := &snapshot.Objects[i]
+		resourceType, ok := resourceTypes[protoreflect.FullName(o.Type)]
+		if !ok || !matchesAny(matchers, resourceType.AssetType) {
+			continue
+		}
+		obj, err := o.Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		resourceName := resourceNameOf(o.Name, obj)
+		ancestors, err := s.assetAncestors(ctx, h, resourceName, obj)
+		if err != nil {
+			return nil, err
+		}
+		if !inScope(ancestors, scopes) {
+			continue
+		}
+
+		data := &structpb.Struct{}
+		if err := protojson.Unmarshal(o.Object, data); err != nil {
+			return nil, fmt.Errorf("converting %v %q to struct: %w", o.Type, o.Name, err)
+		}
+
+		asset := &pb.Asset{
+			Name:       "//" + resourceType.Host + "/" + resourceName,
+			AssetType:  resourceType.AssetType,
+			Ancestors:  ancestors,
+			UpdateTime: updateTimeOf(obj, readTime),
+			Resource: &pb.Resource{
+				Version:              resourceType.Version,
+				DiscoveryDocumentUri: "https://" + resourceType.Host + "/$discovery/rest?version=" + resourceType.Version,
+				DiscoveryName:        string(obj.ProtoReflect().Descriptor().Name()),
+				Data:                 data,
+				Location:             locationOf(resourceName, obj),
+			},
+		}
+
+		result := &inventoryAsset{Asset: asset, Object: obj}
+		for _, ancestor := range ancestors {
+			switch {
+			case strings.HasPrefix(ancestor, "projects/") && result.Project == "":
+				result.Project = ancestor
+			case strings.HasPrefix(ancestor, "folders/"):
+				result.Folders = append(result.Folders, ancestor)
+			case strings.HasPrefix(ancestor, "organizations/"):
+				result.Organization = ancestor
+			}
+		}
+		if parent := parentOf(ancestors, resourceName); parent != "" {
+			asset.Resource.Parent = "//cloudresourcemanager.googleapis.com/" + parent
+		}
+		assets = append(assets, result)
+	}
+	return assets, nil
+}
+
+// assetAncestors returns the projects, folders and organizations containing the resource, nearest first,
+// with projects named by number (as in the asset inventory), e.g. projects/123, folders/456, organizations/789
+func (s *MockService) assetAncestors(ctx context.Context, h *hierarchy.Hierarchy, resourceName string, obj proto.Message) ([]string, error) {
+	start := resourceName
+	if !isHierarchyName(resourceName) && !isHierarchyName(firstTwo(resourceName)) {
+		// Some resources (e.g. GCS buckets) are not named under their project
+		projectNumber := projectNumberField(obj)
+		if projectNumber == "" {
+			return nil, nil
+		}
+		start = "projects/" + projectNumber
+	}
+
+	all, err := h.Ancestors(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+
+	var ancestors []string
+	for _, ancestor := range all {
+		if !isHierarchyName(ancestor) {
+			continue
+		}
+		if projectIDOrNumber, ok := strings.CutPrefix(ancestor, "projects/"); ok {
+			project, err := h.GetProject(ctx, projectIDOrNumber)
+			if err != nil {
+				return nil, err
+			}
+			if project != nil {
+				ancestor = project.GetName()
+			}
+		}
+		ancestors = append(ancestors, ancestor)
+	}
+	return ancestors, nil
+}
+
+// isHierarchyName returns true if name is a project, folder or organization, e.g. projects/p
+func isHierarchyName(name string) bool {
+	tokens := strings.Split(name, "/")
+	if len(tokens) != 2 {
+		return false
+	}
+	switch tokens[0] {
+	case "projects", "folders", "organizations":
+		return true
+	}
+	return false
+}
+
+func firstTwo(name string) string {
+	tokens := strings.Split(name, "/")
+	if len(tokens) < 2 {
+		return name
+	}
+	return tokens[0] + "/" + tokens[1]
+}
+
+// projectNumberField returns the value of a project_number field, or "".
+func projectNumberField(obj proto.Message) string {
+	msg := obj.ProtoReflect()
+	field := msg.Descriptor().Fields().ByName("project_number")
+	if field == nil || !msg.Has(field) {
+		return ""
+	}
+	return fmt.Sprint(msg.Get(field).Interface())
+}
+
+// parentOf returns the nearest project, folder or organization that is not the resource itself.
+func parentOf(ancestors []string, resourceName string) string {
+	for _, ancestor := range ancestors {
+		if ancestor != resourceName {
+			return ancestor
+		}
+	}
+	return ""
+}
+
+// inScope returns true if any of the ancestors is one of the names of the scope.
+func inScope(ancestors []string, scopes []string) bool {
+	for _, ancestor := range ancestors {
+		for _, scope := range scopes {
+			if ancestor == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compileAssetTypes parses the asset_types of a request, which are either exact asset types or RE2 regular expressions.
+func compileAssetTypes(assetTypes []string) ([]*regexp.Regexp, error) {
+	var matchers []*regexp.Regexp
+	for _, assetType := range assetTypes {
+		r, err := regexp.Compile("^(?:" + assetType + ")$")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid asset type %q: %v", assetType, err)
+		}
+		matchers = append(matchers, r)
+	}
+	return matchers, nil
+}
+
+// matchesAny returns true if the asset type matches any of the matchers, or if there are no matchers.
+func matchesAny(matchers []*regexp.Regexp, assetType string) bool {
+	if len(matchers) == 0 {
+		return true
+	}
+	for _, matcher := range matchers {
+		if matcher.MatchString(assetType) {
+			return true
+		}
+	}
+	return false
+}
+
+// locationOf returns the location of the resource, from its name (e.g. projects/p/locations/us-central1/...)
+// or a location field, defaulting to global.
+func locationOf(resourceName string, obj proto.Message) string {
+	tokens := strings.Split(resourceName, "/")
+	for i := 0; i+1 < len(tokens); i += 2 {
+		switch tokens[i] {
+		case "locations", "regions", "zones":
+			return tokens[i+1]
+		}
+	}
+	if location := fields.StringField(obj.ProtoReflect(), "location"); location != "" {
+		return strings.ToLower(location)
+	}
+	return "global"
+}
+
+// updateTimeOf returns the last update time of the resource, if it records one, otherwise the read time.
+func updateTimeOf(obj proto.Message, readTime time.Time) *timestamppb.Timestamp {
+	if t := timestampField(obj, "update_time", "updated", "create_time", "time_created"); t != nil {
+		return t
+	}
+	return timestamppb.New(readTime)
+}
+
+// timestampField returns the value of the first set timestamp field, or nil.
+func timestampField(obj proto.Message, names ...protoreflect.Name) *timestamppb.Timestamp {
+	msg := obj.ProtoReflect()
+	for _, name := range names {
+		field := msg.Descriptor().Fields().ByName(name)
+		if field == nil || field.Message() == nil || !msg.Has(field) {
+			continue
+		}
+		if t, ok := msg.Get(field).Message().Interface().(*timestamppb.Timestamp); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// encodeOffset and decodeOffset implement page tokens for searches, which span all the stored types.
+func encodeOffset(offset int) string {
+	return strconv.Itoa(offset)
+}
+
+func decodeOffset(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page_token %q", token)
+	}
+	return offset, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockasset
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/gcsobjects"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/asset/v1"
+	crmpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	sqlpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/sql/v1beta4"
+	pubsubpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	storagepb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/storage/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func newTestService(t *testing.T) (*AssetService, storage.Storage) {
+	ctx := context.Background()
+	store := storage.NewInMemoryStorage()
+
+	objects := map[string]proto.Message{
+		"folders/100":                     &crmpb.Folder{Name: "folders/100", Parent: "organizations/1"},
+		"projects/my-project":             &crmpb.Project{Name: "projects/123", ProjectId: "my-project", Parent: "folders/100"},
+		"projects/other-project":          &crmpb.Project{Name: "projects/456", ProjectId: "other-project", Parent: "organizations/1"},
+		"projects/my-project/topics/a":    &pubsubpb.Topic{Name: "projects/my-project/topics/a", Labels: map[string]string{"env": "prod"}},
+		"projects/my-project/topics/b":    &pubsubpb.Topic{Name: "projects/my-project/topics/b", Labels: map[string]string{"env": "dev"}},
+		"projects/other-project/topics/c": &pubsubpb.Topic{Name: "projects/other-project/topics/c"},
+		"buckets/export-bucket":           &storagepb.Bucket{Name: proto.String("export-bucket"), Location: proto.String("US"), ProjectNumber: proto.Uint64(123)},
+	}
+	for fqn, obj := range objects {
+		if err := store.Create(ctx, fqn, obj); err != nil {
+			t.Fatalf("creating %q: %v", fqn, err)
+		}
+	}
+
+	return &AssetService{MockService: New(&common.MockEnvironment{}, store)}, store
+}
+
+func TestResourceTypes(t *testing.T) {
+	grid := []struct {
+		msg  proto.Message
+		want string
+	}{
+		{msg: &pubsubpb.Topic{}, want: "pubsub.googleapis.com/Topic"},
+		{msg: &crmpb.Project{}, want: "cloudresourcemanager.googleapis.com/Project"},
+		{msg: &storagepb.Bucket{}, want: "storage.googleapis.com/Bucket"},
+		{msg: &sqlpb.DatabaseInstance{}, want: "sqladmin.googleapis.com/Instance"},
+		{msg: &pb.Feed{}, want: "cloudasset.googleapis.com/Feed"},
+		{msg: &storagepb.Object{}, want: ""},
+		{msg: &sqlpb.Database{}, want: ""},
+	}
+	for _, g := range grid {
+		got := resourceTypes[g.msg.ProtoReflect().Descriptor().FullName()]
+		if got.AssetType != g.want {
+			t.Errorf("asset type of %T is %q, want %q", g.msg, got.AssetType, g.want)
+		}
+	}
+}
+
+func TestExportAssets(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t)
+
+	assets, err := s.inventory(ctx, "projects/my-project", []string{"pubsub.googleapis.com/.*", "storage.googleapis.com/Bucket"}, time.Now())
+	if err != nil {
+		t.Fatalf("building inventory: %v", err)
+	}
+	var names []string
+	for _, asset := range assets {
+		names = append(names, asset.Asset.GetName())
+	}
+	want := []string{
+		"//pubsub.googleapis.com/projects/my-project/topics/a",
+		"//pubsub.googleapis.com/projects/my-project/topics/b",
+		"//storage.googleapis.com/export-bucket",
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected assets %v, want %v", names, want)
+	}
+
+	topic := assets[0].Asset
+	if got, want := strings.Join(topic.GetAncestors(), ","), "projects/123,folders/100,organizations/1"; got != want {
+		t.Errorf("unexpected ancestors %q, want %q", got, want)
+	}
+	if got := topic.GetResource().GetData().GetFields()["labels"].GetStructValue().GetFields()["env"].GetStringValue(); got != "prod" {
+		t.Errorf("unexpected resource data %v", topic.GetResource().GetData())
+	}
+	if got := assets[2].Asset.GetResource().GetLocation(); got != "us" {
+		t.Errorf("unexpected bucket location %q", got)
+	}
+
+	// The whole folder includes my-project, but not other-project
+	folderAssets, err := s.inventory(ctx, "folders/100", []string{"pubsub.googleapis.com/Topic"}, time.Now())
+	if err != nil {
+		t.Fatalf("building inventory: %v", err)
+	}
+	if len(folderAssets) != 2 {
+		t.Errorf("expected 2 topics in folder, got %d", len(folderAssets))
+	}
+
+	// The export is written to mock GCS as newline-delimited JSON, using proto field names
+	req := &pb.ExportAssetsRequest{
+		Parent:      "projects/my-project",
+		AssetTypes:  []string{"pubsub.googleapis.com/Topic"},
+		ContentType: pb.ContentType_RESOURCE,
+		OutputConfig: &pb.OutputConfig{
+			Destination: &pb.OutputConfig_GcsDestination{
+				GcsDestination: &pb.GcsDestination{ObjectUri: &pb.GcsDestination_Uri{Uri: "gs://export-bucket/export.json"}},
+			},
+		},
+	}
+	if _, err := s.ExportAssets(ctx, req); err != nil {
+		t.Fatalf("ExportAssets failed: %v", err)
+	}
+
+	var contents []byte
+	for i := 0; i < 100; i++ {
+		body, err := gcsobjects.Read(ctx, store, "export-bucket", "export.json")
+		if err == nil {
+			contents = body.GetData()
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines in export, got %q", contents)
+	}
+	var line map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatalf("parsing export line %q: %v", lines[0], err)
+	}
+	if line["asset_type"] != "pubsub.googleapis.com/Topic" || line["resource"] == nil {
+		t.Errorf("unexpected export line %q", lines[0])
+	}
+}
+
+func TestSearchAllResources(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t)
+
+	grid := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"//pubsub.googleapis.com/projects/my-project/topics/a", "//pubsub.googleapis.com/projects/my-project/topics/b"}},
+		{query: "labels.env=prod", want: []string{"//pubsub.googleapis.com/projects/my-project/topics/a"}},
+		{query: "name:topics/b", want: []string{"//pubsub.googleapis.com/projects/my-project/topics/b"}},
+		{query: "labels.owner:*", want: nil},
+	}
+	for _, g := range grid {
+		response, err := s.SearchAllResources(ctx, &pb.SearchAllResourcesRequest{
+			Scope:      "projects/123",
+			Query:      g.query,
+			AssetTypes: []string{"pubsub.googleapis.com/Topic"},
+		})
+		if err != nil {
+			t.Fatalf("SearchAllResources(%q) failed: %v", g.query, err)
+		}
+		var got []string
+		for _, result := range response.GetResults() {
+			got = append(got, result.GetName())
+		}
+		if strings.Join(got, ",") != strings.Join(g.want, ",") {
+			t.Errorf("SearchAllResources(%q) = %v, want %v", g.query, got, g.want)
+		}
+	}
+
+	// Paging
+	page1, err := s.SearchAllResources(ctx, &pb.SearchAllResourcesRequest{Scope: "organizations/1", AssetTypes: []string{"pubsub.googleapis.com/Topic"}, PageSize: 2})
+	if err != nil {
+		t.Fatalf("SearchAllResources failed: %v", err)
+	}
+	if len(page1.GetResults()) != 2 || page1.GetNextPageToken() == "" {
+		t.Fatalf("unexpected first page %v", page1)
+	}
+	page2, err := s.SearchAllResources(ctx, &pb.SearchAllResourcesRequest{Scope: "organizations/1", AssetTypes: []string{"pubsub.googleapis.com/Topic"}, PageSize: 2, PageToken: page1.GetNextPageToken()})
+	if err != nil {
+		t.Fatalf("SearchAllResources failed: %v", err)
+	}
+	if len(page2.GetResults()) != 1 || page2.GetNextPageToken() != "" {
+		t.Errorf("unexpected second page %v", page2)
+	}
+	if got := page2.GetResults()[0].GetProject(); got != "projects/456" {
+		t.Errorf("unexpected project %q", got)
+	}
+}
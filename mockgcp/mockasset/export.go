@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockasset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	longrunning "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/gcsobjects"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/asset/v1"
+)
+
+// ExportAssets writes the assets under the parent to a GCS object (in mockstorage), as newline-delimited JSON.
+// Only the metadata-only and RESOURCE content types are supported.
+func (s *AssetService) ExportAssets(ctx context.Context, req *pb.ExportAssetsRequest) (*longrunning.Operation, error) {
+	if req.GetParent() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "parent is required")
+	}
+
+	switch req.GetContentType() {
+	case pb.ContentType_CONTENT_TYPE_UNSPECIFIED, pb.ContentType_RESOURCE:
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "content_type %v is not supported by mockgcp", req.GetContentType())
+	}
+
+	if req.GetOutputConfig().GetBigqueryDestination() != nil {
+		return nil, status.Errorf(codes.Unimplemented, "bigquery_destination is not supported by mockgcp")
+	}
+	if req.GetOutputConfig().GetGcsDestination().GetUriPrefix() != "" {
+		return nil, status.Errorf(codes.Unimplemented, "gcs_destination.uri_prefix is not supported by mockgcp")
+	}
+	uri := req.GetOutputConfig().GetGcsDestination().GetUri()
+	if uri == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "output_config.gcs_destination.uri is required")
+	}
+	bucket, object, err := gcsobjects.ParseGCSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	readTime := time.Now()
+	if req.GetReadTime() != nil {
+		readTime = req.GetReadTime().AsTime()
+	}
+
+	metadata := proto.Clone(req).(*pb.ExportAssetsRequest)
+	return s.operations.StartLRO(ctx, req.GetParent(), metadata, func() (proto.Message, error) {
+		assets, err := s.inventory(ctx, req.GetParent(), req.GetAssetTypes(), readTime)
+		if err != nil {
+			return nil, err
+		}
+
+		var out bytes.Buffer
+		for _, asset := range assets {
+			line := proto.Clone(asset.Asset).(*pb.Asset)
+			if req.GetContentType() != pb.ContentType_RESOURCE {
+				line.Resource = nil
+			}
+			b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(line)
+			if err != nil {
+				return nil, fmt.Errorf("serializing asset %q: %w", line.GetName(), err)
+			}
+			// protojson does not guarantee single-line output
+			if err := json.Compact(&out, b); err != nil {
+				return nil, fmt.Errorf("serializing asset %q: %w", line.GetName(), err)
+			}
+			out.WriteString("\n")
+		}
+
+		if _, err := gcsobjects.Write(ctx, s.storage, bucket, object, "application/json", out.Bytes()); err != nil {
+			return nil, err
+		}
+
+		return &pb.ExportAssetsResponse{
+			ReadTime:     timestamppb.New(readTime),
+			OutputConfig: req.GetOutputConfig(),
+			OutputResult: &pb.OutputResult{
+				Result: &pb.OutputResult_GcsResult{
+					GcsResult: &pb.GcsOutputResult{Uris: []string{uri}},
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockasset
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/asset/v1"
+)
+
+const (
+	defaultSearchPageSize = 100
+	maxSearchPageSize     = 500
+)
+
+// SearchAllResources searches the assets under the scope.
+// We support a subset of the query syntax: space-separated terms (which are ANDed),
+// each either free text or <field>:<value> (contains) or <field>=<value> (equals),
+// for the fields name, displayName, description, location, state, project and labels.<key>.
+func (s *AssetService) SearchAllResources(ctx context.Context, req *pb.SearchAllResourcesRequest) (*pb.SearchAllResourcesResponse, error) {
+	if req.GetScope() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "scope is required")
+	}
+
+	terms, err := parseSearchQuery(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	offset, err := decodeOffset(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.inventory(ctx, req.GetScope(), req.GetAssetTypes(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*pb.ResourceSearchResult
+	for _, asset := range assets {
+		result := searchResultOf(asset)
+		if matchesQuery(result, terms) {
+			results = append(results, result)
+		}
+	}
+
+	response := &pb.SearchAllResourcesResponse{}
+	if offset < len(results) {
+		end := offset + pageSize
+		if end < len(results) {
+			response.NextPageToken = encodeOffset(end)
+		} else {
+			end = len(results)
+		}
+		response.Results = results[offset:end]
+	}
+	return response, nil
+}
+
+// searchResultOf builds the search result for an asset.
+func searchResultOf(asset *inventoryAsset) *pb.ResourceSearchResult {
+	msg := asset.Object.ProtoReflect()
+
+	result := &pb.ResourceSearchResult{
+		Name:         asset.Asset.GetName(),
+		AssetType:    asset.Asset.GetAssetType(),
+		Project:      asset.Project,
+		Folders:      asset.Folders,
+		Organization: asset.Organization,
+		DisplayName:  fields.StringField(msg, "display_name", "friendly_name"),
+		Description:  fields.StringField(msg, "description"),
+		Location:     asset.Asset.GetResource().GetLocation(),
+		Labels:       labelsOf(msg),
+		CreateTime:   timestampField(asset.Object, "create_time", "time_created"),
+		UpdateTime:   asset.Asset.GetUpdateTime(),
+		State:        stateOf(msg),
+	}
+	if parent := asset.Asset.GetResource().GetParent(); parent != "" {
+		result.ParentFullResourceName = parent
+		switch {
+		case strings.Contains(parent, "/projects/"):
+			result.ParentAssetType = "cloudresourcemanager.googleapis.com/Project"
+		case strings.Contains(parent, "/folders/"):
+			result.ParentAssetType = "cloudresourcemanager.googleapis.com/Folder"
+		case strings.Contains(parent, "/organizations/"):
+			result.ParentAssetType = "cloudresourcemanager.googleapis.com/Organization"
+		}
+	}
+	if result.DisplayName == "" {
+		result.DisplayName = lastToken(result.Name)
+	}
+	return result
+}
+
+// labelsOf returns the labels of the resource, if it has a labels field.
+func labelsOf(msg protoreflect.Message) map[string]string {
+	field := msg.Descriptor().Fields().ByName("labels")
+	if field == nil || !field.IsMap() || field.MapValue().Kind() != protoreflect.StringKind {
+		return nil
+	}
+	labels := make(map[string]string)
+	msg.Get(field).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		labels[k.String()] = v.String()
+		return true
+	})
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// stateOf returns the state of the resource, if it has a state field.
+func stateOf(msg protoreflect.Message) string {
+	field := msg.Descriptor().Fields().ByName("state")
+	if field == nil || !msg.Has(field) {
+		return ""
+	}
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByNumber(msg.Get(field).Enum()); value != nil {
+			return string(value.Name())
+		}
+	case protoreflect.StringKind:
+		return msg.Get(field).String()
+	}
+	return ""
+}
+
+func lastToken(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// searchTerm is a single term of a search query.
+type searchTerm struct {
+	// Field is the field to match, or "" for free text
+	Field string
+	// Exact is true for field=value, false for field:value
+	Exact bool
+	Value string
+}
+
+func parseSearchQuery(query string) ([]searchTerm, error) {
+	var terms []searchTerm
+	for _, token := range strings.Fields(query) {
+		switch token {
+		case "AND":
+			continue
+		case "OR", "NOT":
+			return nil, status.Errorf(codes.Unimplemented, "query operator %s is not supported by mockgcp", token)
+		}
+
+		term := searchTerm{}
+		if i := strings.IndexAny(token, ":="); i != -1 {
+			term.Field = token[:i]
+			term.Exact = token[i] == '='
+			term.Value = token[i+1:]
+		} else {
+			term.Value = token
+		}
+		term.Value = strings.Trim(term.Value, `"`)
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// matchesQuery returns true if the result matches all the terms.
+func matchesQuery(result *pb.ResourceSearchResult, terms []searchTerm) bool {
+	for _, term := range terms {
+		var values []string
+		switch {
+		case term.Field == "":
+			values = []string{result.GetName(), result.GetDisplayName(), result.GetDescription(), result.GetLocation()}
+			for _, v := range result.GetLabels() {
+				values = append(values, v)
+			}
+		case term.Field == "name":
+			values = []string{result.GetName()}
+		case term.Field == "displayName":
+			values = []string{result.GetDisplayName()}
+		case term.Field == "description":
+			values = []string{result.GetDescription()}
+		case term.Field == "location":
+			values = []string{result.GetLocation()}
+		case term.Field == "state":
+			values = []string{result.GetState()}
+		case term.Field == "project":
+			values = []string{result.GetProject()}
+		case term.Field == "labels":
+			for k, v := range result.GetLabels() {
+				values = append(values, k, v)
+			}
+		case strings.HasPrefix(term.Field, "labels."):
+			if v, ok := result.GetLabels()[strings.TrimPrefix(term.Field, "labels.")]; ok {
+				values = []string{v}
+			}
+		default:
+			return false
+		}
+
+		if !matchesTerm(term, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTerm(term searchTerm, values []string) bool {
+	want := strings.ToLower(term.Value)
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if term.Exact && value == want {
+			return true
+		}
+		if !term.Exact && (want == "*" || strings.Contains(value, strings.Trim(want, "*"))) {
+			return true
+		}
+	}
+	return false
+}
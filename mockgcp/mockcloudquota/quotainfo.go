@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +tool:mockgcp-support
+// proto.service: google.api.cloudquotas.v1.CloudQuotas
+// proto.message: google.api.cloudquotas.v1.QuotaInfo
+
+package mockcloudquota
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/api/cloudquotas/v1beta"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/quota"
+)
+
+// QuotaInfos are served from the simulated quota limits (see pkg/quota), which tests configure.
+
+func (s *CloudQuotasV1) GetQuotaInfo(ctx context.Context, req *pb.GetQuotaInfoRequest) (*pb.QuotaInfo, error) {
+	name, err := s.parseQuotaInfoName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	limit, found := s.Quotas.GetLimit(name.Service, name.QuotaID)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "Resource '%s' was not found", name.String())
+	}
+	return s.buildQuotaInfo(ctx, name.servicePath(), limit)
+}
+
+func (s *CloudQuotasV1) ListQuotaInfos(ctx context.Context, req *pb.ListQuotaInfosRequest) (*pb.ListQuotaInfosResponse, error) {
+	name, err := s.parseQuotaInfoName(req.GetParent() + "/quotaInfos/-")
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListQuotaInfosResponse{}
+	for _, limit := range s.Quotas.Limits(name.Service) {
+		info, err := s.buildQuotaInfo(ctx, name.servicePath(), limit)
+		if err != nil {
+			return nil, err
+		}
+		response.QuotaInfos = append(response.QuotaInfos, info)
+	}
+	return response, nil
+}
+
+func (s *MockService) buildQuotaInfo(ctx context.Context, servicePath string, limit quota.Limit) (*pb.QuotaInfo, error) {
+	project := strings.TrimPrefix(strings.Split(servicePath, "/locations/")[0], "projects/")
+	value, err := s.Quotas.EffectiveValue(ctx, project, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &pb.QuotaInfo{
+		Name:              servicePath + "/quotaInfos/" + limit.QuotaID,
+		QuotaId:           limit.QuotaID,
+		Metric:            limit.Metric,
+		Service:           limit.Service,
+		IsPrecise:         true,
+		ContainerType:     pb.QuotaInfo_PROJECT,
+		MetricDisplayName: limit.DisplayName,
+		QuotaDisplayName:  limit.DisplayName,
+		MetricUnit:        limit.Unit(),
+		QuotaIncreaseEligibility: &pb.QuotaIncreaseEligibility{
+			IsEligible: true,
+		},
+		DimensionsInfos: []*pb.DimensionsInfo{
+			{
+				Details:             &pb.QuotaDetails{Value: value},
+				ApplicableLocations: []string{"global"},
+			},
+		},
+	}
+	if limit.Type == quota.Rate {
+		info.RefreshInterval = "minute"
+	}
+	return info, nil
+}
+
+type quotaInfoName struct {
+	Project  string
+	Location string
+	Service  string
+	QuotaID  string
+}
+
+func (n *quotaInfoName) servicePath() string {
+	return "projects/" + n.Project + "/locations/" + n.Location + "/services/" + n.Service
+}
+
+func (n *quotaInfoName) String() string {
+	return n.servicePath() + "/quotaInfos/" + n.QuotaID
+}
+
+// parseQuotaInfoName parses a string into a quotaInfoName.
+// The expected form is `projects/*/locations/*/services/*/quotaInfos/*`.
+// We only support projects, because our quota limits are per-project.
+func (s *MockService) parseQuotaInfoName(name string) (*quotaInfoName, error) {
+	tokens := strings.Split(name, "/")
+
+	if len(tokens) == 8 && tokens[0] == "projects" && tokens[2] == "locations" && tokens[4] == "services" && tokens[6] == "quotaInfos" {
+		return &quotaInfoName{
+			Project:  tokens[1],
+			Location: tokens[3],
+			Service:  tokens[5],
+			QuotaID:  tokens[7],
+		}, nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "name %q is not valid", name)
+}
@@ -73,6 +73,9 @@ func (s *CloudQuotasV1) CreateQuotaPreference(ctx context.Context, req *pb.Creat
 	if err := s.storage.Create(ctx, fqn, obj); err != nil {
 		return nil, err
 	}
+	if err := s.applyQuotaPreference(ctx, obj); err != nil {
+		return nil, err
+	}
 
 	return obj, nil
 }
@@ -126,9 +129,25 @@ func (s *CloudQuotasV1) UpdateQuotaPreference(ctx context.Context, req *pb.Updat
 	if err := s.storage.Update(ctx, fqn, obj); err != nil {
 		return nil, err
 	}
+	if err := s.applyQuotaPreference(ctx, obj); err != nil {
+		return nil, err
+	}
 	return obj, nil
 }
 
+// applyQuotaPreference sets the granted value as the project's limit, if we simulate the quota.
+func (s *MockService) applyQuotaPreference(ctx context.Context, obj *pb.QuotaPreference) error {
+	project, ok := strings.CutPrefix(obj.GetName(), "projects/")
+	if !ok {
+		return nil
+	}
+	project, _, _ = strings.Cut(project, "/")
+	if _, found := s.Quotas.GetLimit(obj.GetService(), obj.GetQuotaId()); !found {
+		return nil
+	}
+	return s.Quotas.SetOverride(ctx, project, obj.GetService(), obj.GetQuotaId(), obj.GetQuotaConfig().GetGrantedValue().GetValue())
+}
+
 type quotaPreferenceName struct {
 	Parent              string
 	QuotaPreferenceName string
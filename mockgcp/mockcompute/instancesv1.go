@@ -59,6 +59,14 @@ func (s *InstancesV1) Insert(ctx context.Context, req *pb.InsertInstanceRequest)
 
 	id := s.generateID()
 
+	// The constraint allows or denies external IPs for the whole instance, so we evaluate it once
+	externalIPErr := s.OrgPolicies.CheckListValue(ctx, "projects/"+name.Project.ID, "compute.vmExternalIpAccess", fqn)
+	for _, networkInterface := range req.GetInstanceResource().GetNetworkInterfaces() {
+		if len(networkInterface.GetAccessConfigs()) != 0 && externalIPErr != nil {
+			return nil, externalIPErr
+		}
+	}
+
 	obj := proto.Clone(req.GetInstanceResource()).(*pb.Instance)
 	obj.SelfLink = PtrTo(buildComputeSelfLink(ctx, fqn))
 	obj.CreationTimestamp = PtrTo(s.nowString())
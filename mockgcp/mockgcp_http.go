@@ -29,11 +29,18 @@ import (
 	"testing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/operations"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockgcpregistry"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/auditlog"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/authz"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/faults"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/quota"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
 )
 
@@ -43,6 +50,12 @@ type mockRoundTripper struct {
 
 	iamPolicies *mockIAMPolicies
 
+	faults          *faults.Injector
+	operationTiming *operations.Timing
+	authorizer      *authz.Authorizer
+	auditLog        *auditlog.Recorder
+	quotas          *quota.Manager
+
 	registeredServices *mockgcpregistry.Services
 
 	services []registeredService
@@ -107,6 +120,22 @@ func (m *mockRoundTripper) Run(ctx context.Context) error {
 	return m.server.Serve(m.grpcListener)
 }
 
+func (m *mockRoundTripper) FaultInjector() *faults.Injector {
+	return m.faults
+}
+
+func (m *mockRoundTripper) OperationTiming() *operations.Timing {
+	return m.operationTiming
+}
+
+func (m *mockRoundTripper) Authorizer() *authz.Authorizer {
+	return m.authorizer
+}
+
+func (m *mockRoundTripper) Quotas() *quota.Manager {
+	return m.quotas
+}
+
 func (m *mockRoundTripper) RunTestCommand(ctx context.Context, serviceName string, command string) error {
 	for _, service := range m.services {
 		if _, match := service.MatchesHost(serviceName); !match {
@@ -251,11 +280,19 @@ func (m *mockRoundTripper) roundTripIAMPolicy(req *http.Request) (*http.Response
 
 	requestPath = strings.TrimSuffix(requestPath, ":"+verb)
 
+	// These verbs don't go through grpc, so we apply the fault rules here
+	if response := m.faults.InjectHTTP(req, verb, trimVersion(requestPath)); response != nil {
+		return response, nil
+	}
+	if err := m.authorizer.AuthorizeHTTP(req, verb, trimVersion(requestPath)); err != nil {
+		return m.iamPolicies.buildErrorResponse(err)
+	}
+
 	switch verb {
 	case "getIamPolicy":
 		if req.Method == "GET" || req.Method == "POST" {
 			resourcePath := req.URL.Host + requestPath
-			return m.iamPolicies.serveGetIAMPolicy(resourcePath)
+			return m.iamPolicies.serveGetIAMPolicy(req.Context(), resourcePath)
 		} else {
 			response := &http.Response{
 				StatusCode: http.StatusMethodNotAllowed,
@@ -268,7 +305,17 @@ func (m *mockRoundTripper) roundTripIAMPolicy(req *http.Request) (*http.Response
 	case "setIamPolicy":
 		if req.Method == "POST" {
 			resourcePath := req.URL.Host + requestPath
-			return m.iamPolicies.serveSetIAMPolicy(resourcePath, req)
+
+			// We read the body so that we can record a digest of it in the audit log
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, fmt.Errorf("reading request body: %w", err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			response, err := m.iamPolicies.serveSetIAMPolicy(req.Context(), resourcePath, req)
+			m.auditLog.RecordHTTP(req, "google.iam.v1.IAMPolicy.SetIamPolicy", trimVersion(requestPath), body, response, err)
+			return response, err
 		} else {
 			response := &http.Response{
 				StatusCode: http.StatusMethodNotAllowed,
@@ -287,6 +334,56 @@ func (m *mockRoundTripper) roundTripIAMPolicy(req *http.Request) (*http.Response
 	}
 }
 
+// roundTripTestIAMPermissions serves testIamPermissions, which returns the permissions the caller has on a resource.
+// Like the other IAM policy verbs, it is implemented once here rather than per-resource, but only when authz is enforced.
+func (m *mockRoundTripper) roundTripTestIAMPermissions(req *http.Request) (*http.Response, error) {
+	resource := trimVersion(strings.TrimSuffix(req.URL.Path, ":testIamPermissions"))
+
+	if response := m.faults.InjectHTTP(req, "testIamPermissions", resource); response != nil {
+		return response, nil
+	}
+	if req.Method != "POST" {
+		return &http.Response{
+			StatusCode: http.StatusMethodNotAllowed,
+			Status:     "method not supported",
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}
+
+	request := struct {
+		Permissions []string `json:"permissions"`
+	}{}
+	if req.Body != nil {
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil && err != io.EOF {
+			return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "parsing request body: %v", err))
+		}
+	}
+	for _, permission := range request.Permissions {
+		if strings.Contains(permission, "*") {
+			return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "Permissions with wildcards (such as %q) are not allowed.", permission))
+		}
+	}
+
+	granted, err := m.authorizer.TestPermissionsHTTP(req, resource, request.Permissions)
+	if err != nil {
+		return m.iamPolicies.buildErrorResponse(err)
+	}
+	response := map[string]any{}
+	if len(granted) != 0 {
+		response["permissions"] = granted
+	}
+	return m.iamPolicies.buildResponse(response)
+}
+
+// trimVersion removes the API version prefix from a request path, e.g. /v1/projects/p => projects/p
+func trimVersion(requestPath string) string {
+	requestPath = strings.TrimPrefix(requestPath, "/")
+	if first, rest, ok := strings.Cut(requestPath, "/"); ok && regexp.MustCompile(`^v[0-9]`).MatchString(first) {
+		return rest
+	}
+	return requestPath
+}
+
 func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	klog.Infof("mockgcp request: %v %v", req.Method, req.URL)
 
@@ -294,6 +391,13 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	if strings.HasSuffix(requestPath, ":getIamPolicy") || strings.HasSuffix(requestPath, ":setIamPolicy") {
 		return m.roundTripIAMPolicy(req)
 	}
+	// Without enforcement, services that implement testIamPermissions themselves (e.g. billing) serve it.
+	if strings.HasSuffix(requestPath, ":testIamPermissions") && m.authorizer.Enforcing() {
+		return m.roundTripTestIAMPermissions(req)
+	}
+	if req.URL.Host == iamCredentialsHost {
+		return m.roundTripIAMCredentials(req)
+	}
 
 	var mux http.Handler
 	for _, service := range m.services {
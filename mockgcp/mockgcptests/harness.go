@@ -122,8 +122,10 @@ func (t *Harness) Init() {
 	if targetGCP := os.Getenv("E2E_GCP_TARGET"); targetGCP == "mock" {
 		t.Logf("creating mock gcp")
 
+		mockStorage := storage.NewForTest(ctx, t.T)
+
 		var kubeClient client.Client // TODO: We should replace this, it didn't work
-		mockCloud := mockgcp.NewMockRoundTripperForTest(t.T, kubeClient, storage.NewInMemoryStorage())
+		mockCloud := mockgcp.NewMockRoundTripperForTest(t.T, kubeClient, mockStorage)
 
 		mockCloudGRPCClientConnection = mockCloud.NewGRPCConnection(ctx)
 		t.MockGCP = mockCloud
@@ -97,6 +97,10 @@ func (s *IAMServer) CreateServiceAccountKey(ctx context.Context, req *pb.CreateS
 		return nil, err
 	}
 
+	if err := s.OrgPolicies.CheckBoolean(ctx, "projects/"+parent.Project.ID, "iam.disableServiceAccountKeyCreation"); err != nil {
+		return nil, err
+	}
+
 	name := &serviceAccountKeyName{
 		serviceAccountName: *parent,
 	}
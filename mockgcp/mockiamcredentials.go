@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockgcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/iam/admin/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockiam"
+)
+
+const (
+	iamCredentialsHost = "iamcredentials.googleapis.com"
+
+	defaultAccessTokenLifetime = time.Hour
+	maxAccessTokenLifetime     = 12 * time.Hour
+)
+
+// roundTripIAMCredentials serves the IAM Credentials API, which mints tokens for service accounts.
+// We serve it directly rather than through grpc, because the tokens must be issued by the authorizer,
+// so that requests made with them are authorized as the service account.
+func (m *mockRoundTripper) roundTripIAMCredentials(req *http.Request) (*http.Response, error) {
+	requestPath := req.URL.Path
+
+	lastColon := strings.LastIndex(requestPath, ":")
+	if lastColon == -1 || requestPath[lastColon+1:] != "generateAccessToken" {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "not found",
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}
+	if req.Method != "POST" {
+		return &http.Response{
+			StatusCode: http.StatusMethodNotAllowed,
+			Status:     "method not supported",
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}
+	name := trimVersion(requestPath[:lastColon])
+
+	if response := m.faults.InjectHTTP(req, "generateAccessToken", name); response != nil {
+		return response, nil
+	}
+
+	// The project must be `-`; it is inferred from the email of the service account.
+	tokens := strings.Split(name, "/")
+	if len(tokens) != 4 || tokens[0] != "projects" || tokens[1] != "-" || tokens[2] != "serviceAccounts" {
+		return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "name %q is not valid", name))
+	}
+	email := tokens[3]
+	_, domain, _ := strings.Cut(email, "@")
+	projectID, ok := strings.CutSuffix(domain, mockiam.ServiceAccountSuffix)
+	if !ok {
+		return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "service account %q is not a user-managed service account", email))
+	}
+	serviceAccountName := "projects/" + projectID + "/serviceAccounts/" + email
+
+	if err := m.authorizer.AuthorizePermissionHTTP(req, "iam.serviceAccounts.getAccessToken", "iam.googleapis.com", serviceAccountName); err != nil {
+		return m.iamPolicies.buildErrorResponse(err)
+	}
+
+	serviceAccount := &pb.ServiceAccount{}
+	if err := m.iamPolicies.storage.Get(req.Context(), serviceAccountName, serviceAccount); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return m.iamPolicies.buildErrorResponse(status.Errorf(codes.NotFound, "Requested entity was not found."))
+		}
+		return nil, err
+	}
+	if serviceAccount.GetDisabled() {
+		return m.iamPolicies.buildErrorResponse(status.Errorf(codes.FailedPrecondition, "Service account %q is disabled.", email))
+	}
+
+	request := struct {
+		Scope    []string `json:"scope"`
+		Lifetime string   `json:"lifetime"`
+	}{}
+	if req.Body != nil {
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil && err != io.EOF {
+			return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "parsing request body: %v", err))
+		}
+	}
+	lifetime := defaultAccessTokenLifetime
+	if request.Lifetime != "" {
+		d, err := time.ParseDuration(request.Lifetime)
+		if err != nil || d <= 0 || d > maxAccessTokenLifetime {
+			return m.iamPolicies.buildErrorResponse(status.Errorf(codes.InvalidArgument, "lifetime %q is not valid", request.Lifetime))
+		}
+		lifetime = d
+	}
+
+	token := m.authorizer.IssueToken("serviceAccount:" + email)
+	return m.iamPolicies.buildResponse(map[string]any{
+		"accessToken": token,
+		"expireTime":  time.Now().Add(lifetime).UTC().Format(time.RFC3339),
+	})
+}
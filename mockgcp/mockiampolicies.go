@@ -16,23 +16,40 @@ package mockgcp
 
 import (
 	"bytes"
-	"crypto/md5"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 
 	"cloud.google.com/go/iam/apiv1/iampb"
-	"google.golang.org/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/httpmux"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
 )
 
+// mockIAMPolicies stores IAM policies keyed by resource path, so they are included in storage snapshots.
 type mockIAMPolicies struct {
-	policies map[string]*iampb.Policy
+	storage   storage.Storage
+	hierarchy *hierarchy.Hierarchy
+
+	// mutex makes setIamPolicy an atomic read-modify-write, so concurrent writers see etag conflicts as they would in GCP.
+	mutex sync.Mutex
 }
 
-func newMockIAMPolicies() *mockIAMPolicies {
+func newMockIAMPolicies(storage storage.Storage) *mockIAMPolicies {
 	return &mockIAMPolicies{
-		policies: make(map[string]*iampb.Policy),
+		storage:   storage,
+		hierarchy: hierarchy.New(storage),
 	}
 }
 
@@ -60,24 +77,47 @@ func (m *mockIAMPolicies) buildResponse(obj any) (*http.Response, error) {
 	return w, nil
 }
 
-func (m *mockIAMPolicies) getIAMPolicy(resourcePath string) (*iampb.Policy, error) {
-	policy := m.policies[resourcePath]
-	if policy == nil {
-		policy = &iampb.Policy{}
-		policy.Etag = computeEtag(policy)
+// buildErrorResponse builds an HTTP response in the format GCP uses for errors.
+func (m *mockIAMPolicies) buildErrorResponse(err error) (*http.Response, error) {
+	st := status.Convert(err)
+	statusCode := runtime.HTTPStatusFromCode(st.Code())
+	obj := map[string]any{
+		"error": &httpmux.ErrorResponse{
+			Code:    statusCode,
+			Message: st.Message(),
+			Status:  code.Code(st.Code()).String(),
+		},
+	}
+	response, err := m.buildResponse(obj)
+	if err != nil {
+		return nil, err
+	}
+	response.StatusCode = statusCode
+	response.Status = fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))
+	return response, nil
+}
+
+func (m *mockIAMPolicies) getIAMPolicy(ctx context.Context, resourcePath string) (*iampb.Policy, bool, error) {
+	policy := &iampb.Policy{}
+	if err := m.storage.Get(ctx, resourcePath, policy); err != nil {
+		if status.Code(err) == codes.NotFound {
+			fields.StampEtag(policy)
+			return policy, false, nil
+		}
+		return nil, false, err
 	}
-	return policy, nil
+	return policy, true, nil
 }
 
-func (m *mockIAMPolicies) serveGetIAMPolicy(resourcePath string) (*http.Response, error) {
-	policy, err := m.getIAMPolicy(resourcePath)
+func (m *mockIAMPolicies) serveGetIAMPolicy(ctx context.Context, resourcePath string) (*http.Response, error) {
+	policy, _, err := m.getIAMPolicy(ctx, resourcePath)
 	if err != nil {
 		return nil, err
 	}
 	return m.buildResponse(policy)
 }
 
-func (m *mockIAMPolicies) serveSetIAMPolicy(resourcePath string, httpRequest *http.Request) (*http.Response, error) {
+func (m *mockIAMPolicies) serveSetIAMPolicy(ctx context.Context, resourcePath string, httpRequest *http.Request) (*http.Response, error) {
 	request := &iampb.SetIamPolicyRequest{}
 
 	requestBytes, err := io.ReadAll(httpRequest.Body)
@@ -88,15 +128,21 @@ func (m *mockIAMPolicies) serveSetIAMPolicy(resourcePath string, httpRequest *ht
 		return nil, err
 	}
 
-	oldPolicy, err := m.getIAMPolicy(resourcePath)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	oldPolicy, exists, err := m.getIAMPolicy(ctx, resourcePath)
 	if err != nil {
 		return nil, err
 	}
 
-	if request.Policy.Etag != nil && !bytes.Equal(request.Policy.Etag, oldPolicy.Etag) {
-		responseBytes := []byte("{}")
-		responseBody := io.NopCloser(bytes.NewReader(responseBytes))
-		return &http.Response{StatusCode: http.StatusConflict, Body: responseBody}, nil
+	if len(request.Policy.Etag) != 0 {
+		requestEtag := base64.StdEncoding.EncodeToString(request.Policy.Etag)
+		currentEtag := base64.StdEncoding.EncodeToString(oldPolicy.Etag)
+		if fields.CheckEtag(codes.Aborted, resourcePath, requestEtag, currentEtag) != nil {
+			// IAM reports a stale etag with this message, which clients use to decide to retry
+			return m.buildErrorResponse(status.Errorf(codes.Aborted, "There were concurrent policy changes. Please retry the whole read-modify-write with exponential backoff."))
+		}
 	}
 
 	// conditional role bindings must specify version 3
@@ -112,18 +158,56 @@ func (m *mockIAMPolicies) serveSetIAMPolicy(resourcePath string, httpRequest *ht
 		request.Policy.Version = 1
 	}
 
-	request.Policy.Etag = computeEtag(request.Policy)
-	m.policies[resourcePath] = request.Policy
+	fields.StampEtag(request.Policy)
+	if exists {
+		err = m.storage.Update(ctx, resourcePath, request.Policy)
+	} else {
+		err = m.storage.Create(ctx, resourcePath, request.Policy)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	return m.buildResponse(request.Policy)
 
 }
 
-func computeEtag(policy *iampb.Policy) []byte {
-	b, err := proto.Marshal(policy)
+// iamPolicyVersions are the API versions under which IAM policies may have been set; policies are stored by request path.
+var iamPolicyVersions = []string{"v1", "v1beta1", "v1beta", "v1alpha", "v1alpha1", "v2", "v2beta1", "v3"}
+
+// IAMPolicies implements authz.PolicySource, returning the IAM policies set on the resource with any API version.
+func (m *mockIAMPolicies) IAMPolicies(ctx context.Context, host string, resource string) ([]*iampb.Policy, error) {
+	tokens := strings.Split(resource, "/")
+	if len(tokens) == 2 {
+		switch tokens[0] {
+		case "projects", "folders", "organizations":
+			host = "cloudresourcemanager.googleapis.com"
+		}
+	}
+
+	// The policy of a project could have been set using the project id or number
+	names, err := m.hierarchy.Aliases(ctx, resource)
 	if err != nil {
-		panic(fmt.Sprintf("converting to proto: %v", err))
+		return nil, err
+	}
+
+	var policies []*iampb.Policy
+	for _, name := range names {
+		for _, version := range iamPolicyVersions {
+			policy := &iampb.Policy{}
+			if err := m.storage.Get(ctx, host+"/"+version+"/"+name, policy); err != nil {
+				if status.Code(err) == codes.NotFound {
+					continue
+				}
+				return nil, err
+			}
+			policies = append(policies, policy)
+		}
 	}
-	hash := md5.Sum(b)
-	return hash[:]
+	return policies, nil
+}
+
+// Ancestors implements authz.PolicySource, returning the resource and its ancestors in the resource hierarchy.
+func (m *mockIAMPolicies) Ancestors(ctx context.Context, resource string) ([]string, error) {
+	return m.hierarchy.Ancestors(ctx, resource)
 }
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +tool:mockgcp-support
+// proto.service: google.logging.v2.LoggingServiceV2
+// proto.message: google.logging.v2.LogEntry
+
+package mocklogging
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "cloud.google.com/go/logging/apiv2/loggingpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/logentries"
+)
+
+const (
+	defaultLogEntriesPageSize = 50
+	maxLogEntriesPageSize     = 1000
+)
+
+func (s *loggingServiceV2) WriteLogEntries(ctx context.Context, req *pb.WriteLogEntriesRequest) (*pb.WriteLogEntriesResponse, error) {
+	if len(req.GetEntries()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "entries is required")
+	}
+
+	var entries []*pb.LogEntry
+	for _, reqEntry := range req.GetEntries() {
+		entry := proto.Clone(reqEntry).(*pb.LogEntry)
+		if entry.LogName == "" {
+			entry.LogName = req.GetLogName()
+		}
+		if entry.Resource == nil {
+			entry.Resource = req.GetResource()
+		}
+		for k, v := range req.GetLabels() {
+			if _, found := entry.GetLabels()[k]; !found {
+				if entry.Labels == nil {
+					entry.Labels = make(map[string]string)
+				}
+				entry.Labels[k] = v
+			}
+		}
+		if entry.GetResource() == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "resource is required")
+		}
+		if _, _, err := logentries.ParseLogName(entry.GetLogName()); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if req.GetDryRun() {
+		return &pb.WriteLogEntriesResponse{}, nil
+	}
+	for _, entry := range entries {
+		if err := logentries.Write(ctx, s.storage, entry); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.WriteLogEntriesResponse{}, nil
+}
+
+func (s *loggingServiceV2) ListLogEntries(ctx context.Context, req *pb.ListLogEntriesRequest) (*pb.ListLogEntriesResponse, error) {
+	if len(req.GetResourceNames()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "resource_names is required")
+	}
+
+	descending := false
+	switch strings.TrimSpace(req.GetOrderBy()) {
+	case "", "timestamp asc":
+	case "timestamp desc":
+		descending = true
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by %q", req.GetOrderBy())
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultLogEntriesPageSize
+	}
+	if pageSize > maxLogEntriesPageSize {
+		pageSize = maxLogEntriesPageSize
+	}
+	offset := 0
+	if req.GetPageToken() != "" {
+		n, err := strconv.Atoi(req.GetPageToken())
+		if err != nil || n < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %q", req.GetPageToken())
+		}
+		offset = n
+	}
+
+	// Projects can be named by id or number, but entries are stored under the name they were written to
+	h := hierarchy.New(s.storage)
+	seen := make(map[string]bool)
+	var entries []*pb.LogEntry
+	for _, resourceName := range req.GetResourceNames() {
+		aliases, err := h.Aliases(ctx, resourceName)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range aliases {
+			if seen[alias] {
+				continue
+			}
+			seen[alias] = true
+
+			found, err := logentries.List(ctx, s.storage, alias, req.GetFilter())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, found...)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].GetTimestamp().AsTime(), entries[j].GetTimestamp().AsTime()
+		if descending {
+			return a.After(b)
+		}
+		return a.Before(b)
+	})
+
+	response := &pb.ListLogEntriesResponse{}
+	if offset < len(entries) {
+		end := offset + pageSize
+		if end < len(entries) {
+			response.NextPageToken = strconv.Itoa(end)
+		} else {
+			end = len(entries)
+		}
+		for _, entry := range entries[offset:end] {
+			response.Entries = append(response.Entries, proto.Clone(entry).(*pb.LogEntry))
+		}
+	}
+	return response, nil
+}
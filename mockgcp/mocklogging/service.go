@@ -15,6 +15,7 @@
 // +tool:mockgcp-service
 // http.host: logging.googleapis.com
 // proto.service: google.logging.v2.ConfigServiceV2
+// proto.service: google.logging.v2.LoggingServiceV2
 // proto.service: google.logging.v2.MetricsServiceV2
 
 package mocklogging
@@ -56,6 +57,11 @@ type metricsServiceV2 struct {
 	pb.UnimplementedMetricsServiceV2Server
 }
 
+type loggingServiceV2 struct {
+	*MockService
+	pb.UnimplementedLoggingServiceV2Server
+}
+
 // New creates a MockService.
 func New(env *common.MockEnvironment, storage storage.Storage) mockgcpregistry.MockService {
 	s := &MockService{
@@ -73,12 +79,14 @@ func (s *MockService) ExpectedHosts() []string {
 func (s *MockService) Register(grpcServer *grpc.Server) {
 	pb.RegisterMetricsServiceV2Server(grpcServer, &metricsServiceV2{MockService: s})
 	pb.RegisterConfigServiceV2Server(grpcServer, &configServiceV2{MockService: s})
+	pb.RegisterLoggingServiceV2Server(grpcServer, &loggingServiceV2{MockService: s})
 }
 
 func (s *MockService) NewHTTPMux(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
 	mux, err := httpmux.NewServeMux(ctx, conn, httpmux.Options{},
 		pb_http.RegisterMetricsServiceV2Handler,
 		pb_http.RegisterConfigServiceV2Handler,
+		pb_http.RegisterLoggingServiceV2Handler,
 		s.operations.RegisterOperationsPath("/v2/{prefix=**}/operations/{name}"))
 	if err != nil {
 		return nil, err
@@ -69,17 +69,20 @@ func (s *AlertPolicyService) ListAlertPolicies(ctx context.Context, req *pb.List
 	var alertPolicies []*pb.AlertPolicy
 
 	findKind := (&pb.AlertPolicy{}).ProtoReflect().Descriptor()
-	if err := s.storage.List(ctx, findKind, storage.ListOptions{Prefix: findPrefix}, func(obj proto.Message) error {
+	listOptions := storage.ListOptions{Prefix: findPrefix, Filter: req.GetFilter()}
+	nextPageToken, totalSize, err := storage.ListPage(ctx, s.storage, findKind, listOptions, req.GetPageSize(), req.GetPageToken(), func(obj proto.Message) error {
 		alertPolicy := obj.(*pb.AlertPolicy)
 		alertPolicies = append(alertPolicies, alertPolicy)
 		return nil
-	}); err != nil {
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &pb.ListAlertPoliciesResponse{
 		AlertPolicies: alertPolicies,
-		TotalSize:     int32(len(alertPolicies)),
+		NextPageToken: nextPageToken,
+		TotalSize:     int32(totalSize),
 	}, nil
 }
 
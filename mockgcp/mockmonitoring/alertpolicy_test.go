@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockmonitoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/projects"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/monitoring/v3"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// testProjects is a ProjectStore holding a single project.
+type testProjects struct{}
+
+var testProject = &projects.ProjectData{ID: "my-project", Number: 123}
+
+func (p *testProjects) GetProject(project *projects.ProjectName) (*projects.ProjectData, error) {
+	return testProject, nil
+}
+
+func (p *testProjects) GetProjectByID(projectID string) (*projects.ProjectData, error) {
+	return testProject, nil
+}
+
+func (p *testProjects) GetProjectByNumber(projectNumberAsString string) (*projects.ProjectData, error) {
+	return testProject, nil
+}
+
+func (p *testProjects) GetProjectByIDOrNumber(projectIDOrNumber string) (*projects.ProjectData, error) {
+	return testProject, nil
+}
+
+func TestListAlertPolicies(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewInMemoryStorage()
+	s := &AlertPolicyService{MockService: New(&common.MockEnvironment{Projects: &testProjects{}}, store).(*MockService)}
+
+	for _, policy := range []*pb.AlertPolicy{
+		{Name: "projects/my-project/alertPolicies/1", DisplayName: "high cpu", UserLabels: map[string]string{"env": "prod"}},
+		{Name: "projects/my-project/alertPolicies/2", DisplayName: "high memory", UserLabels: map[string]string{"env": "prod"}},
+		{Name: "projects/my-project/alertPolicies/3", DisplayName: "low disk", UserLabels: map[string]string{"env": "dev"}},
+	} {
+		if err := store.Create(ctx, policy.Name, policy); err != nil {
+			t.Fatalf("creating %q: %v", policy.Name, err)
+		}
+	}
+
+	grid := []struct {
+		filter    string
+		pageSize  int32
+		wantNames []string
+		wantTotal int32
+	}{
+		{filter: "", wantNames: []string{"high cpu", "high memory", "low disk"}, wantTotal: 3},
+		{filter: `user_labels.env = "prod"`, wantNames: []string{"high cpu", "high memory"}, wantTotal: 2},
+		{filter: `display_name = "high*" AND NOT user_labels.env = "dev"`, pageSize: 1, wantNames: []string{"high cpu"}, wantTotal: 2},
+	}
+	for _, g := range grid {
+		resp, err := s.ListAlertPolicies(ctx, &pb.ListAlertPoliciesRequest{Name: "projects/my-project", Filter: g.filter, PageSize: g.pageSize})
+		if err != nil {
+			t.Fatalf("ListAlertPolicies(%q) failed: %v", g.filter, err)
+		}
+		var names []string
+		for _, policy := range resp.GetAlertPolicies() {
+			names = append(names, policy.GetDisplayName())
+		}
+		if diff := cmp.Diff(g.wantNames, names); diff != "" {
+			t.Errorf("unexpected policies for filter %q (-want +got):\n%v", g.filter, diff)
+		}
+		if resp.GetTotalSize() != g.wantTotal {
+			t.Errorf("unexpected total size %d for filter %q, want %d", resp.GetTotalSize(), g.filter, g.wantTotal)
+		}
+	}
+}
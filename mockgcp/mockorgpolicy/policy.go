@@ -25,6 +25,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/orgpolicy"
 	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/orgpolicy/v2"
 	"github.com/golang/protobuf/ptypes/empty"
 )
@@ -55,6 +56,12 @@ func (s *orgPolicyV2) CreatePolicy(ctx context.Context, req *pb.CreatePolicyRequ
 		return nil, err
 	}
 
+	for _, spec := range []*pb.PolicySpec{req.GetPolicy().GetSpec(), req.GetPolicy().GetDryRunSpec()} {
+		if err := orgpolicy.ValidateSpec(spec); err != nil {
+			return nil, err
+		}
+	}
+
 	fqn := name.String()
 
 	obj := proto.Clone(req.Policy).(*pb.Policy)
@@ -83,6 +90,12 @@ func (s *orgPolicyV2) UpdatePolicy(ctx context.Context, req *pb.UpdatePolicyRequ
 		return nil, err
 	}
 
+	for _, spec := range []*pb.PolicySpec{req.GetPolicy().GetSpec(), req.GetPolicy().GetDryRunSpec()} {
+		if err := orgpolicy.ValidateSpec(spec); err != nil {
+			return nil, err
+		}
+	}
+
 	fqn := name.String()
 	obj := &pb.Policy{}
 	if err := s.storage.Get(ctx, fqn, obj); err != nil {
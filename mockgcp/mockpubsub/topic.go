@@ -184,24 +184,23 @@ func (s *publisherService) ListTopics(ctx context.Context, req *pb.ListTopicsReq
 		return nil, err
 	}
 
-	findPrefix := fmt.Sprintf("projects/%v/", project.ID)
+	findPrefix := fmt.Sprintf("projects/%v/topics/", project.ID)
 
 	var topics []*pb.Topic
 
 	topicKind := (&pb.Topic{}).ProtoReflect().Descriptor()
-	if err := s.storage.List(ctx, topicKind, storage.ListOptions{}, func(obj proto.Message) error {
+	nextPageToken, _, err := storage.ListPage(ctx, s.storage, topicKind, storage.ListOptions{Prefix: findPrefix}, req.GetPageSize(), req.GetPageToken(), func(obj proto.Message) error {
 		topic := obj.(*pb.Topic)
-		if strings.HasPrefix(topic.Name, findPrefix) {
-			topics = append(topics, topic)
-		}
+		topics = append(topics, topic)
 		return nil
-	}); err != nil {
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &pb.ListTopicsResponse{
 		Topics:        topics,
-		NextPageToken: "",
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/projects"
 	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/secretmanager/v1"
 )
@@ -65,7 +66,7 @@ func (s *SecretsV1) CreateSecret(ctx context.Context, req *pb.CreateSecretReques
 	if obj.Replication == nil {
 		return nil, fmt.Errorf("Secret.replication must be specified.")
 	}
-	obj.Etag = computeEtag(obj)
+	fields.StampEtag(obj)
 	if err := s.populateDefaultsForSecret(ctx, obj); err != nil {
 		return nil, err
 	}
@@ -129,6 +130,9 @@ func (s *SecretsV1) UpdateSecret(ctx context.Context, req *pb.UpdateSecretReques
 	if err := s.storage.Get(ctx, fqn, existing); err != nil {
 		return nil, err
 	}
+	if err := fields.CheckEtag(codes.FailedPrecondition, fqn, fields.RequestEtag(req), fields.EtagOf(existing)); err != nil {
+		return nil, err
+	}
 
 	updated := ProtoClone(existing)
 	updated.Name = name.String()
@@ -177,6 +181,7 @@ func (s *SecretsV1) UpdateSecret(ctx context.Context, req *pb.UpdateSecretReques
 	if err := s.populateDefaultsForSecret(ctx, updated); err != nil {
 		return nil, err
 	}
+	fields.StampEtag(updated)
 	if err := s.storage.Update(ctx, fqn, updated); err != nil {
 		return nil, err
 	}
@@ -192,6 +197,16 @@ func (s *SecretsV1) DeleteSecret(ctx context.Context, req *pb.DeleteSecretReques
 
 	fqn := name.String()
 
+	if req.GetEtag() != "" {
+		existing := &pb.Secret{}
+		if err := s.storage.Get(ctx, fqn, existing); err != nil {
+			return nil, err
+		}
+		if err := fields.CheckEtag(codes.FailedPrecondition, fqn, req.GetEtag(), fields.EtagOf(existing)); err != nil {
+			return nil, err
+		}
+	}
+
 	oldObj := &pb.Secret{}
 	if err := s.storage.Delete(ctx, fqn, oldObj); err != nil {
 		return nil, err
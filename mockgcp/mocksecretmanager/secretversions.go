@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/projects"
 	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/secretmanager/v1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
@@ -95,7 +96,7 @@ func (s *SecretsV1) AddSecretVersion(ctx context.Context, req *pb.AddSecretVersi
 	secretVersionObj.Name = secretVersionName.String()
 	secretVersionObj.CreateTime = timestamppb.Now()
 	secretVersionObj.State = pb.SecretVersion_ENABLED
-	secretVersionObj.Etag = computeEtag(secretVersionObj)
+	fields.StampEtag(secretVersionObj)
 
 	// TODO: Copy from secret
 	if secretVersionObj.ReplicationStatus == nil {
@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +tool:mockgcp-support
+// proto.service: google.api.serviceusage.v1beta1.ServiceUsage
+// proto.message: google.api.serviceusage.v1beta1.ConsumerQuotaMetric
+
+package mockserviceusage
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/api/serviceusage/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/quota"
+)
+
+// Consumer quota metrics are served from the simulated quota limits (see pkg/quota), which tests configure.
+
+func (s *ServiceUsageV1Beta1) ListConsumerQuotaMetrics(ctx context.Context, req *pb.ListConsumerQuotaMetricsRequest) (*pb.ListConsumerQuotaMetricsResponse, error) {
+	name, err := s.parseServiceName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListConsumerQuotaMetricsResponse{}
+	var metrics []string
+	for _, limit := range s.Quotas.Limits(name.ServiceName) {
+		if !slices.Contains(metrics, limit.Metric) {
+			metrics = append(metrics, limit.Metric)
+		}
+	}
+	for _, metric := range metrics {
+		obj, err := s.buildConsumerQuotaMetric(ctx, name, metric)
+		if err != nil {
+			return nil, err
+		}
+		response.Metrics = append(response.Metrics, obj)
+	}
+	return response, nil
+}
+
+func (s *ServiceUsageV1Beta1) GetConsumerQuotaMetric(ctx context.Context, req *pb.GetConsumerQuotaMetricRequest) (*pb.ConsumerQuotaMetric, error) {
+	parent, metricID, ok := strings.Cut(req.GetName(), "/consumerQuotaMetrics/")
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "name %q is not valid", req.GetName())
+	}
+	name, err := s.parseServiceName(parent)
+	if err != nil {
+		return nil, err
+	}
+	metric, err := url.PathUnescape(metricID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "name %q is not valid", req.GetName())
+	}
+
+	obj, err := s.buildConsumerQuotaMetric(ctx, name, metric)
+	if err != nil {
+		return nil, err
+	}
+	if len(obj.ConsumerQuotaLimits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Consumer quota metric '%s' was not found", req.GetName())
+	}
+	return obj, nil
+}
+
+// buildConsumerQuotaMetric reports the limits for the metric, with their effective values in the project.
+func (s *MockService) buildConsumerQuotaMetric(ctx context.Context, name *serviceName, metric string) (*pb.ConsumerQuotaMetric, error) {
+	metricName := name.String() + "/consumerQuotaMetrics/" + url.PathEscape(metric)
+	obj := &pb.ConsumerQuotaMetric{
+		Name:   metricName,
+		Metric: metric,
+	}
+
+	projectNumber := strconv.FormatInt(name.Project.Number, 10)
+	for _, limit := range s.Quotas.Limits(name.ServiceName) {
+		if limit.Metric != metric {
+			continue
+		}
+		value, err := s.Quotas.EffectiveValue(ctx, projectNumber, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		if obj.DisplayName == "" {
+			obj.DisplayName = limit.DisplayName
+			obj.Unit = limit.Unit()
+		}
+		limitID := "/project"
+		if limit.Type == quota.Rate {
+			limitID = "/min/project"
+		}
+		obj.ConsumerQuotaLimits = append(obj.ConsumerQuotaLimits, &pb.ConsumerQuotaLimit{
+			Name:      metricName + "/limits/" + url.PathEscape(limitID),
+			Metric:    metric,
+			Unit:      limit.Unit(),
+			IsPrecise: true,
+			QuotaBuckets: []*pb.QuotaBucket{
+				{
+					EffectiveLimit: value,
+					DefaultLimit:   limit.Value,
+				},
+			},
+		})
+	}
+	return obj, nil
+}
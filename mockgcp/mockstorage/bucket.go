@@ -154,6 +154,16 @@ func (s *buckets) InsertBucket(ctx context.Context, req *pb.InsertBucketRequest)
 		}
 	}
 
+	projectResource := "projects/" + project.ID
+	if err := s.OrgPolicies.CheckListValue(ctx, projectResource, "gcp.resourceLocations", strings.ToLower(obj.GetLocation())); err != nil {
+		return nil, err
+	}
+	if !req.GetBucket().GetIamConfiguration().GetUniformBucketLevelAccess().GetEnabled() {
+		if err := s.OrgPolicies.CheckBoolean(ctx, projectResource, "storage.uniformBucketLevelAccess"); err != nil {
+			return nil, err
+		}
+	}
+
 	obj.Etag = PtrTo(computeEtag(obj))
 	if obj.Lifecycle != nil && proto.Equal(obj.Lifecycle, &pb.BucketLifecycle{}) {
 		obj.Lifecycle = nil
@@ -16,12 +16,21 @@ package mockstorage
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/httpmux"
-	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/storage/v1"
+	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/gcsobjects"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/httpmux"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/storage/v1"
 )
 
 type objects struct {
@@ -30,19 +39,82 @@ type objects struct {
 }
 
 func (s *objects) ListObjects(ctx context.Context, req *pb.ListObjectsRequest) (*pb.Objects, error) {
-	// A stub implementation, just to support deletion (for now)
-
 	httpmux.SetExpiresHeader(ctx, time.Now())
 
 	ret := &pb.Objects{}
 	ret.Kind = PtrTo("storage#objects")
+
+	// Objects are only written by other mocks (e.g. asset exports)
+	items, err := gcsobjects.List(ctx, s.storage, req.GetBucket(), req.GetPrefix())
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		ret.Items = append(ret.Items, proto.Clone(item).(*pb.Object))
+	}
+
+	// A stub implementation of folders, just to support deletion (for now)
 	ret.Prefixes = append(ret.Prefixes, "testfolder")
 	ret.Prefixes = append(ret.Prefixes, "testmanagedfolder")
 	return ret, nil
 }
 
 func (s *objects) GetObject(ctx context.Context, req *pb.GetObjectRequest) (*pb.Object, error) {
-	// A stub implementation, just to support deletion (for now)
+	obj, err := gcsobjects.Get(ctx, s.storage, req.GetBucket(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	httpmux.SetExpiresHeader(ctx, time.Now())
+
+	return proto.Clone(obj).(*pb.Object), nil
+}
+
+func (s *objects) DeleteObject(ctx context.Context, req *pb.DeleteObjectRequest) (*empty.Empty, error) {
+	if err := gcsobjects.Delete(ctx, s.storage, req.GetBucket(), req.GetName()); err != nil {
+		return nil, err
+	}
+	httpmux.SetStatusCode(ctx, http.StatusNoContent)
+
+	return &empty.Empty{}, nil
+}
+
+// serveMedia serves object downloads (alt=media), which return the contents rather than the metadata,
+// and so can't go through grpc-gateway.  It returns false if the request is not a download.
+func (s *MockService) serveMedia(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet || r.URL.Query().Get("alt") != "media" {
+		return false
+	}
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/download")
+	path, ok := strings.CutPrefix(path, "/storage/v1/b/")
+	if !ok {
+		return false
+	}
+	bucket, object, ok := strings.Cut(path, "/o/")
+	if !ok {
+		return false
+	}
+	object, err := url.PathUnescape(object)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return true
+	}
+
+	contents, err := gcsobjects.Read(r.Context(), s.storage, bucket, object)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return true
+	}
 
-	return nil, status.Errorf(codes.NotFound, "No such object: %s/%s", req.GetBucket(), req.GetName())
+	w.Header().Set("Content-Type", contents.GetContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(contents.GetData())))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(contents.GetData()); err != nil {
+		klog.Warningf("error writing contents of gs://%s/%s: %v", bucket, object, err)
+	}
+	return true
 }
@@ -0,0 +1,338 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog records Cloud Audit Logs (admin activity) for the mutating methods served by mockgcp,
+// so that tests can check which principal made which changes.  The records are served by mocklogging.
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	pb "cloud.google.com/go/logging/apiv2/loggingpb"
+	"google.golang.org/genproto/googleapis/api/monitoredres"
+	"google.golang.org/genproto/googleapis/cloud/audit"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	longrunningpb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/logentries"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// ActivityLogID is the log to which admin activity audit logs are written.
+const ActivityLogID = "cloudaudit.googleapis.com/activity"
+
+// PrincipalSource maps the authorization header of a request to the principal that made it.
+type PrincipalSource interface {
+	// Principal returns the principal (e.g. user:alice@example.com), or "" if it is not known.
+	Principal(authorization string) string
+}
+
+// Recorder writes an audit log entry for each mutating method.
+type Recorder struct {
+	storage    storage.Storage
+	principals PrincipalSource
+}
+
+// NewRecorder constructs a Recorder that writes to storage.  principals may be nil.
+func NewRecorder(storage storage.Storage, principals PrincipalSource) *Recorder {
+	return &Recorder{storage: storage, principals: principals}
+}
+
+// skippedServices are not audited: polling operations is not a change, and we must not audit our own log writes.
+var skippedServices = map[string]bool{
+	"google.longrunning.Operations":      true,
+	"google.logging.v2.LoggingServiceV2": true,
+}
+
+// readOnlyPrefixes are the prefixes of methods that don't change anything (and so are not admin activity).
+var readOnlyPrefixes = []string{
+	"Get", "BatchGet", "List", "AggregatedList", "Search", "Lookup", "Query",
+	"Read", "Check", "Test", "Fetch", "Validate", "Analyze", "Export",
+}
+
+// dataAccessServices and dataAccessMethods are the data plane: they read or write user data rather than configuration.
+// GCP records them as data access audit logs (not admin activity), which are off by default, so we don't record them.
+var dataAccessServices = map[string]bool{
+	"mockgcp.storage.v1.ObjectsServer": true,
+}
+
+var dataAccessMethods = map[string]bool{
+	"mockgcp.cloud.kms.v1.KeyManagementService/AsymmetricDecrypt":             true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/AsymmetricSign":                true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/Decrypt":                       true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/Encrypt":                       true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/GenerateRandomBytes":           true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/MacSign":                       true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/MacVerify":                     true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/RawDecrypt":                    true,
+	"mockgcp.cloud.kms.v1.KeyManagementService/RawEncrypt":                    true,
+	"mockgcp.cloud.secretmanager.v1.SecretManagerService/AccessSecretVersion": true,
+	"mockgcp.monitoring.v3.MetricService/CreateServiceTimeSeries":             true,
+	"mockgcp.monitoring.v3.MetricService/CreateTimeSeries":                    true,
+	"mockgcp.pubsub.v1.Publisher/Publish":                                     true,
+	"mockgcp.pubsub.v1.Subscriber/Acknowledge":                                true,
+	"mockgcp.pubsub.v1.Subscriber/ModifyAckDeadline":                          true,
+	"mockgcp.pubsub.v1.Subscriber/Pull":                                       true,
+	"mockgcp.pubsub.v1.Subscriber/StreamingPull":                              true,
+}
+
+// isMutating returns true if the method changes a resource, and so should be audited as admin activity.
+func isMutating(service string, method string) bool {
+	if skippedServices[service] || dataAccessServices[service] || dataAccessMethods[service+"/"+method] {
+		return false
+	}
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnaryServerInterceptor is a grpc interceptor that records mutating methods, whether they succeed or fail.
+func (r *Recorder) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	if !isMutating(service, method) {
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(ctx, req)
+
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return resp, err
+	}
+
+	var authorization, host string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) != 0 {
+			authorization = values[0]
+		}
+		if values := md.Get("x-forwarded-host"); len(values) != 0 {
+			host = values[0]
+		}
+	}
+
+	methodName := strings.TrimPrefix(service, "mockgcp.")
+	if methodName != service {
+		methodName = "google." + methodName
+	}
+
+	c := &call{
+		serviceName:   serviceNameOf(host, service),
+		methodName:    methodName + "." + method,
+		authorization: authorization,
+		err:           err,
+		requestDigest: digest(reqMsg),
+	}
+	respMsg, _ := resp.(proto.Message)
+	if err == nil && respMsg != nil {
+		c.responseDigest = digest(respMsg)
+	}
+	c.resourceName = resourceNameOf(reqMsg, respMsg, err)
+	c.parent = parentOf(reqMsg, c.resourceName)
+
+	if err2 := r.record(ctx, c); err2 != nil {
+		klog.Warningf("mockgcp: error writing audit log for %v: %v", info.FullMethod, err2)
+	}
+	return resp, err
+}
+
+// RecordHTTP records a mutating request that is served directly, rather than through grpc (for example setIamPolicy).
+// requestBody is the body of the request, which has already been consumed; the response body is read and replaced.
+func (r *Recorder) RecordHTTP(req *http.Request, methodName string, resourceName string, requestBody []byte, response *http.Response, err error) {
+	c := &call{
+		serviceName:   serviceNameOf(req.URL.Host, ""),
+		methodName:    methodName,
+		resourceName:  resourceName,
+		parent:        parentOf(nil, resourceName),
+		authorization: req.Header.Get("Authorization"),
+		err:           err,
+		requestDigest: digestBytes(requestBody),
+	}
+	if err == nil && response != nil {
+		if response.StatusCode >= 300 {
+			c.err = status.Error(codes.Unknown, response.Status)
+		} else if response.Body != nil {
+			responseBody, readErr := io.ReadAll(response.Body)
+			if readErr != nil {
+				klog.Warningf("mockgcp: error reading response for audit log of %v: %v", methodName, readErr)
+			}
+			response.Body = io.NopCloser(bytes.NewReader(responseBody))
+			c.responseDigest = digestBytes(responseBody)
+		}
+	}
+
+	if err2 := r.record(req.Context(), c); err2 != nil {
+		klog.Warningf("mockgcp: error writing audit log for %v: %v", methodName, err2)
+	}
+}
+
+// call holds the details of a call that we record.
+type call struct {
+	serviceName    string
+	methodName     string
+	resourceName   string
+	parent         string
+	authorization  string
+	err            error
+	requestDigest  string
+	responseDigest string
+}
+
+// record writes the audit log entry for a call.
+func (r *Recorder) record(ctx context.Context, c *call) error {
+	if c.parent == "" {
+		klog.V(2).Infof("mockgcp: not writing audit log for %v, cannot determine project of %q", c.methodName, c.resourceName)
+		return nil
+	}
+
+	auditLog := &audit.AuditLog{
+		ServiceName:  c.serviceName,
+		MethodName:   c.methodName,
+		ResourceName: c.resourceName,
+		AuthenticationInfo: &audit.AuthenticationInfo{
+			PrincipalEmail: r.principalEmail(c.authorization),
+		},
+		Status: status.Convert(c.err).Proto(),
+	}
+	digests := map[string]interface{}{
+		"requestDigest": c.requestDigest,
+	}
+	if c.responseDigest != "" {
+		digests["responseDigest"] = c.responseDigest
+	}
+	digestStruct, err := structpb.NewStruct(digests)
+	if err != nil {
+		return err
+	}
+	auditLog.Metadata = digestStruct
+
+	payload, err := anypb.New(auditLog)
+	if err != nil {
+		return err
+	}
+
+	severity := ltype.LogSeverity_NOTICE
+	if c.err != nil {
+		severity = ltype.LogSeverity_ERROR
+	}
+
+	entry := &pb.LogEntry{
+		LogName: logentries.LogName(c.parent, ActivityLogID),
+		Resource: &monitoredres.MonitoredResource{
+			Type: "audited_resource",
+			Labels: map[string]string{
+				"service": c.serviceName,
+				"method":  c.methodName,
+			},
+		},
+		Severity: severity,
+		Payload:  &pb.LogEntry_ProtoPayload{ProtoPayload: payload},
+	}
+	if project, ok := strings.CutPrefix(c.parent, "projects/"); ok {
+		entry.Resource.Labels["project_id"] = project
+	}
+	return logentries.Write(ctx, r.storage, entry)
+}
+
+// principalEmail returns the email of the caller, e.g. alice@example.com for user:alice@example.com
+func (r *Recorder) principalEmail(authorization string) string {
+	if r.principals == nil || authorization == "" {
+		return ""
+	}
+	principal := r.principals.Principal(authorization)
+	if _, email, ok := strings.Cut(principal, ":"); ok {
+		return email
+	}
+	return principal
+}
+
+// resourceNameOf returns the name of the resource the call changed.
+// For creates, that is the name of the created resource (if we have it), not the parent in the request.
+func resourceNameOf(req proto.Message, resp proto.Message, callErr error) string {
+	if callErr == nil && resp != nil {
+		if _, isOperation := resp.(*longrunningpb.Operation); !isOperation {
+			if name := fields.StringField(resp.ProtoReflect(), "name"); strings.Contains(name, "/") {
+				return name
+			}
+		}
+	}
+	return fields.RequestResourceName(req)
+}
+
+// parentOf returns the project, folder or organization that the audit log is written to, e.g. projects/p
+func parentOf(req proto.Message, resourceName string) string {
+	tokens := strings.Split(resourceName, "/")
+	if len(tokens) >= 2 {
+		switch tokens[0] {
+		case "projects", "folders", "organizations":
+			return tokens[0] + "/" + tokens[1]
+		}
+	}
+	// Some APIs (e.g. GCS buckets) pass the project separately
+	if req == nil {
+		return ""
+	}
+	if project := fields.StringField(req.ProtoReflect(), "project"); project != "" {
+		return "projects/" + strings.TrimPrefix(project, "projects/")
+	}
+	return ""
+}
+
+// serviceNameOf returns the name of the API, e.g. pubsub.googleapis.com
+func serviceNameOf(host string, grpcService string) string {
+	if host != "" {
+		if i := strings.LastIndex(host, ":"); i != -1 {
+			host = host[:i]
+		}
+		return host
+	}
+	for _, token := range strings.Split(grpcService, ".") {
+		if token == "mockgcp" || token == "google" || token == "cloud" {
+			continue
+		}
+		return token + ".googleapis.com"
+	}
+	return grpcService
+}
+
+// digest returns a digest of the message, so tests can compare requests and responses without storing them.
+func digest(msg proto.Message) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	return digestBytes(b)
+}
+
+func digestBytes(b []byte) string {
+	hash := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/cloud/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/logentries"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// fakePrincipals maps bearer tokens to principals.
+type fakePrincipals map[string]string
+
+func (f fakePrincipals) Principal(authorization string) string {
+	return f[strings.TrimPrefix(authorization, "Bearer ")]
+}
+
+func invoke(r *Recorder, token string, fullMethod string, req interface{}, handler grpc.UnaryHandler) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-forwarded-host", "pubsub.googleapis.com",
+		"authorization", "Bearer "+token,
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	r.UnaryServerInterceptor(ctx, req, info, handler)
+}
+
+func auditLogs(t *testing.T, store storage.Storage, filter string) []*audit.AuditLog {
+	t.Helper()
+
+	entries, err := logentries.List(context.Background(), store, "projects/my-project", filter)
+	if err != nil {
+		t.Fatalf("listing log entries: %v", err)
+	}
+	var logs []*audit.AuditLog
+	for _, entry := range entries {
+		if want := "projects/my-project/logs/cloudaudit.googleapis.com%2Factivity"; entry.GetLogName() != want {
+			t.Errorf("unexpected logName %q, want %q", entry.GetLogName(), want)
+		}
+		auditLog := &audit.AuditLog{}
+		if err := entry.GetProtoPayload().UnmarshalTo(auditLog); err != nil {
+			t.Fatalf("unpacking protoPayload: %v", err)
+		}
+		logs = append(logs, auditLog)
+	}
+	return logs
+}
+
+func TestRecorder(t *testing.T) {
+	store := storage.NewInMemoryStorage()
+	r := NewRecorder(store, fakePrincipals{"alice-token": "user:alice@example.com"})
+
+	createTopic := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.Topic{Name: req.(*pb.Topic).GetName()}, nil
+	}
+	invoke(r, "alice-token", "/mockgcp.pubsub.v1.Publisher/CreateTopic", &pb.Topic{Name: "projects/my-project/topics/t1"}, createTopic)
+
+	getTopic := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.Topic{Name: req.(*pb.GetTopicRequest).GetTopic()}, nil
+	}
+	invoke(r, "alice-token", "/mockgcp.pubsub.v1.Publisher/GetTopic", &pb.GetTopicRequest{Topic: "projects/my-project/topics/t1"}, getTopic)
+
+	deleteTopic := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.NotFound, "topic not found")
+	}
+	invoke(r, "unknown-token", "/mockgcp.pubsub.v1.Publisher/DeleteTopic", &pb.DeleteTopicRequest{Topic: "projects/my-project/topics/t2"}, deleteTopic)
+
+	publish := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.PublishResponse{MessageIds: []string{"1"}}, nil
+	}
+	invoke(r, "alice-token", "/mockgcp.pubsub.v1.Publisher/Publish", &pb.PublishRequest{Topic: "projects/my-project/topics/t1"}, publish)
+
+	logs := auditLogs(t, store, "")
+	if len(logs) != 2 {
+		t.Fatalf("got %d audit logs, want 2 (reads and data access should not be recorded)", len(logs))
+	}
+
+	logs = auditLogs(t, store, `protoPayload.methodName="google.pubsub.v1.Publisher.CreateTopic"`)
+	if len(logs) != 1 {
+		t.Fatalf("got %d audit logs for CreateTopic, want 1", len(logs))
+	}
+	created := logs[0]
+	if got, want := created.GetServiceName(), "pubsub.googleapis.com"; got != want {
+		t.Errorf("unexpected serviceName %q, want %q", got, want)
+	}
+	if got, want := created.GetResourceName(), "projects/my-project/topics/t1"; got != want {
+		t.Errorf("unexpected resourceName %q, want %q", got, want)
+	}
+	if got, want := created.GetAuthenticationInfo().GetPrincipalEmail(), "alice@example.com"; got != want {
+		t.Errorf("unexpected principalEmail %q, want %q", got, want)
+	}
+	digests := created.GetMetadata().GetFields()
+	if !strings.HasPrefix(digests["requestDigest"].GetStringValue(), "sha256:") {
+		t.Errorf("unexpected requestDigest %v", digests["requestDigest"])
+	}
+	if !strings.HasPrefix(digests["responseDigest"].GetStringValue(), "sha256:") {
+		t.Errorf("unexpected responseDigest %v", digests["responseDigest"])
+	}
+
+	logs = auditLogs(t, store, `protoPayload.methodName="google.pubsub.v1.Publisher.DeleteTopic"`)
+	if len(logs) != 1 {
+		t.Fatalf("got %d audit logs for DeleteTopic, want 1", len(logs))
+	}
+	deleted := logs[0]
+	if got, want := codes.Code(deleted.GetStatus().GetCode()), codes.NotFound; got != want {
+		t.Errorf("unexpected status %v, want %v", got, want)
+	}
+	if got := deleted.GetAuthenticationInfo().GetPrincipalEmail(); got != "" {
+		t.Errorf("unexpected principalEmail %q for unknown token", got)
+	}
+	if _, found := deleted.GetMetadata().GetFields()["responseDigest"]; found {
+		t.Errorf("unexpected responseDigest for failed call")
+	}
+}
+
+func TestRecordHTTP(t *testing.T) {
+	store := storage.NewInMemoryStorage()
+	r := NewRecorder(store, fakePrincipals{"alice-token": "user:alice@example.com"})
+
+	requestBody := []byte(`{"policy":{}}`)
+	req := httptest.NewRequest("POST", "https://pubsub.googleapis.com/v1/projects/my-project/topics/t1:setIamPolicy", nil)
+	req.Header.Set("Authorization", "Bearer alice-token")
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"etag":"abc"}`)),
+	}
+	r.RecordHTTP(req, "google.iam.v1.IAMPolicy.SetIamPolicy", "projects/my-project/topics/t1", requestBody, response, nil)
+
+	// The response body must still be readable by the caller
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %v", err)
+	}
+	if got, want := string(b), `{"etag":"abc"}`; got != want {
+		t.Errorf("unexpected response body %q, want %q", got, want)
+	}
+
+	logs := auditLogs(t, store, `protoPayload.methodName="google.iam.v1.IAMPolicy.SetIamPolicy"`)
+	if len(logs) != 1 {
+		t.Fatalf("got %d audit logs for SetIamPolicy, want 1", len(logs))
+	}
+	if got, want := logs[0].GetAuthenticationInfo().GetPrincipalEmail(), "alice@example.com"; got != want {
+		t.Errorf("unexpected principalEmail %q, want %q", got, want)
+	}
+	if got, want := logs[0].GetResourceName(), "projects/my-project/topics/t1"; got != want {
+		t.Errorf("unexpected resourceName %q, want %q", got, want)
+	}
+}
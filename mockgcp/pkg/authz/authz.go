@@ -0,0 +1,270 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authz authenticates mockgcp requests and checks them against IAM policies,
+// so that we can test how clients behave with limited permissions.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// InternalToken is the bearer token that mockgcp uses for requests to itself (for example from workflows).
+// Requests with this token are always allowed; see InternalTransport.
+const InternalToken = "mockgcp-internal"
+
+// PolicySource provides the IAM policies and resource hierarchy that the Authorizer evaluates.
+type PolicySource interface {
+	// IAMPolicies returns the IAM policies set directly on the resource (e.g. projects/p/topics/t), which is served by host.
+	IAMPolicies(ctx context.Context, host string, resource string) ([]*iampb.Policy, error)
+
+	// Ancestors returns the resource and its ancestors in the resource hierarchy, nearest first,
+	// e.g. projects/p/topics/t, projects/p, folders/123, organizations/456
+	Ancestors(ctx context.Context, resource string) ([]string, error)
+}
+
+// emptyHierarchy is used when there is no PolicySource; it walks up resource names, but knows no projects or folders.
+var emptyHierarchy = hierarchy.New(storage.NewInMemoryStorage())
+
+// Authorizer maps bearer tokens to principals, and checks that principals have the permissions for their requests.
+//
+// Enforcement is opt-in: until a principal is added, all requests are allowed.
+// Once enforcement is on, requests without credentials are rejected, except for requests mockgcp makes to itself with InternalToken.
+type Authorizer struct {
+	mutex sync.Mutex
+
+	// principals holds the principal for each bearer token, e.g. user:alice@example.com
+	principals map[string]string
+
+	// bindings holds extra role bindings by resource, in addition to the IAM policies from the PolicySource.
+	bindings map[string][]*iampb.Binding
+
+	// roles holds custom roles (and overrides of predefined roles), as permission patterns.
+	roles map[string][]string
+
+	// permissions holds overrides of the permission needed for a grpc method.
+	permissions map[string]string
+
+	policies PolicySource
+
+	// issuedTokens counts the tokens issued by IssueToken, to make them unique.
+	issuedTokens int
+}
+
+// NewAuthorizer constructs an Authorizer with no principals, which allows all requests.
+func NewAuthorizer() *Authorizer {
+	a := &Authorizer{}
+	a.Reset()
+	return a
+}
+
+// SetPolicySource sets the source of IAM policies and the resource hierarchy.
+func (a *Authorizer) SetPolicySource(policies PolicySource) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.policies = policies
+}
+
+// AddPrincipal maps a bearer token to a principal, e.g. serviceAccount:kcc@my-project.iam.gserviceaccount.com
+// Adding a principal enables enforcement.
+func (a *Authorizer) AddPrincipal(token string, principal string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.principals[token] = principal
+}
+
+// IssueToken returns a new bearer token for the principal, e.g. for a service account token minted by the IAM Credentials API.
+// The token is only mapped to the principal if enforcement is on, so that issuing tokens doesn't enable enforcement.
+func (a *Authorizer) IssueToken(principal string) string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.issuedTokens++
+	token := fmt.Sprintf("ya29.mockgcp-%d", a.issuedTokens)
+	if len(a.principals) > 0 {
+		a.principals[token] = principal
+	}
+	return token
+}
+
+// AddBinding grants the role to the member on the resource (e.g. projects/my-project or folders/123),
+// without going through setIamPolicy.  This is useful to bootstrap permissions in tests.
+func (a *Authorizer) AddBinding(resource string, role string, member string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.bindings[resource] = append(a.bindings[resource], &iampb.Binding{Role: role, Members: []string{member}})
+}
+
+// AddRole defines a custom role (or overrides a predefined role), as a list of permissions.
+// Permissions can use * as a wildcard for a component, e.g. pubsub.topics.* or pubsub.*.get
+func (a *Authorizer) AddRole(role string, permissions ...string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.roles[role] = permissions
+}
+
+// SetPermission overrides the permission needed for a grpc method (e.g. /mockgcp.pubsub.v1.Publisher/CreateTopic),
+// for methods where the permission we derive from the method name is wrong.
+func (a *Authorizer) SetPermission(fullMethod string, permission string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.permissions[fullMethod] = permission
+}
+
+// Reset removes all principals, bindings, roles and permission overrides, disabling enforcement.
+func (a *Authorizer) Reset() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.principals = make(map[string]string)
+	a.bindings = make(map[string][]*iampb.Binding)
+	a.roles = make(map[string][]string)
+	a.permissions = make(map[string]string)
+}
+
+// Enforcing returns true once a principal has been added, i.e. when requests are checked.
+func (a *Authorizer) Enforcing() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return len(a.principals) > 0
+}
+
+// authenticate returns the principal for the authorization header.
+// It returns "" if the request should not be checked, either because enforcement is off or because it is an internal request.
+func (a *Authorizer) authenticate(authorization string) (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.principals) == 0 {
+		return "", nil
+	}
+	if authorization == "" {
+		return "", status.Errorf(codes.Unauthenticated, "Request is missing required authentication credential. Expected OAuth 2 access token, login cookie or other valid authentication credential.")
+	}
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if ok {
+		token = strings.TrimSpace(token)
+		if token == InternalToken {
+			return "", nil
+		}
+		if principal := a.principals[token]; principal != "" {
+			return principal, nil
+		}
+	}
+	return "", status.Errorf(codes.Unauthenticated, "Request had invalid authentication credentials. Expected OAuth 2 access token, login cookie or other valid authentication credential.")
+}
+
+// Principal returns the principal for the authorization header (e.g. Bearer <token>),
+// or "" if the request has no credentials or the token has not been added.
+func (a *Authorizer) Principal(authorization string) string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return a.principals[strings.TrimSpace(token)]
+}
+
+// authorize checks that the principal has the permission on the resource, or on one of its ancestors.
+func (a *Authorizer) authorize(ctx context.Context, principal string, permission string, host string, resource string) error {
+	a.mutex.Lock()
+	policies := a.policies
+	a.mutex.Unlock()
+
+	var ancestors []string
+	var err error
+	if policies != nil {
+		ancestors, err = policies.Ancestors(ctx, resource)
+	} else {
+		ancestors, err = emptyHierarchy.Ancestors(ctx, resource)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range ancestors {
+		bindings, err := a.bindingsFor(ctx, host, ancestor)
+		if err != nil {
+			return err
+		}
+		for _, binding := range bindings {
+			if !hasMember(binding, principal) {
+				continue
+			}
+			if a.roleGrants(binding.GetRole(), permission) {
+				return nil
+			}
+		}
+	}
+
+	klog.Infof("mockgcp authz: denying %q to %v on %q", permission, principal, resource)
+	return status.Errorf(codes.PermissionDenied, "Permission '%s' denied on resource '%s' (or it may not exist).", permission, resource)
+}
+
+// bindingsFor returns the role bindings set directly on the resource.
+func (a *Authorizer) bindingsFor(ctx context.Context, host string, resource string) ([]*iampb.Binding, error) {
+	a.mutex.Lock()
+	bindings := append([]*iampb.Binding(nil), a.bindings[resource]...)
+	policies := a.policies
+	a.mutex.Unlock()
+
+	if policies != nil {
+		iamPolicies, err := policies.IAMPolicies(ctx, host, resource)
+		if err != nil {
+			return nil, fmt.Errorf("getting IAM policies for %q: %w", resource, err)
+		}
+		for _, policy := range iamPolicies {
+			bindings = append(bindings, policy.GetBindings()...)
+		}
+	}
+	return bindings, nil
+}
+
+// hasMember returns true if the binding applies to the principal.
+// We don't evaluate conditions, or group membership.
+func hasMember(binding *iampb.Binding, principal string) bool {
+	for _, member := range binding.GetMembers() {
+		switch {
+		case member == principal:
+			return true
+		case member == "allUsers" || member == "allAuthenticatedUsers":
+			return true
+		case strings.HasPrefix(member, "domain:"):
+			domain := strings.TrimPrefix(member, "domain:")
+			if strings.HasSuffix(principal, "@"+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
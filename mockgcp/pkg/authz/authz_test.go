@@ -0,0 +1,281 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	resourcemanagerpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// fakePolicySource holds IAM policies by resource key (host and resource), and a hierarchy of projects and folders.
+type fakePolicySource struct {
+	policies  map[string]*iampb.Policy
+	hierarchy *hierarchy.Hierarchy
+}
+
+// newHierarchy stores the projects and folders, as resource name to parent.
+func newHierarchy(t *testing.T, parents map[string]string) *hierarchy.Hierarchy {
+	ctx := context.Background()
+	s := storage.NewInMemoryStorage()
+	for name, parent := range parents {
+		var obj proto.Message
+		if strings.HasPrefix(name, "projects/") {
+			obj = &resourcemanagerpb.Project{Name: name, ProjectId: strings.TrimPrefix(name, "projects/"), Parent: parent}
+		} else {
+			obj = &resourcemanagerpb.Folder{Name: name, Parent: parent}
+		}
+		if err := s.Create(ctx, name, obj); err != nil {
+			t.Fatalf("creating %v: %v", name, err)
+		}
+	}
+	return hierarchy.New(s)
+}
+
+func (f *fakePolicySource) IAMPolicies(ctx context.Context, host string, resource string) ([]*iampb.Policy, error) {
+	if policy := f.policies[host+"/"+resource]; policy != nil {
+		return []*iampb.Policy{policy}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakePolicySource) Ancestors(ctx context.Context, resource string) ([]string, error) {
+	return f.hierarchy.Ancestors(ctx, resource)
+}
+
+func invoke(a *Authorizer, token string, fullMethod string, req interface{}) error {
+	return invokeWithMetadata(a, metadata.Pairs("x-forwarded-host", "pubsub.googleapis.com", "authorization", "Bearer "+token), fullMethod, req)
+}
+
+func invokeWithMetadata(a *Authorizer, md metadata.MD, fullMethod string, req interface{}) error {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	_, err := a.UnaryServerInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	return err
+}
+
+func TestAuthorizer(t *testing.T) {
+	a := NewAuthorizer()
+	a.SetPolicySource(&fakePolicySource{
+		policies: map[string]*iampb.Policy{
+			"pubsub.googleapis.com/folders/100": {
+				Bindings: []*iampb.Binding{
+					{Role: "roles/pubsub.viewer", Members: []string{"user:viewer@example.com"}},
+				},
+			},
+			"pubsub.googleapis.com/projects/p/topics/shared": {
+				Bindings: []*iampb.Binding{
+					{Role: "roles/pubsub.editor", Members: []string{"domain:partner.com"}},
+				},
+			},
+		},
+		hierarchy: newHierarchy(t, map[string]string{
+			"projects/p":  "folders/100",
+			"folders/100": "organizations/1",
+		}),
+	})
+
+	createTopic := "/mockgcp.pubsub.v1.Publisher/CreateTopic"
+	getTopic := "/mockgcp.pubsub.v1.Publisher/GetTopic"
+
+	// Enforcement is off until we add a principal
+	if err := invoke(a, "anything", createTopic, &pb.Topic{Name: "projects/p/topics/t"}); err != nil {
+		t.Errorf("expected requests to be allowed without principals, got %v", err)
+	}
+
+	a.AddPrincipal("admin-token", "serviceAccount:admin@p.iam.gserviceaccount.com")
+	a.AddPrincipal("viewer-token", "user:viewer@example.com")
+	a.AddPrincipal("partner-token", "user:someone@partner.com")
+	a.AddBinding("organizations/1", "roles/owner", "serviceAccount:admin@p.iam.gserviceaccount.com")
+
+	grid := []struct {
+		name     string
+		token    string
+		method   string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{name: "unknown token", token: "bad-token", method: getTopic, req: &pb.GetTopicRequest{Topic: "projects/p/topics/t"}, wantCode: codes.Unauthenticated},
+		{name: "internal requests are allowed", token: InternalToken, method: createTopic, req: &pb.Topic{Name: "projects/p/topics/t"}},
+		{name: "owner inherited from organization", token: "admin-token", method: createTopic, req: &pb.Topic{Name: "projects/p/topics/t"}},
+		{name: "viewer inherited from folder can get", token: "viewer-token", method: getTopic, req: &pb.GetTopicRequest{Topic: "projects/p/topics/t"}},
+		{name: "viewer cannot create", token: "viewer-token", method: createTopic, req: &pb.Topic{Name: "projects/p/topics/t"}, wantCode: codes.PermissionDenied},
+		{name: "domain member can update shared topic", token: "partner-token", method: "/mockgcp.pubsub.v1.Publisher/UpdateTopic", req: &pb.UpdateTopicRequest{Topic: &pb.Topic{Name: "projects/p/topics/shared"}}},
+		{name: "domain member cannot get other topics", token: "partner-token", method: getTopic, req: &pb.GetTopicRequest{Topic: "projects/p/topics/t"}, wantCode: codes.PermissionDenied},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := invoke(a, g.token, g.method, g.req)
+			if got := status.Code(err); got != g.wantCode {
+				t.Errorf("unexpected result, got %v (%v), want %v", got, err, g.wantCode)
+			}
+		})
+	}
+
+	// Requests without credentials are rejected once enforcement is on
+	if err := invokeWithMetadata(a, metadata.Pairs("x-forwarded-host", "pubsub.googleapis.com"), getTopic, &pb.GetTopicRequest{Topic: "projects/p/topics/t"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected a request without credentials to be unauthenticated, got %v", err)
+	}
+
+	// Direct grpc calls don't have a host, so we find the policies by the host of the grpc service
+	if err := invokeWithMetadata(a, metadata.Pairs("authorization", "Bearer partner-token"), "/mockgcp.pubsub.v1.Publisher/UpdateTopic", &pb.UpdateTopicRequest{Topic: &pb.Topic{Name: "projects/p/topics/shared"}}); err != nil {
+		t.Errorf("expected direct grpc call to be allowed by the policy on the topic, got %v", err)
+	}
+
+	// Custom roles and permission overrides
+	a.AddRole("projects/p/roles/publisher", "pubsub.topics.publish")
+	a.AddBinding("projects/p", "projects/p/roles/publisher", "user:someone@partner.com")
+	if err := invoke(a, "partner-token", "/mockgcp.pubsub.v1.Publisher/Publish", &pb.PublishRequest{Topic: "projects/p/topics/t"}); err != nil {
+		t.Errorf("expected publish to be allowed by custom role, got %v", err)
+	}
+	a.SetPermission("/mockgcp.pubsub.v1.Publisher/Publish", "pubsub.topics.somethingElse")
+	if err := invoke(a, "partner-token", "/mockgcp.pubsub.v1.Publisher/Publish", &pb.PublishRequest{Topic: "projects/p/topics/t"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected publish to be denied after permission override, got %v", err)
+	}
+}
+
+func TestAuthorizeHTTP(t *testing.T) {
+	a := NewAuthorizer()
+	a.AddPrincipal("viewer-token", "user:viewer@example.com")
+	a.AddBinding("projects/p", "roles/viewer", "user:viewer@example.com")
+
+	req := httptest.NewRequest("POST", "https://pubsub.googleapis.com/v1/projects/p/topics/t:getIamPolicy", nil)
+	req.Header.Set("Authorization", "Bearer viewer-token")
+	if err := a.AuthorizeHTTP(req, "getIamPolicy", "projects/p/topics/t"); err != nil {
+		t.Errorf("expected getIamPolicy to be allowed, got %v", err)
+	}
+	if err := a.AuthorizeHTTP(req, "setIamPolicy", "projects/p/topics/t"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected setIamPolicy to be denied, got %v", err)
+	}
+}
+
+func TestTestPermissionsHTTP(t *testing.T) {
+	a := NewAuthorizer()
+	a.AddPrincipal("viewer-token", "user:viewer@example.com")
+	a.AddBinding("projects/p", "roles/pubsub.viewer", "user:viewer@example.com")
+
+	req := httptest.NewRequest("POST", "https://cloudresourcemanager.googleapis.com/v3/projects/p:testIamPermissions", nil)
+	req.Header.Set("Authorization", "Bearer viewer-token")
+	granted, err := a.TestPermissionsHTTP(req, "projects/p", []string{"pubsub.topics.get", "pubsub.topics.create"})
+	if err != nil {
+		t.Fatalf("TestPermissionsHTTP failed: %v", err)
+	}
+	if len(granted) != 1 || granted[0] != "pubsub.topics.get" {
+		t.Errorf("expected only pubsub.topics.get to be granted, got %v", granted)
+	}
+
+	req.Header.Set("Authorization", "Bearer unknown-token")
+	if _, err := a.TestPermissionsHTTP(req, "projects/p", []string{"pubsub.topics.get"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected an unknown token to be unauthenticated, got %v", err)
+	}
+}
+
+func TestEnforcing(t *testing.T) {
+	a := NewAuthorizer()
+	a.IssueToken("serviceAccount:sa@p.iam.gserviceaccount.com")
+	if a.Enforcing() {
+		t.Errorf("expected no enforcement before a principal is added")
+	}
+	a.AddPrincipal("admin-token", "user:admin@example.com")
+	if !a.Enforcing() {
+		t.Errorf("expected enforcement once a principal is added")
+	}
+	a.Reset()
+	if a.Enforcing() {
+		t.Errorf("expected no enforcement after a reset")
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	a := NewAuthorizer()
+	if token := a.IssueToken("serviceAccount:sa@p.iam.gserviceaccount.com"); a.Principal("Bearer "+token) != "" {
+		t.Errorf("expected tokens issued without enforcement not to be mapped to a principal")
+	}
+
+	a.AddPrincipal("admin-token", "user:admin@example.com")
+	token := a.IssueToken("serviceAccount:sa@p.iam.gserviceaccount.com")
+	if got := a.Principal("Bearer " + token); got != "serviceAccount:sa@p.iam.gserviceaccount.com" {
+		t.Errorf("expected the issued token to be mapped to the service account, got %q", got)
+	}
+	if other := a.IssueToken("serviceAccount:sa@p.iam.gserviceaccount.com"); other == token {
+		t.Errorf("expected issued tokens to be unique, got %q twice", token)
+	}
+}
+
+func TestPermissionFor(t *testing.T) {
+	grid := []struct {
+		method   string
+		resource string
+		want     string
+	}{
+		{method: "CreateTopic", resource: "projects/p", want: "pubsub.topics.create"},
+		{method: "ListTopics", resource: "projects/p", want: "pubsub.topics.list"},
+		{method: "GetTopic", resource: "projects/p/topics/t", want: "pubsub.topics.get"},
+		{method: "UpdateTopic", resource: "projects/p/topics/t", want: "pubsub.topics.update"},
+		{method: "CreatePolicy", resource: "projects/p", want: "pubsub.policies.create"},
+		{method: "Publish", resource: "projects/p/topics/t", want: "pubsub.topics.publish"},
+		{method: "GetIamPolicy", resource: "projects/p/topics/t", want: "pubsub.topics.getIamPolicy"},
+	}
+	for _, g := range grid {
+		if got := permissionFor("pubsub", g.method, g.resource); got != g.want {
+			t.Errorf("permissionFor(%q, %q) = %q, want %q", g.method, g.resource, got, g.want)
+		}
+	}
+}
+
+func TestInternalTransport(t *testing.T) {
+	var got []string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Header.Get("Authorization"))
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	transport := InternalTransport(next)
+
+	req := httptest.NewRequest("GET", "https://compute.googleapis.com/compute/v1/projects/p/global/networks", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer user-token")
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+
+	want := []string{"Bearer " + InternalToken, "Bearer user-token"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected authorization headers, got %v, want %v", got, want)
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
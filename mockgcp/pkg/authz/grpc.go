@@ -0,0 +1,179 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+)
+
+// UnaryServerInterceptor is a grpc interceptor that checks the caller has the permission for the request.
+// grpc-gateway passes the Authorization header as authorization metadata, and the host as x-forwarded-host.
+func (a *Authorizer) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+
+	// Polling operations is allowed for anyone who could start them, and anyone can test their own permissions
+	if service == "google.longrunning.Operations" || method == "TestIamPermissions" {
+		return handler(ctx, req)
+	}
+
+	var authorization, host string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) != 0 {
+			authorization = values[0]
+		}
+		if values := md.Get("x-forwarded-host"); len(values) != 0 {
+			host = stripPort(values[0])
+		}
+	}
+	if host == "" {
+		// Direct grpc calls don't have a host, but IAM policies are stored by the host that serves the resource
+		host = serviceHost(service)
+	}
+
+	principal, err := a.authenticate(authorization)
+	if err != nil {
+		return nil, err
+	}
+	if principal == "" {
+		return handler(ctx, req)
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+	resource := fields.RequestResourceName(msg)
+	if resource == "" {
+		return handler(ctx, req)
+	}
+
+	a.mutex.Lock()
+	permission := a.permissions[info.FullMethod]
+	a.mutex.Unlock()
+	if permission == "" {
+		permission = permissionFor(servicePrefix(host, service), method, resource)
+	}
+
+	if err := a.authorize(ctx, principal, permission, host, resource); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// servicePrefix returns the prefix of the permissions for the service, e.g. pubsub for pubsub.googleapis.com.
+// We use the host if we have it, otherwise the grpc service name (e.g. mockgcp.pubsub.v1.Publisher).
+func servicePrefix(host string, grpcService string) string {
+	if host != "" {
+		prefix, _, _ := strings.Cut(host, ".")
+		if prefix == "cloudresourcemanager" {
+			return "resourcemanager"
+		}
+		return prefix
+	}
+
+	tokens := strings.Split(grpcService, ".")
+	for _, token := range tokens {
+		if token == "mockgcp" || token == "google" || token == "cloud" {
+			continue
+		}
+		return token
+	}
+	return grpcService
+}
+
+// serviceHost returns the host that serves a grpc service, e.g. pubsub.googleapis.com for mockgcp.pubsub.v1.Publisher
+func serviceHost(grpcService string) string {
+	prefix := servicePrefix("", grpcService)
+	if prefix == "resourcemanager" {
+		return "cloudresourcemanager.googleapis.com"
+	}
+	return prefix + ".googleapis.com"
+}
+
+// standardVerbs maps the prefixes of standard methods to the verb in their permission.
+var standardVerbs = []struct {
+	prefix string
+	verb   string
+}{
+	{"Create", "create"},
+	{"Get", "get"},
+	{"List", "list"},
+	{"Update", "update"},
+	{"Patch", "update"},
+	{"Delete", "delete"},
+	{"Undelete", "undelete"},
+}
+
+// permissionFor derives the permission for a method, following the usual GCP convention of <service>.<collection>.<verb>.
+// For example CreateTopic on projects/p needs pubsub.topics.create, and Publish on projects/p/topics/t needs pubsub.topics.publish.
+func permissionFor(service string, method string, resource string) string {
+	if method == "GetIamPolicy" || method == "SetIamPolicy" {
+		return service + "." + collection(resource) + "." + lowerFirst(method)
+	}
+	for _, standard := range standardVerbs {
+		noun, ok := strings.CutPrefix(method, standard.prefix)
+		if !ok || noun == "" || !unicode.IsUpper(rune(noun[0])) {
+			continue
+		}
+		if standard.verb == "create" || standard.verb == "list" {
+			// The resource is the parent, so we take the collection from the method
+			return service + "." + plural(lowerFirst(noun)) + "." + standard.verb
+		}
+		return service + "." + collection(resource) + "." + standard.verb
+	}
+	return service + "." + collection(resource) + "." + lowerFirst(method)
+}
+
+// collection returns the collection of a resource name, e.g. topics for projects/p/topics/t
+func collection(resource string) string {
+	tokens := strings.Split(strings.Trim(resource, "/"), "/")
+	if len(tokens) >= 2 && len(tokens)%2 == 0 {
+		return tokens[len(tokens)-2]
+	}
+	return tokens[len(tokens)-1]
+}
+
+func plural(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "s"):
+		return noun
+	case strings.HasSuffix(noun, "y"):
+		return strings.TrimSuffix(noun, "y") + "ies"
+	default:
+		return noun + "s"
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func stripPort(host string) string {
+	if i := strings.LastIndex(host, ":"); i != -1 {
+		return host[:i]
+	}
+	return host
+}
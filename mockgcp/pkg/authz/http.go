@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizeHTTP checks a request that is served directly, rather than through grpc (for example the IAM policy verbs).
+// verb is the custom method (e.g. setIamPolicy) and resource is the resource it acts on (e.g. projects/p/topics/t).
+// It returns nil if the request is allowed, otherwise an Unauthenticated or PermissionDenied error.
+func (a *Authorizer) AuthorizeHTTP(req *http.Request, verb string, resource string) error {
+	principal, err := a.authenticate(req.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+	if principal == "" {
+		return nil
+	}
+
+	host := req.URL.Hostname()
+	permission := servicePrefix(host, "") + "." + collection(resource) + "." + verb
+	return a.authorize(req.Context(), principal, permission, host, resource)
+}
+
+// AuthorizePermissionHTTP checks that the caller of a request that is served directly has the permission on the resource,
+// which is served by host.  It is for requests whose permission is not derived from the verb, e.g. generateAccessToken.
+func (a *Authorizer) AuthorizePermissionHTTP(req *http.Request, permission string, host string, resource string) error {
+	principal, err := a.authenticate(req.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+	if principal == "" {
+		return nil
+	}
+	return a.authorize(req.Context(), principal, permission, host, resource)
+}
+
+// TestPermissionsHTTP implements testIamPermissions: it returns the permissions, out of those requested, that the caller
+// of the request has on the resource.  Like GCP, it doesn't require any permission, only valid credentials.
+func (a *Authorizer) TestPermissionsHTTP(req *http.Request, resource string, permissions []string) ([]string, error) {
+	principal, err := a.authenticate(req.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+	if principal == "" {
+		return permissions, nil
+	}
+
+	var granted []string
+	for _, permission := range permissions {
+		err := a.authorize(req.Context(), principal, permission, req.URL.Hostname(), resource)
+		if err == nil {
+			granted = append(granted, permission)
+			continue
+		}
+		if status.Code(err) != codes.PermissionDenied {
+			return nil, err
+		}
+	}
+	return granted, nil
+}
+
+// InternalTransport wraps an http.RoundTripper for requests that mockgcp makes to itself,
+// authenticating them with InternalToken unless they already have credentials.
+func InternalTransport(next http.RoundTripper) http.RoundTripper {
+	return &internalTransport{next: next}
+}
+
+type internalTransport struct {
+	next http.RoundTripper
+}
+
+func (t *internalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+InternalToken)
+	}
+	return t.next.RoundTrip(req)
+}
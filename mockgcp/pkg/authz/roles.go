@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"path"
+	"strings"
+)
+
+// roleDefinition holds the permissions of a role, as patterns.
+type roleDefinition struct {
+	permissions []string
+	// excluded holds permissions that are not granted, even if they match permissions.
+	excluded []string
+}
+
+// predefinedRoles approximates the predefined GCP roles that are not of the form roles/<service>.<level>.
+var predefinedRoles = map[string]roleDefinition{
+	"roles/owner": {
+		permissions: []string{"*"},
+	},
+	"roles/editor": {
+		permissions: []string{"*"},
+		excluded:    []string{"*.setIamPolicy"},
+	},
+	"roles/viewer": {
+		permissions: []string{"*.get", "*.list", "*.getIamPolicy"},
+	},
+	"roles/iam.securityAdmin": {
+		permissions: []string{"*.getIamPolicy", "*.setIamPolicy"},
+	},
+	"roles/iam.securityReviewer": {
+		permissions: []string{"*.getIamPolicy"},
+	},
+	"roles/iam.serviceAccountTokenCreator": {
+		permissions: []string{"iam.serviceAccounts.getAccessToken", "iam.serviceAccounts.getOpenIdToken", "iam.serviceAccounts.signBlob", "iam.serviceAccounts.signJwt", "iam.serviceAccounts.implicitDelegation"},
+	},
+	"roles/iam.workloadIdentityUser": {
+		permissions: []string{"iam.serviceAccounts.getAccessToken", "iam.serviceAccounts.getOpenIdToken"},
+	},
+	"roles/resourcemanager.projectIamAdmin": {
+		permissions: []string{"resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy"},
+	},
+	"roles/resourcemanager.folderIamAdmin": {
+		permissions: []string{"resourcemanager.folders.getIamPolicy", "resourcemanager.folders.setIamPolicy"},
+	},
+	"roles/resourcemanager.organizationAdmin": {
+		permissions: []string{"resourcemanager.*"},
+	},
+}
+
+// serviceRole approximates the predefined roles of the form roles/<service>.admin, roles/<service>.editor and roles/<service>.viewer.
+func serviceRole(role string) (roleDefinition, bool) {
+	name, ok := strings.CutPrefix(role, "roles/")
+	if !ok {
+		return roleDefinition{}, false
+	}
+	service, level, ok := strings.Cut(name, ".")
+	if !ok {
+		return roleDefinition{}, false
+	}
+	switch level {
+	case "admin":
+		return roleDefinition{permissions: []string{service + ".*"}}, true
+	case "editor":
+		return roleDefinition{permissions: []string{service + ".*"}, excluded: []string{"*.setIamPolicy"}}, true
+	case "viewer":
+		return roleDefinition{permissions: []string{service + ".*.get", service + ".*.list", service + ".*.getIamPolicy"}}, true
+	}
+	return roleDefinition{}, false
+}
+
+// roleGrants returns true if the role includes the permission.
+func (a *Authorizer) roleGrants(role string, permission string) bool {
+	a.mutex.Lock()
+	custom, isCustom := a.roles[role]
+	a.mutex.Unlock()
+
+	var def roleDefinition
+	if isCustom {
+		def = roleDefinition{permissions: custom}
+	} else if predefined, ok := predefinedRoles[role]; ok {
+		def = predefined
+	} else if predefined, ok := serviceRole(role); ok {
+		def = predefined
+	} else {
+		return false
+	}
+
+	return matchesAny(def.permissions, permission) && !matchesAny(def.excluded, permission)
+}
+
+// matchesAny returns true if the permission matches any of the patterns.
+// A * in a pattern matches any sequence of characters, including dots.
+func matchesAny(patterns []string, permission string) bool {
+	for _, pattern := range patterns {
+		if match, _ := path.Match(pattern, permission); match {
+			return true
+		}
+	}
+	return false
+}
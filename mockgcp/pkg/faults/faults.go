@@ -0,0 +1,254 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faults injects failures into mockgcp, so that we can test how clients handle a misbehaving GCP.
+package faults
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/filter"
+)
+
+// Rule describes a fault, and the requests it applies to.
+// Empty match fields match all requests; patterns can use * to match any sequence of characters.
+type Rule struct {
+	// Service matches the API host (e.g. pubsub.googleapis.com), or the grpc service name (e.g. mockgcp.pubsub.v1.Publisher).
+	Service string
+	// Method matches the grpc method name (e.g. CreateTopic), or the IAM verb (e.g. setIamPolicy).
+	Method string
+	// Resource matches the resource name of the request, e.g. projects/*/topics/my-topic.
+	// This is the name field of the request, falling back to parent, resource or the first field that looks like a resource path.
+	Resource string
+
+	// Times limits how many times the rule applies; 0 means the rule always applies.
+	Times int
+
+	// Code is the error returned instead of serving the request,
+	// e.g. codes.ResourceExhausted (HTTP 429), codes.Aborted (HTTP 409),
+	// codes.Internal (HTTP 500), codes.Unavailable (HTTP 503) or codes.FailedPrecondition.
+	// If FailOperation is set, the code is instead used as the error of the operation.
+	Code codes.Code
+	// Message is the error message; a generic message is used if not set.
+	Message string
+
+	// Latency is added before the request is served.
+	Latency time.Duration
+
+	// FailOperation makes long-running operations returned by the method fail, with Code (or codes.Internal).
+	// The request is still served, and later polls of the operation also report the failure.
+	// The work of the operation is still performed, so (as when a GCP operation fails part way through)
+	// the resource may exist even though the operation failed.
+	FailOperation bool
+
+	// NotFoundReads simulates eventual consistency: after the request succeeds,
+	// the next NotFoundReads Get calls for the returned resource return NotFound.
+	NotFoundReads int
+}
+
+// Injector holds the fault rules for a mockgcp instance.  It is safe for concurrent use.
+type Injector struct {
+	mutex sync.Mutex
+
+	rules []*ruleState
+
+	// failedOperations holds the operations that we have failed, so that polls also fail.
+	failedOperations map[string]*status.Status
+
+	// notFoundReads holds the remaining number of NotFound responses, by resource name.
+	notFoundReads map[string]int
+}
+
+type ruleState struct {
+	Rule
+
+	service  *regexp.Regexp
+	method   *regexp.Regexp
+	resource *regexp.Regexp
+
+	// remaining is the number of times the rule can still apply, if Times is set.
+	remaining int
+}
+
+// NewInjector constructs an Injector with no rules.
+func NewInjector() *Injector {
+	return &Injector{
+		failedOperations: make(map[string]*status.Status),
+		notFoundReads:    make(map[string]int),
+	}
+}
+
+// AddRule adds a fault rule.
+func (i *Injector) AddRule(rule Rule) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.rules = append(i.rules, &ruleState{
+		Rule:      rule,
+		service:   compilePattern(rule.Service),
+		method:    compilePattern(rule.Method),
+		resource:  compilePattern(rule.Resource),
+		remaining: rule.Times,
+	})
+}
+
+// Reset removes all rules, and forgets any injected state (failed operations and pending NotFound reads).
+func (i *Injector) Reset() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.rules = nil
+	i.failedOperations = make(map[string]*status.Status)
+	i.notFoundReads = make(map[string]int)
+}
+
+// request identifies a call, for matching against rules.
+type request struct {
+	// services holds the names that the Service pattern is matched against.
+	services []string
+	method   string
+	resource string
+}
+
+func (r *request) String() string {
+	return strings.Join(r.services, ",") + "/" + r.method + " " + r.resource
+}
+
+// match returns the rules that apply to the request, consuming one use of each rule.
+func (i *Injector) match(req *request) []Rule {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	var matches []Rule
+	for _, rule := range i.rules {
+		if rule.Times != 0 && rule.remaining <= 0 {
+			continue
+		}
+		if !rule.matches(req) {
+			continue
+		}
+		rule.remaining--
+		klog.Infof("mockgcp fault injection: rule %+v matches %v", rule.Rule, req)
+		matches = append(matches, rule.Rule)
+	}
+	return matches
+}
+
+func (r *ruleState) matches(req *request) bool {
+	if r.service != nil {
+		found := false
+		for _, service := range req.services {
+			if r.service.MatchString(service) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.method != nil && !r.method.MatchString(req.method) {
+		return false
+	}
+	if r.resource != nil && !r.resource.MatchString(req.resource) {
+		return false
+	}
+	return true
+}
+
+// takeNotFoundRead returns true if a read of the resource should return NotFound.
+func (i *Injector) takeNotFoundRead(resource string) bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	n := i.notFoundReads[resource]
+	if n <= 0 {
+		return false
+	}
+	if n == 1 {
+		delete(i.notFoundReads, resource)
+	} else {
+		i.notFoundReads[resource] = n - 1
+	}
+	return true
+}
+
+func (i *Injector) addNotFoundReads(resource string, n int) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.notFoundReads[resource] = n
+}
+
+func (i *Injector) addFailedOperation(name string, st *status.Status) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.failedOperations[name] = st
+}
+
+func (i *Injector) failedOperation(name string) *status.Status {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.failedOperations[name]
+}
+
+// injectError returns the error to return for the matched rules (if any), after applying any latency.
+func injectError(ctx context.Context, rules []Rule) error {
+	for _, rule := range rules {
+		if rule.Latency != 0 {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-time.After(rule.Latency):
+			}
+		}
+	}
+	for _, rule := range rules {
+		if rule.Code != codes.OK && !rule.FailOperation {
+			return rule.status().Err()
+		}
+	}
+	return nil
+}
+
+func (r *Rule) status() *status.Status {
+	code := r.Code
+	if code == codes.OK {
+		code = codes.Internal
+	}
+	message := r.Message
+	if message == "" {
+		message = "mockgcp injected fault: " + code.String()
+	}
+	return status.New(code, message)
+}
+
+// compilePattern converts a pattern, where * matches any sequence of characters, to a regex.
+// An empty pattern returns nil, which matches everything.
+func compilePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	return filter.CompileWildcard(pattern)
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+)
+
+const (
+	createTopic  = "/mockgcp.pubsub.v1.Publisher/CreateTopic"
+	getTopic     = "/mockgcp.pubsub.v1.Publisher/GetTopic"
+	getOperation = "/google.longrunning.Operations/GetOperation"
+)
+
+// invoke calls the interceptor, with a handler that echoes the request (or returns resp, if set).
+func invoke(ctx context.Context, injector *Injector, fullMethod string, req interface{}, resp interface{}) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+	return injector.UnaryServerInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if resp != nil {
+			return resp, nil
+		}
+		return req, nil
+	})
+}
+
+func TestErrorRule(t *testing.T) {
+	ctx := context.Background()
+
+	injector := NewInjector()
+	injector.AddRule(Rule{
+		Service:  "pubsub.googleapis.com",
+		Method:   "CreateTopic",
+		Resource: "projects/*/topics/flaky",
+		Times:    2,
+		Code:     codes.ResourceExhausted,
+	})
+
+	httpCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-host", "pubsub.googleapis.com:443"))
+
+	// Different resource, not matched
+	if _, err := invoke(httpCtx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/other"}, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// Direct grpc calls don't match the host
+	if _, err := invoke(ctx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/flaky"}, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := invoke(httpCtx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/flaky"}, nil); status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("expected ResourceExhausted, got %v", err)
+		}
+	}
+	// Rule only applies twice
+	if _, err := invoke(httpCtx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/flaky"}, nil); err != nil {
+		t.Errorf("unexpected error after rule exhausted: %v", err)
+	}
+
+	injector.AddRule(Rule{Service: "mockgcp.pubsub.v1.Publisher", Code: codes.Unavailable})
+	if _, err := invoke(ctx, injector, getTopic, &pb.GetTopicRequest{Topic: "projects/p/topics/t"}, nil); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+
+	injector.Reset()
+	if _, err := invoke(ctx, injector, getTopic, &pb.GetTopicRequest{Topic: "projects/p/topics/t"}, nil); err != nil {
+		t.Errorf("unexpected error after reset: %v", err)
+	}
+}
+
+func TestFailOperation(t *testing.T) {
+	ctx := context.Background()
+
+	injector := NewInjector()
+	injector.AddRule(Rule{Method: "CreateTopic", FailOperation: true, Code: codes.FailedPrecondition, Message: "no capacity"})
+
+	op := &longrunningpb.Operation{Name: "operations/op1"}
+	resp, err := invoke(ctx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/t"}, op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := resp.(*longrunningpb.Operation)
+	if !got.GetDone() || got.GetError().GetCode() != int32(codes.FailedPrecondition) || got.GetError().GetMessage() != "no capacity" {
+		t.Errorf("expected failed operation, got %v", got)
+	}
+	if op.GetDone() {
+		t.Errorf("original operation should not be modified")
+	}
+
+	// Polling the operation should also report the failure
+	resp, err = invoke(ctx, injector, getOperation, &longrunningpb.GetOperationRequest{Name: "operations/op1"}, &longrunningpb.Operation{Name: "operations/op1", Done: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.(*longrunningpb.Operation).GetError() == nil {
+		t.Errorf("expected polled operation to be failed, got %v", resp)
+	}
+
+	// Other operations are not affected
+	resp, err = invoke(ctx, injector, getOperation, &longrunningpb.GetOperationRequest{Name: "operations/op2"}, &longrunningpb.Operation{Name: "operations/op2", Done: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.(*longrunningpb.Operation).GetError() != nil {
+		t.Errorf("expected other operation to succeed, got %v", resp)
+	}
+}
+
+func TestNotFoundReads(t *testing.T) {
+	ctx := context.Background()
+
+	injector := NewInjector()
+	injector.AddRule(Rule{Method: "CreateTopic", NotFoundReads: 2})
+
+	if _, err := invoke(ctx, injector, createTopic, &pb.Topic{Name: "projects/p/topics/t"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	get := &pb.GetTopicRequest{Topic: "projects/p/topics/t"}
+	for i := 0; i < 2; i++ {
+		if _, err := invoke(ctx, injector, getTopic, get, nil); status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound on read %d, got %v", i, err)
+		}
+	}
+	if _, err := invoke(ctx, injector, getTopic, get, nil); err != nil {
+		t.Errorf("unexpected error after eventual consistency: %v", err)
+	}
+}
+
+func TestInjectHTTP(t *testing.T) {
+	injector := NewInjector()
+	injector.AddRule(Rule{Service: "pubsub.googleapis.com", Method: "setIamPolicy", Code: codes.Aborted, Message: "concurrent change"})
+
+	req := httptest.NewRequest("POST", "https://pubsub.googleapis.com/v1/projects/p/topics/t:setIamPolicy", nil)
+	if response := injector.InjectHTTP(req, "getIamPolicy", "projects/p/topics/t"); response != nil {
+		t.Errorf("unexpected response for getIamPolicy: %v", response.Status)
+	}
+
+	response := injector.InjectHTTP(req, "setIamPolicy", "projects/p/topics/t")
+	if response == nil {
+		t.Fatalf("expected error response for setIamPolicy")
+	}
+	if response.StatusCode != http.StatusConflict {
+		t.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	var body struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("parsing body %q: %v", string(b), err)
+	}
+	if body.Error.Code != 409 || body.Error.Status != "ABORTED" || body.Error.Message != "concurrent change" {
+		t.Errorf("unexpected error body %s", string(b))
+	}
+}
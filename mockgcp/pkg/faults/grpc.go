@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// UnaryServerInterceptor is a grpc interceptor that applies the fault rules.
+// HTTP requests served by grpc-gateway are matched by their host, which grpc-gateway passes as x-forwarded-host.
+func (i *Injector) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	call := buildGRPCRequest(ctx, info.FullMethod, req)
+
+	if strings.HasPrefix(call.method, "Get") && call.resource != "" && i.takeNotFoundRead(call.resource) {
+		klog.Infof("mockgcp fault injection: simulating eventual consistency for %v", call)
+		return nil, status.Errorf(codes.NotFound, "%q not found (mockgcp simulated eventual consistency)", call.resource)
+	}
+
+	rules := i.match(call)
+	if err := injectError(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, rule := range rules {
+		if rule.FailOperation {
+			op, ok := resp.(*longrunningpb.Operation)
+			if !ok {
+				klog.Warningf("mockgcp fault injection: cannot fail operation for %v, response is %T", call, resp)
+				continue
+			}
+			i.addFailedOperation(op.GetName(), rule.status())
+		}
+		if rule.NotFoundReads != 0 {
+			name := resourceNameFromResponse(resp)
+			if name == "" {
+				name = call.resource
+			}
+			i.addNotFoundReads(name, rule.NotFoundReads)
+		}
+	}
+
+	if op, ok := resp.(*longrunningpb.Operation); ok {
+		if st := i.failedOperation(op.GetName()); st != nil {
+			op = proto.Clone(op).(*longrunningpb.Operation)
+			op.Done = true
+			op.Result = &longrunningpb.Operation_Error{Error: st.Proto()}
+			resp = op
+		}
+	}
+
+	return resp, nil
+}
+
+// buildGRPCRequest extracts the fields we match on from a grpc request.
+func buildGRPCRequest(ctx context.Context, fullMethod string, req interface{}) *request {
+	call := &request{}
+
+	// fullMethod is of the form /mockgcp.pubsub.v1.Publisher/CreateTopic
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	call.method = method
+	call.services = append(call.services, service)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, host := range md.Get("x-forwarded-host") {
+			// Strip any port
+			if i := strings.LastIndex(host, ":"); i != -1 {
+				host = host[:i]
+			}
+			call.services = append(call.services, host)
+		}
+	}
+
+	if msg, ok := req.(proto.Message); ok {
+		call.resource = fields.RequestResourceName(msg)
+	}
+	return call
+}
+
+// resourceNameFromResponse returns the name of the resource in a response.
+// For long-running operations, we look at the result, falling back to the target in the metadata.
+func resourceNameFromResponse(resp interface{}) string {
+	op, ok := resp.(*longrunningpb.Operation)
+	if !ok {
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return ""
+		}
+		return fields.StringField(msg.ProtoReflect(), "name")
+	}
+
+	if result := storage.UnpackAny(op.GetResponse()); result != nil {
+		if name := fields.StringField(result.ProtoReflect(), "name"); name != "" {
+			return name
+		}
+	}
+	if metadata := storage.UnpackAny(op.GetMetadata()); metadata != nil {
+		return fields.StringField(metadata.ProtoReflect(), "target")
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+)
+
+// InjectHTTP applies the fault rules to an HTTP request that is served directly, rather than through grpc
+// (for example the IAM policy verbs).
+// If the request should fail, it returns the error response; otherwise it returns nil and the request should be served.
+func (i *Injector) InjectHTTP(req *http.Request, method string, resource string) *http.Response {
+	call := &request{
+		services: []string{req.URL.Hostname()},
+		method:   method,
+		resource: resource,
+	}
+	rules := i.match(call)
+	if err := injectError(req.Context(), rules); err != nil {
+		return errorResponse(status.Convert(err))
+	}
+	return nil
+}
+
+// errorResponse builds an HTTP response in the format GCP uses for errors.
+func errorResponse(st *status.Status) *http.Response {
+	statusCode := runtime.HTTPStatusFromCode(st.Code())
+
+	body := map[string]any{
+		"error": map[string]any{
+			"code":    statusCode,
+			"message": st.Message(),
+			"status":  code.Code(st.Code()).String(),
+		},
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		b = []byte("{}")
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}
+}
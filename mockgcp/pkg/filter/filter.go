@@ -0,0 +1,428 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Filter is a parsed filter expression, which can be evaluated against proto messages.
+//
+// We support the commonly used subset of AIP-160: AND, OR, NOT (or -), parentheses,
+// the comparators = != < <= > >= and : (has), traversal of message and map fields
+// (e.g. labels.env = "prod"), and * wildcards in string values.
+// Global restrictions (bare values without a field) and functions are not supported.
+type Filter struct {
+	expression string
+	root       node
+}
+
+// Parse parses a filter expression.  An empty expression matches all objects.
+func Parse(expression string) (*Filter, error) {
+	f := &Filter{expression: expression}
+	if strings.TrimSpace(expression) == "" {
+		return f, nil
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+	f.root = root
+	return f, nil
+}
+
+// String returns the original filter expression.
+func (f *Filter) String() string {
+	return f.expression
+}
+
+// Matches returns true if the message satisfies the filter.
+// An error is returned if the filter refers to fields that do not exist, or compares them with values of the wrong type.
+func (f *Filter) Matches(msg proto.Message) (bool, error) {
+	if f.root == nil {
+		return true, nil
+	}
+	return f.root.eval(msg.ProtoReflect())
+}
+
+type node interface {
+	eval(msg protoreflect.Message) (bool, error)
+}
+
+type andNode []node
+
+func (n andNode) eval(msg protoreflect.Message) (bool, error) {
+	for _, term := range n {
+		ok, err := term.eval(msg)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type orNode []node
+
+func (n orNode) eval(msg protoreflect.Message) (bool, error) {
+	for _, term := range n {
+		ok, err := term.eval(msg)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+type notNode struct {
+	term node
+}
+
+func (n *notNode) eval(msg protoreflect.Message) (bool, error) {
+	ok, err := n.term.eval(msg)
+	if err != nil {
+		return false, err
+	}
+	return !ok, nil
+}
+
+// restriction is a single comparison, e.g. labels.env = "prod"
+type restriction struct {
+	path     []string
+	operator string
+	value    string
+	quoted   bool
+}
+
+func (r *restriction) eval(msg protoreflect.Message) (bool, error) {
+	return r.evalPath(msg, r.path)
+}
+
+// isPresenceCheck is true for `field:*`, which tests that the field is set.
+func (r *restriction) isPresenceCheck() bool {
+	return r.operator == ":" && r.value == "*" && !r.quoted
+}
+
+func (r *restriction) evalPath(msg protoreflect.Message, path []string) (bool, error) {
+	fd := findField(msg.Descriptor(), path[0])
+	if fd == nil {
+		return false, fmt.Errorf("field %q not found in %v", strings.Join(r.path, "."), msg.Descriptor().FullName())
+	}
+	rest := path[1:]
+
+	switch {
+	case fd.IsMap():
+		m := msg.Get(fd).Map()
+		if len(rest) == 0 {
+			if r.operator != ":" {
+				return false, fmt.Errorf("map field %q only supports the : operator", strings.Join(r.path, "."))
+			}
+			if r.isPresenceCheck() {
+				return m.Len() != 0, nil
+			}
+			if fd.MapKey().Kind() != protoreflect.StringKind {
+				return false, fmt.Errorf("map field %q does not have string keys", strings.Join(r.path, "."))
+			}
+			return m.Has(protoreflect.ValueOfString(r.value).MapKey()), nil
+		}
+		if fd.MapKey().Kind() != protoreflect.StringKind {
+			return false, fmt.Errorf("map field %q does not have string keys", strings.Join(r.path, "."))
+		}
+		v := m.Get(protoreflect.ValueOfString(rest[0]).MapKey())
+		if !v.IsValid() {
+			return false, nil
+		}
+		if r.isPresenceCheck() && len(rest) == 1 {
+			return true, nil
+		}
+		valueField := fd.MapValue()
+		if len(rest) > 1 {
+			if valueField.Kind() != protoreflect.MessageKind {
+				return false, fmt.Errorf("cannot traverse into %q", strings.Join(r.path, "."))
+			}
+			return r.evalPath(v.Message(), rest[1:])
+		}
+		return r.compare(valueField, v)
+
+	case fd.IsList():
+		if r.operator != ":" {
+			return false, fmt.Errorf("repeated field %q only supports the : operator", strings.Join(r.path, "."))
+		}
+		l := msg.Get(fd).List()
+		if r.isPresenceCheck() && len(rest) == 0 {
+			return l.Len() != 0, nil
+		}
+		for i := 0; i < l.Len(); i++ {
+			var ok bool
+			var err error
+			if len(rest) != 0 {
+				if fd.Kind() != protoreflect.MessageKind {
+					return false, fmt.Errorf("cannot traverse into %q", strings.Join(r.path, "."))
+				}
+				ok, err = r.evalPath(l.Get(i).Message(), rest)
+			} else {
+				ok, err = r.compare(fd, l.Get(i))
+			}
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+
+	case fd.Kind() == protoreflect.MessageKind && !isComparableMessage(fd.Message()):
+		if len(rest) == 0 {
+			if r.isPresenceCheck() {
+				return msg.Has(fd), nil
+			}
+			return false, fmt.Errorf("field %q is a message and can only be tested for presence", strings.Join(r.path, "."))
+		}
+		child := msg.Get(fd).Message()
+		if fd.Message().FullName() == "google.protobuf.Any" {
+			// Traverse into the packed message, e.g. protoPayload.methodName in log entries
+			unpacked, ok := unpackAny(child)
+			if !ok {
+				return false, nil
+			}
+			child = unpacked
+		}
+		return r.evalPath(child, rest)
+
+	default:
+		if len(rest) != 0 {
+			return false, fmt.Errorf("cannot traverse into %q", strings.Join(r.path, "."))
+		}
+		if r.isPresenceCheck() {
+			return msg.Has(fd), nil
+		}
+		return r.compare(fd, msg.Get(fd))
+	}
+}
+
+// findField looks up a field by its proto name, or by its json name.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return fields.ByJSONName(name)
+}
+
+// unpackAny returns the message packed in an Any, or false if the Any is empty or of an unknown type.
+func unpackAny(msg protoreflect.Message) (protoreflect.Message, bool) {
+	a, ok := msg.Interface().(*anypb.Any)
+	if !ok || a.GetTypeUrl() == "" {
+		return nil, false
+	}
+	unpacked, err := a.UnmarshalNew()
+	if err != nil {
+		return nil, false
+	}
+	return unpacked.ProtoReflect(), true
+}
+
+// isComparableMessage is true for the well-known message types that we compare as values.
+func isComparableMessage(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return true
+	}
+	return false
+}
+
+// compare compares a single (non-repeated) value against the restriction value.
+func (r *restriction) compare(fd protoreflect.FieldDescriptor, v protoreflect.Value) (bool, error) {
+	fieldPath := strings.Join(r.path, ".")
+
+	operator := r.operator
+	if operator == ":" {
+		// For scalar values, has is equality
+		operator = "="
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		switch operator {
+		case "=":
+			return matchWildcard(r.value, v.String()), nil
+		case "!=":
+			return !matchWildcard(r.value, v.String()), nil
+		}
+		return compareOrdered(strings.Compare(v.String(), r.value), operator), nil
+
+	case protoreflect.BoolKind:
+		want, err := strconv.ParseBool(r.value)
+		if err != nil {
+			return false, fmt.Errorf("field %q is a bool, cannot compare with %q", fieldPath, r.value)
+		}
+		return compareEquality(v.Bool() == want, operator, fieldPath)
+
+	case protoreflect.EnumKind:
+		var want protoreflect.EnumNumber
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(r.value)); ev != nil {
+			want = ev.Number()
+		} else if n, err := strconv.ParseInt(r.value, 10, 32); err == nil {
+			want = protoreflect.EnumNumber(n)
+		} else {
+			return false, fmt.Errorf("%q is not a valid value for enum field %q", r.value, fieldPath)
+		}
+		return compareEquality(v.Enum() == want, operator, fieldPath)
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		want, err := strconv.ParseInt(r.value, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("field %q is an integer, cannot compare with %q", fieldPath, r.value)
+		}
+		return compareOrdered(compareInt(v.Int(), want), operator), nil
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		want, err := strconv.ParseUint(r.value, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("field %q is an unsigned integer, cannot compare with %q", fieldPath, r.value)
+		}
+		return compareOrdered(compareUint(v.Uint(), want), operator), nil
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		want, err := strconv.ParseFloat(r.value, 64)
+		if err != nil {
+			return false, fmt.Errorf("field %q is a number, cannot compare with %q", fieldPath, r.value)
+		}
+		return compareOrdered(compareFloat(v.Float(), want), operator), nil
+
+	case protoreflect.MessageKind:
+		switch fd.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			want, err := time.Parse(time.RFC3339Nano, r.value)
+			if err != nil {
+				return false, fmt.Errorf("field %q is a timestamp, cannot compare with %q", fieldPath, r.value)
+			}
+			got := time.Unix(secondsAndNanos(v.Message()))
+			return compareOrdered(got.Compare(want), operator), nil
+
+		case "google.protobuf.Duration":
+			want, err := time.ParseDuration(r.value)
+			if err != nil {
+				return false, fmt.Errorf("field %q is a duration, cannot compare with %q", fieldPath, r.value)
+			}
+			seconds, nanos := secondsAndNanos(v.Message())
+			got := time.Duration(seconds)*time.Second + time.Duration(nanos)
+			return compareOrdered(compareInt(int64(got), int64(want)), operator), nil
+		}
+	}
+
+	return false, fmt.Errorf("filtering on field %q (of type %v) is not supported", fieldPath, fd.Kind())
+}
+
+func secondsAndNanos(msg protoreflect.Message) (int64, int64) {
+	fields := msg.Descriptor().Fields()
+	return msg.Get(fields.ByName("seconds")).Int(), msg.Get(fields.ByName("nanos")).Int()
+}
+
+func compareEquality(equal bool, operator string, fieldPath string) (bool, error) {
+	switch operator {
+	case "=":
+		return equal, nil
+	case "!=":
+		return !equal, nil
+	}
+	return false, fmt.Errorf("field %q only supports the = and != operators", fieldPath)
+}
+
+// compareOrdered returns the result of the operator, given c as the result of comparing the field value with the restriction value.
+func compareOrdered(c int, operator string) bool {
+	switch operator {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// matchWildcard matches s against pattern, where * in the pattern matches any sequence of characters.
+func matchWildcard(pattern string, s string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == s
+	}
+	return CompileWildcard(pattern).MatchString(s)
+}
+
+// CompileWildcard converts a pattern, where * matches any sequence of characters, to a regex matching the whole string.
+func CompileWildcard(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i, part := range strings.Split(pattern, "*") {
+		if i != 0 {
+			sb.WriteString(".*")
+		}
+		sb.WriteString(regexp.QuoteMeta(part))
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
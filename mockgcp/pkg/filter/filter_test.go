@@ -0,0 +1,169 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	longrunningpb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+)
+
+func TestMatches(t *testing.T) {
+	topic := &pb.Topic{
+		Name:   "projects/p/topics/my-topic",
+		Labels: map[string]string{"env": "prod", "team": "infra"},
+		MessageStoragePolicy: &pb.MessageStoragePolicy{
+			AllowedPersistenceRegions: []string{"us-central1", "us-east1"},
+		},
+		SatisfiesPzs:             true,
+		MessageRetentionDuration: durationpb.New(time.Hour),
+		State:                    pb.Topic_ACTIVE,
+	}
+
+	grid := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "", want: true},
+		{filter: `name = "projects/p/topics/my-topic"`, want: true},
+		{filter: `name = "projects/p/topics/other"`, want: false},
+		{filter: `name != "projects/p/topics/other"`, want: true},
+		{filter: `name = "projects/p/topics/my-*"`, want: true},
+		{filter: `name = "*/other"`, want: false},
+		{filter: `labels.env = prod`, want: true},
+		{filter: `labels.env = "dev"`, want: false},
+		{filter: `labels.missing = "prod"`, want: false},
+		{filter: `labels:env`, want: true},
+		{filter: `labels:missing`, want: false},
+		{filter: `labels.team:*`, want: true},
+		{filter: `labels:*`, want: true},
+		{filter: `state = ACTIVE`, want: true},
+		{filter: `state != ACTIVE`, want: false},
+		{filter: `satisfiesPzs = true`, want: true},
+		{filter: `satisfies_pzs = false`, want: false},
+		{filter: `messageRetentionDuration > 30m`, want: true},
+		{filter: `messageRetentionDuration >= 2h`, want: false},
+		{filter: `messageStoragePolicy.allowedPersistenceRegions:us-east1`, want: true},
+		{filter: `messageStoragePolicy.allowedPersistenceRegions:europe-west1`, want: false},
+		{filter: `messageStoragePolicy:*`, want: true},
+		{filter: `schemaSettings:*`, want: false},
+		{filter: `kmsKeyName:*`, want: false},
+		{filter: `labels.env = prod AND state = ACTIVE`, want: true},
+		{filter: `labels.env = prod state = ACTIVE`, want: true},
+		{filter: `labels.env = dev AND state = ACTIVE`, want: false},
+		{filter: `labels.env = dev OR labels.team = infra`, want: true},
+		{filter: `labels.env = dev OR labels.team = web`, want: false},
+		{filter: `NOT labels.env = dev`, want: true},
+		{filter: `-labels.env = prod`, want: false},
+		{filter: `- labels:env`, want: false},
+		{filter: `NOT (labels.env = prod AND labels.team = web)`, want: true},
+		// OR binds more tightly than AND
+		{filter: `labels.env = dev OR labels.env = prod AND labels.team = infra`, want: true},
+		{filter: `labels.env = prod AND labels.team = web OR labels.team = infra`, want: true},
+	}
+
+	for _, g := range grid {
+		t.Run(g.filter, func(t *testing.T) {
+			f, err := Parse(g.filter)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", g.filter, err)
+			}
+			got, err := f.Matches(topic)
+			if err != nil {
+				t.Fatalf("Matches failed: %v", err)
+			}
+			if got != g.want {
+				t.Errorf("filter %q: got %v, want %v", g.filter, got, g.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{
+		`name =`,
+		`name = "unterminated`,
+		`(name = a`,
+		`name = a)`,
+		`foo`,
+		`AND name = a`,
+		`name ! a`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("expected error parsing %q", expression)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	topic := &pb.Topic{Name: "projects/p/topics/t"}
+
+	for _, expression := range []string{
+		`unknownField = a`,
+		`satisfiesPzs = maybe`,
+		`state = NOT_A_STATE`,
+		`labels = a`,
+		`messageStoragePolicy = a`,
+		`name.foo = a`,
+		`messageRetentionDuration > soon`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			f, err := Parse(expression)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", expression, err)
+			}
+			if _, err := f.Matches(topic); err == nil {
+				t.Errorf("expected error evaluating %q", expression)
+			}
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	metadata, err := anypb.New(&pb.Topic{Name: "projects/p/topics/my-topic"})
+	if err != nil {
+		t.Fatalf("building any: %v", err)
+	}
+	op := &longrunningpb.Operation{Name: "operations/op1", Metadata: metadata}
+
+	grid := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `metadata.name = "projects/p/topics/my-topic"`, want: true},
+		{filter: `metadata.name = "projects/p/topics/other"`, want: false},
+		{filter: `response.name = "projects/p/topics/my-topic"`, want: false},
+	}
+	for _, g := range grid {
+		f, err := Parse(g.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", g.filter, err)
+		}
+		got, err := f.Matches(op)
+		if err != nil {
+			t.Fatalf("Matches(%q) failed: %v", g.filter, err)
+		}
+		if got != g.want {
+			t.Errorf("Matches(%q) = %v, want %v", g.filter, got, g.want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filter implements the filtering language used by List methods, as described in https://google.aip.dev/160
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenComparator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// comparators are ordered so that the longest match is tried first
+var comparators = []string{"!=", "<=", ">=", "=", "<", ">", ":"}
+
+// isWordTerminator returns true if c ends a bare (unquoted) word
+func isWordTerminator(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '(', ')', '"', '\'', '=', '!', '<', '>', ':':
+		return true
+	}
+	return false
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(s) {
+					return nil, fmt.Errorf("unterminated string starting at position %d", start)
+				}
+				if s[i] == '\\' && i+1 < len(s) {
+					sb.WriteByte(s[i+1])
+					i += 2
+					continue
+				}
+				if s[i] == c {
+					i++
+					break
+				}
+				sb.WriteByte(s[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, value: sb.String(), pos: start})
+		default:
+			matched := false
+			for _, comparator := range comparators {
+				if strings.HasPrefix(s[i:], comparator) {
+					tokens = append(tokens, token{kind: tokenComparator, value: comparator, pos: i})
+					i += len(comparator)
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if c == '!' {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			start := i
+			for i < len(s) && !isWordTerminator(s[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[start:i], pos: start})
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(s)})
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && t.value == keyword
+}
+
+// parseExpression parses `sequence {AND sequence}`
+func (p *parser) parseExpression() (node, error) {
+	var terms andNode
+	for {
+		n, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, n)
+		if !p.peekKeyword("AND") {
+			break
+		}
+		p.next()
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+// parseSequence parses `factor {factor}`; adjacent factors are implicitly ANDed together.
+func (p *parser) parseSequence() (node, error) {
+	var terms andNode
+	for {
+		n, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, n)
+		t := p.peek()
+		if t.kind == tokenEOF || t.kind == tokenRightParen || p.peekKeyword("AND") {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+// parseFactor parses `term {OR term}`; note that OR binds more tightly than AND.
+func (p *parser) parseFactor() (node, error) {
+	var terms orNode
+	for {
+		n, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, n)
+		if !p.peekKeyword("OR") {
+			break
+		}
+		p.next()
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+// parseTerm parses `[NOT|-] simple`
+func (p *parser) parseTerm() (node, error) {
+	t := p.peek()
+	if t.kind == tokenWord {
+		if t.value == "NOT" || t.value == "-" {
+			p.next()
+			n, err := p.parseSimple()
+			if err != nil {
+				return nil, err
+			}
+			return &notNode{n}, nil
+		}
+		if strings.HasPrefix(t.value, "-") {
+			// e.g. -labels.env:*
+			p.tokens[p.pos].value = strings.TrimPrefix(t.value, "-")
+			n, err := p.parseSimple()
+			if err != nil {
+				return nil, err
+			}
+			return &notNode{n}, nil
+		}
+	}
+	return p.parseSimple()
+}
+
+// parseSimple parses `restriction | "(" expression ")"`
+func (p *parser) parseSimple() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLeftParen:
+		n, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected ) at position %d", closing.pos)
+		}
+		return n, nil
+
+	case tokenWord:
+		if t.value == "AND" || t.value == "OR" || t.value == "NOT" {
+			return nil, fmt.Errorf("unexpected %v at position %d", t.value, t.pos)
+		}
+		comparator := p.next()
+		if comparator.kind != tokenComparator {
+			return nil, fmt.Errorf("expected comparator after %q at position %d (global restrictions are not supported)", t.value, comparator.pos)
+		}
+		arg := p.next()
+		if arg.kind != tokenWord && arg.kind != tokenString {
+			return nil, fmt.Errorf("expected value after %q at position %d", comparator.value, arg.pos)
+		}
+		return &restriction{
+			path:     strings.Split(t.value, "."),
+			operator: comparator.value,
+			value:    arg.value,
+			quoted:   arg.kind == tokenString,
+		}, nil
+
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of filter")
+
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/fields"
+)
+
+// UnaryServerInterceptor is a grpc interceptor that counts requests against the quota limits of their project,
+// and rejects them with RESOURCE_EXHAUSTED if a limit is exceeded.
+func (m *Manager) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	m.mutex.Lock()
+	enabled := len(m.limits) != 0
+	m.mutex.Unlock()
+	if !enabled {
+		return handler(ctx, req)
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+	project := projectOf(msg)
+	if project == "" {
+		return handler(ctx, req)
+	}
+	projectID, err := m.projectID(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	fullMethod := strings.TrimPrefix(info.FullMethod, "/")
+	host, _, _ := strings.Cut(fullMethod, "/")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-host"); len(values) != 0 {
+			host = values[0]
+			// Strip any port
+			if i := strings.LastIndex(host, ":"); i != -1 {
+				host = host[:i]
+			}
+		}
+	}
+
+	release, err := m.check(ctx, projectID, host, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+	// Once the handler returns, a created resource is stored and counted, so we no longer need the reservation
+	defer release()
+	return handler(ctx, req)
+}
+
+// check counts the request, and returns an error if it exceeds a limit.
+// For allocation limits, it reserves a resource, so that concurrent creates cannot both take the last one;
+// the caller must call release once the request has been handled.
+func (m *Manager) check(ctx context.Context, projectID string, host string, fullMethod string) (release func(), err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var reserved []projectLimit
+	release = func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.releaseLocked(reserved)
+	}
+
+	m.requests[projectService{project: projectID, service: host}]++
+	for _, limit := range m.limits {
+		if !limit.appliesTo(host, fullMethod) {
+			continue
+		}
+		value := m.effectiveValue(projectID, limit.Limit)
+		key := projectLimit{project: projectID, limitKey: limitKey{service: limit.Service, quotaID: limit.QuotaID}}
+		switch limit.Type {
+		case Rate:
+			now := m.now()
+			w := m.windows[key]
+			if w == nil || now.Sub(w.start) >= time.Minute {
+				w = &window{start: now}
+				m.windows[key] = w
+			}
+			if w.count >= value {
+				m.releaseLocked(reserved)
+				return nil, m.exceeded(ctx, projectID, limit.Limit, value)
+			}
+			w.count++
+		case Allocation:
+			count, err := m.countResources(ctx, projectID, limit.Limit)
+			if err != nil {
+				klog.Warningf("mockgcp quota: cannot check %v for %v: %v", limit.QuotaID, fullMethod, err)
+				continue
+			}
+			if count+m.reservations[key] >= value {
+				m.releaseLocked(reserved)
+				return nil, m.exceeded(ctx, projectID, limit.Limit, value)
+			}
+			m.reservations[key]++
+			reserved = append(reserved, key)
+		}
+	}
+	return release, nil
+}
+
+// releaseLocked releases the reservations of a request.  The caller must hold the lock.
+func (m *Manager) releaseLocked(reserved []projectLimit) {
+	for _, key := range reserved {
+		// The reservations are gone if the manager was reset in the meantime
+		if m.reservations[key] > 0 {
+			m.reservations[key]--
+		}
+	}
+}
+
+// appliesTo returns true if the limit applies to a call of the method on the host.
+func (l *limitState) appliesTo(host string, fullMethod string) bool {
+	if len(l.methods) == 0 {
+		return l.Type == Rate && host == l.Service
+	}
+	for _, method := range l.methods {
+		if method.MatchString(fullMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// exceeded builds the error GCP returns when a quota is exceeded, with the quota in the details.
+func (m *Manager) exceeded(ctx context.Context, projectID string, limit Limit, value int64) error {
+	consumer := "projects/" + projectID
+	if number := m.projectNumber(ctx, projectID); number != "" {
+		consumer = "projects/" + number
+	}
+
+	displayName := limit.DisplayName
+	if displayName == "" {
+		displayName = limit.Metric
+	}
+
+	var reason, message string
+	switch limit.Type {
+	case Rate:
+		reason = "RATE_LIMIT_EXCEEDED"
+		message = fmt.Sprintf("Quota exceeded for quota metric '%s' and limit '%s' of service '%s' for consumer '%s'.", displayName, limit.QuotaID, limit.Service, consumer)
+	default:
+		reason = "QUOTA_EXCEEDED"
+		message = fmt.Sprintf("Quota '%s' exceeded.  Limit: %d globally.", limit.QuotaID, value)
+	}
+	klog.Infof("mockgcp quota: rejecting request from %v: %v", consumer, message)
+
+	st := status.New(codes.ResourceExhausted, message)
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: "googleapis.com",
+			Metadata: map[string]string{
+				"service":           limit.Service,
+				"quota_metric":      limit.Metric,
+				"quota_limit":       limit.QuotaID,
+				"quota_limit_value": strconv.FormatInt(value, 10),
+				"quota_location":    "global",
+				"consumer":          consumer,
+			},
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     consumer,
+					Description: message,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// projectOf returns the project (id or number) that a request is made in, or "" if we cannot tell.
+func projectOf(req proto.Message) string {
+	tokens := strings.Split(fields.RequestResourceName(req), "/")
+	if len(tokens) >= 2 && tokens[0] == "projects" {
+		return tokens[1]
+	}
+	// Some APIs (e.g. compute) pass the project separately
+	if project := fields.StringField(req.ProtoReflect(), "project"); project != "" {
+		return strings.TrimPrefix(project, "projects/")
+	}
+	return ""
+}
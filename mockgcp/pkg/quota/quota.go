@@ -0,0 +1,351 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota simulates per-project quotas in mockgcp, so that we can test how clients behave when quota is exhausted.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/hierarchy"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/filter"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// LimitType is the kind of a quota limit.
+type LimitType string
+
+const (
+	// Allocation limits how many resources can exist in a project, e.g. networks per project.
+	Allocation LimitType = "Allocation"
+	// Rate limits how many requests a project can make per minute.
+	Rate LimitType = "Rate"
+)
+
+// Limit describes a per-project quota limit.
+type Limit struct {
+	// Service is the API host, e.g. pubsub.googleapis.com
+	Service string
+	// QuotaID identifies the limit within the service, e.g. TopicsPerProject
+	QuotaID string
+	// Metric is the quota metric, e.g. pubsub.googleapis.com/topics
+	Metric string
+	// DisplayName is a human-readable name for the limit, used in error messages.
+	DisplayName string
+
+	Type LimitType
+
+	// Methods are the grpc methods the limit applies to, as patterns matched against
+	// the full method without the leading slash, e.g. *.pubsub.v1.Publisher/CreateTopic
+	// For allocation limits these are the methods that create the resource.
+	// For rate limits, if empty the limit applies to all requests to Service.
+	Methods []string
+
+	// Kind is the proto message that is counted for allocation limits, e.g. mockgcp.pubsub.v1.Topic
+	Kind string
+
+	// Value is the default limit per project.
+	Value int64
+}
+
+// Unit returns the unit of the limit, as reported by serviceusage and cloudquotas.
+func (l *Limit) Unit() string {
+	if l.Type == Rate {
+		return "1/min/{project}"
+	}
+	return "1/{project}"
+}
+
+// DefaultLimits returns limits for some commonly exhausted quotas.
+// The values are the defaults for a new project, tests will usually want to lower them.
+func DefaultLimits() []Limit {
+	return []Limit{
+		{
+			Service:     "compute.googleapis.com",
+			QuotaID:     "NETWORKS-per-project",
+			Metric:      "compute.googleapis.com/networks",
+			DisplayName: "Networks",
+			Type:        Allocation,
+			Methods:     []string{"*.compute.v1.Networks/Insert"},
+			Kind:        "mockgcp.cloud.compute.v1.Network",
+			Value:       15,
+		},
+		{
+			Service:     "compute.googleapis.com",
+			QuotaID:     "FIREWALLS-per-project",
+			Metric:      "compute.googleapis.com/firewalls",
+			DisplayName: "Firewall rules",
+			Type:        Allocation,
+			Methods:     []string{"*.compute.v1.Firewalls/Insert"},
+			Kind:        "mockgcp.cloud.compute.v1.Firewall",
+			Value:       200,
+		},
+		{
+			Service:     "pubsub.googleapis.com",
+			QuotaID:     "TopicsPerProject",
+			Metric:      "pubsub.googleapis.com/topics",
+			DisplayName: "Topics",
+			Type:        Allocation,
+			Methods:     []string{"*.pubsub.v1.Publisher/CreateTopic"},
+			Kind:        "mockgcp.pubsub.v1.Topic",
+			Value:       10000,
+		},
+	}
+}
+
+// Manager holds the quota limits for a mockgcp instance, and the usage of rate limits.  It is safe for concurrent use.
+//
+// Enforcement is opt-in: until a limit is added, requests are neither counted nor checked.
+type Manager struct {
+	mutex sync.Mutex
+
+	storage storage.Storage
+
+	limits []*limitState
+
+	// overrides holds the per-project limit values, set via cloudquotas or SetOverride.
+	overrides map[projectLimit]int64
+
+	// windows holds the current rate limit window, for each project and limit.
+	windows map[projectLimit]*window
+
+	// requests counts the requests made by each project to each service, while limits are configured.
+	requests map[projectService]int64
+
+	// reservations counts the resources being created by in-flight requests, for each project and allocation limit.
+	reservations map[projectLimit]int64
+
+	// now is the clock, for tests.
+	now func() time.Time
+}
+
+type limitState struct {
+	Limit
+
+	methods []*regexp.Regexp
+}
+
+// limitKey identifies a limit.
+type limitKey struct {
+	service string
+	quotaID string
+}
+
+// projectLimit identifies a limit in a project (by project id).
+type projectLimit struct {
+	project string
+	limitKey
+}
+
+type projectService struct {
+	project string
+	service string
+}
+
+// window is a one-minute window for a rate limit.
+type window struct {
+	start time.Time
+	count int64
+}
+
+// NewManager constructs a Manager with no limits.
+func NewManager(storage storage.Storage) *Manager {
+	m := &Manager{storage: storage, now: time.Now}
+	m.Reset()
+	return m
+}
+
+// SetLimit adds a limit, replacing any existing limit with the same service and quota id.
+func (m *Manager) SetLimit(limit Limit) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state := &limitState{Limit: limit}
+	for _, method := range limit.Methods {
+		state.methods = append(state.methods, filter.CompileWildcard(method))
+	}
+
+	for i, existing := range m.limits {
+		if existing.Service == limit.Service && existing.QuotaID == limit.QuotaID {
+			m.limits[i] = state
+			return
+		}
+	}
+	m.limits = append(m.limits, state)
+}
+
+// SetOverride sets the value of a limit for one project (by id or number), as if increased or decreased via cloudquotas.
+func (m *Manager) SetOverride(ctx context.Context, project string, service string, quotaID string, value int64) error {
+	projectID, err := m.projectID(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.overrides[projectLimit{project: projectID, limitKey: limitKey{service: service, quotaID: quotaID}}] = value
+	return nil
+}
+
+// Reset removes all limits, overrides and usage, disabling enforcement.
+func (m *Manager) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.limits = nil
+	m.overrides = make(map[projectLimit]int64)
+	m.windows = make(map[projectLimit]*window)
+	m.requests = make(map[projectService]int64)
+	m.reservations = make(map[projectLimit]int64)
+}
+
+// Limits returns the limits for a service, or all limits if service is "".
+func (m *Manager) Limits(service string) []Limit {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var limits []Limit
+	for _, limit := range m.limits {
+		if service == "" || limit.Service == service {
+			limits = append(limits, limit.Limit)
+		}
+	}
+	return limits
+}
+
+// GetLimit returns the limit with the quota id in the service, or false if there is no such limit.
+func (m *Manager) GetLimit(service string, quotaID string) (Limit, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, limit := range m.limits {
+		if limit.Service == service && limit.QuotaID == quotaID {
+			return limit.Limit, true
+		}
+	}
+	return Limit{}, false
+}
+
+// EffectiveValue returns the value of the limit for the project (by id or number), taking into account any override.
+func (m *Manager) EffectiveValue(ctx context.Context, project string, limit Limit) (int64, error) {
+	projectID, err := m.projectID(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.effectiveValue(projectID, limit), nil
+}
+
+func (m *Manager) effectiveValue(projectID string, limit Limit) int64 {
+	if value, found := m.overrides[projectLimit{project: projectID, limitKey: limitKey{service: limit.Service, quotaID: limit.QuotaID}}]; found {
+		return value
+	}
+	return limit.Value
+}
+
+// Usage returns the current usage of the limit by the project (by id or number):
+// the number of resources for allocation limits, or the number of requests in the current minute for rate limits.
+func (m *Manager) Usage(ctx context.Context, project string, limit Limit) (int64, error) {
+	projectID, err := m.projectID(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+
+	if limit.Type == Allocation {
+		return m.countResources(ctx, projectID, limit)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	w := m.windows[projectLimit{project: projectID, limitKey: limitKey{service: limit.Service, quotaID: limit.QuotaID}}]
+	if w == nil || m.now().Sub(w.start) >= time.Minute {
+		return 0, nil
+	}
+	return w.count, nil
+}
+
+// RequestCount returns the number of requests the project (by id or number) has made to the service, while limits were configured.
+func (m *Manager) RequestCount(ctx context.Context, project string, service string) (int64, error) {
+	projectID, err := m.projectID(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.requests[projectService{project: projectID, service: service}], nil
+}
+
+// countResources counts the stored resources for an allocation limit in the project.
+func (m *Manager) countResources(ctx context.Context, projectID string, limit Limit) (int64, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(limit.Kind))
+	if err != nil {
+		return 0, fmt.Errorf("finding kind %q for quota %v: %w", limit.Kind, limit.QuotaID, err)
+	}
+
+	// Resources may be stored under the project id or number
+	aliases, err := hierarchy.New(m.storage).Aliases(ctx, "projects/"+projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, alias := range aliases {
+		if err := m.storage.List(ctx, messageType.Descriptor(), storage.ListOptions{Prefix: alias + "/"}, func(obj proto.Message) error {
+			count++
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// projectID normalizes a project id or number to the project id.
+// Unknown projects are returned as-is, so that limits can be configured before the project is created.
+func (m *Manager) projectID(ctx context.Context, project string) (string, error) {
+	project = strings.TrimPrefix(project, "projects/")
+	p, err := hierarchy.New(m.storage).GetProject(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return project, nil
+	}
+	return p.GetProjectId(), nil
+}
+
+// projectNumber returns the project number for a project id, or "" if the project is not known.
+func (m *Manager) projectNumber(ctx context.Context, projectID string) string {
+	p, err := hierarchy.New(m.storage).GetProject(ctx, projectID)
+	if err != nil || p == nil {
+		return ""
+	}
+	return strings.TrimPrefix(p.GetName(), "projects/")
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	crmpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	pb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func newTestManager(t *testing.T) (*Manager, storage.Storage) {
+	t.Helper()
+
+	store := storage.NewInMemoryStorage()
+	project := &crmpb.Project{Name: "projects/123456", ProjectId: "my-project"}
+	if err := store.Create(context.Background(), "projects/my-project", project); err != nil {
+		t.Fatalf("creating project: %v", err)
+	}
+	return NewManager(store), store
+}
+
+// createTopic invokes CreateTopic through the interceptor, storing the topic if it is allowed.
+func createTopic(m *Manager, store storage.Storage, name string) error {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-host", "pubsub.googleapis.com"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/mockgcp.pubsub.v1.Publisher/CreateTopic"}
+	_, err := m.UnaryServerInterceptor(ctx, &pb.Topic{Name: name}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		topic := req.(*pb.Topic)
+		return topic, store.Create(ctx, topic.Name, topic)
+	})
+	return err
+}
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("unexpected error %v, want ResourceExhausted", err)
+	}
+	var info *errdetails.ErrorInfo
+	var quotaFailure *errdetails.QuotaFailure
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.QuotaFailure:
+			quotaFailure = detail
+		}
+	}
+	if info == nil || quotaFailure == nil {
+		t.Fatalf("expected ErrorInfo and QuotaFailure details in %v", st.Details())
+	}
+	if got, want := quotaFailure.GetViolations()[0].GetSubject(), "projects/123456"; got != want {
+		t.Errorf("unexpected QuotaFailure subject %q, want %q", got, want)
+	}
+	return info
+}
+
+func TestAllocationLimit(t *testing.T) {
+	ctx := context.Background()
+	m, store := newTestManager(t)
+
+	// Without limits, nothing is enforced
+	for _, name := range []string{"projects/my-project/topics/t1", "projects/123456/topics/t2"} {
+		if err := createTopic(m, store, name); err != nil {
+			t.Fatalf("creating topic %q: %v", name, err)
+		}
+	}
+
+	for _, limit := range DefaultLimits() {
+		if limit.QuotaID == "TopicsPerProject" {
+			limit.Value = 2
+			m.SetLimit(limit)
+		}
+	}
+
+	// Topics are counted whether stored under the project id or number
+	err := createTopic(m, store, "projects/my-project/topics/t3")
+	info := errorInfo(t, err)
+	if got, want := info.GetReason(), "QUOTA_EXCEEDED"; got != want {
+		t.Errorf("unexpected reason %q, want %q", got, want)
+	}
+	if got, want := info.GetMetadata()["quota_limit"], "TopicsPerProject"; got != want {
+		t.Errorf("unexpected quota_limit %q, want %q", got, want)
+	}
+	if got, want := info.GetMetadata()["quota_limit_value"], "2"; got != want {
+		t.Errorf("unexpected quota_limit_value %q, want %q", got, want)
+	}
+
+	// Raising the limit for the project (by number) allows the request
+	if err := m.SetOverride(ctx, "projects/123456", "pubsub.googleapis.com", "TopicsPerProject", 3); err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+	if err := createTopic(m, store, "projects/my-project/topics/t3"); err != nil {
+		t.Fatalf("creating topic after override: %v", err)
+	}
+
+	limit, _ := m.GetLimit("pubsub.googleapis.com", "TopicsPerProject")
+	usage, err := m.Usage(ctx, "my-project", limit)
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if usage != 3 {
+		t.Errorf("unexpected usage %d, want 3", usage)
+	}
+}
+
+func TestAllocationLimitConcurrent(t *testing.T) {
+	m, store := newTestManager(t)
+	for _, limit := range DefaultLimits() {
+		if limit.QuotaID == "TopicsPerProject" {
+			limit.Value = 3
+			m.SetLimit(limit)
+		}
+	}
+
+	// A failed create releases its reservation
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-host", "pubsub.googleapis.com"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/mockgcp.pubsub.v1.Publisher/CreateTopic"}
+	for i := 0; i < 5; i++ {
+		_, err := m.UnaryServerInterceptor(ctx, &pb.Topic{Name: "projects/my-project/topics/failed"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Errorf(codes.Internal, "failed")
+		})
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("unexpected error %v, want Internal", err)
+		}
+	}
+
+	// Concurrent creates cannot exceed the limit
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- createTopic(m, store, fmt.Sprintf("projects/my-project/topics/t%d", i))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+		} else if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if created != 3 {
+		t.Errorf("created %d topics, want 3", created)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	m, store := newTestManager(t)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.SetLimit(Limit{
+		Service: "pubsub.googleapis.com",
+		QuotaID: "AdminRequestsPerMinutePerProject",
+		Metric:  "pubsub.googleapis.com/admin_requests",
+		Type:    Rate,
+		Value:   2,
+	})
+
+	for _, name := range []string{"projects/my-project/topics/t1", "projects/my-project/topics/t2"} {
+		if err := createTopic(m, store, name); err != nil {
+			t.Fatalf("creating topic %q: %v", name, err)
+		}
+	}
+
+	err := createTopic(m, store, "projects/my-project/topics/t3")
+	info := errorInfo(t, err)
+	if got, want := info.GetReason(), "RATE_LIMIT_EXCEEDED"; got != want {
+		t.Errorf("unexpected reason %q, want %q", got, want)
+	}
+	if got, want := info.GetMetadata()["consumer"], "projects/123456"; got != want {
+		t.Errorf("unexpected consumer %q, want %q", got, want)
+	}
+
+	// The limit resets after a minute
+	now = now.Add(time.Minute)
+	if err := createTopic(m, store, "projects/my-project/topics/t3"); err != nil {
+		t.Fatalf("creating topic after a minute: %v", err)
+	}
+
+	count, err := m.RequestCount(ctx, "projects/123456", "pubsub.googleapis.com")
+	if err != nil {
+		t.Fatalf("RequestCount: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("unexpected request count %d, want 4", count)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+// Options configures the mockgcp server.
+type Options struct {
+	// HTTPAddress is the address the HTTP (REST) server listens on.
+	HTTPAddress string
+
+	// GRPCAddress is the address the grpc server listens on.
+	GRPCAddress string
+}
+
+// Server serves a mock GCP over HTTP and grpc, so that it can be used by other processes.
+type Server struct {
+	mockCloud    mockgcp.Interface
+	httpListener net.Listener
+}
+
+// New builds a Server, and starts listening on the configured addresses.
+// Objects are stored in storage; k8sClient is used by the few services which store data in kubernetes (e.g. secret payloads).
+func New(ctx context.Context, options Options, k8sClient client.Client, storage storage.Storage) (*Server, error) {
+	mockCloud, err := mockgcp.NewMockRoundTripperWithOptions(ctx, k8sClient, storage, mockgcp.Options{
+		GRPCListenAddress: options.GRPCAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building mockgcp: %w", err)
+	}
+
+	httpListener, err := net.Listen("tcp", options.HTTPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %q: %w", options.HTTPAddress, err)
+	}
+
+	return &Server{
+		mockCloud:    mockCloud,
+		httpListener: httpListener,
+	}, nil
+}
+
+// HTTPAddress returns the address the HTTP server is listening on.
+func (s *Server) HTTPAddress() string {
+	return s.httpListener.Addr().String()
+}
+
+// Run serves HTTP and grpc requests, until ctx is closed.
+func (s *Server) Run(ctx context.Context) error {
+	log := klog.FromContext(ctx)
+
+	httpServer := &http.Server{Handler: s}
+
+	errChan := make(chan error, 2)
+	go func() {
+		errChan <- s.mockCloud.Run(ctx)
+	}()
+	go func() {
+		log.Info("serving mock gcp http server", "address", s.HTTPAddress())
+		if err := httpServer.Serve(s.httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+			return
+		}
+		errChan <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errChan:
+		if err != nil {
+			return err
+		}
+	}
+	return httpServer.Shutdown(context.Background())
+}
+
+// ServeHTTP implements http.Handler, routing the request to the mock service for its host.
+//
+// The host is taken from the Host header, unless the first element of the path is a googleapis.com
+// host (e.g. /pubsub.googleapis.com/v1/projects/...), which supports clients that can only override
+// the endpoint URL, and not the Host header.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	log := klog.FromContext(req.Context())
+
+	outbound := req.Clone(req.Context())
+	outbound.RequestURI = ""
+	outbound.URL.Scheme = "https"
+	outbound.URL.Host, outbound.URL.Path = routeRequest(req.Host, req.URL.Path)
+	outbound.Host = outbound.URL.Host
+	if outbound.URL.RawPath != "" {
+		_, outbound.URL.RawPath = routeRequest(req.Host, req.URL.RawPath)
+	}
+
+	response, err := s.mockCloud.RoundTrip(outbound)
+	if err != nil {
+		log.Error(err, "error serving request", "method", req.Method, "url", outbound.URL.String())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer response.Body.Close()
+
+	for k, values := range response.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(response.StatusCode)
+	if _, err := io.Copy(w, response.Body); err != nil {
+		log.Error(err, "error writing response", "method", req.Method, "url", outbound.URL.String())
+	}
+}
+
+// routeRequest returns the GCP host and path for a request made to the server with the given Host header and path.
+func routeRequest(host string, path string) (string, string) {
+	trimmed := strings.TrimPrefix(path, "/")
+	first, rest, _ := strings.Cut(trimmed, "/")
+	if strings.HasSuffix(first, ".googleapis.com") {
+		return first, "/" + rest
+	}
+	// Strip any port from the Host header
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host, path
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+)
+
+func TestRouteRequest(t *testing.T) {
+	grid := []struct {
+		host, path         string
+		wantHost, wantPath string
+	}{
+		{"pubsub.googleapis.com", "/v1/projects/p/topics", "pubsub.googleapis.com", "/v1/projects/p/topics"},
+		{"localhost:8080", "/pubsub.googleapis.com/v1/projects/p/topics", "pubsub.googleapis.com", "/v1/projects/p/topics"},
+		{"pubsub.googleapis.com:8080", "/v1/projects/p/topics", "pubsub.googleapis.com", "/v1/projects/p/topics"},
+	}
+	for _, g := range grid {
+		gotHost, gotPath := routeRequest(g.host, g.path)
+		if gotHost != g.wantHost || gotPath != g.wantPath {
+			t.Errorf("routeRequest(%q, %q) = (%q, %q), want (%q, %q)", g.host, g.path, gotHost, gotPath, g.wantHost, g.wantPath)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, Options{HTTPAddress: "127.0.0.1:0", GRPCAddress: "127.0.0.1:0"}, fake.NewClientBuilder().Build(), storage.NewInMemoryStorage())
+	if err != nil {
+		t.Fatalf("error building server: %v", err)
+	}
+	go func() {
+		if err := s.Run(ctx); err != nil {
+			t.Errorf("error from server: %v", err)
+		}
+	}()
+
+	base := "http://" + s.HTTPAddress() + "/cloudresourcemanager.googleapis.com"
+	response, err := http.Post(base+"/v1/projects", "application/json", strings.NewReader(`{"projectId": "mock-project"}`))
+	if err != nil {
+		t.Fatalf("error creating project: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status creating project: %v", response.Status)
+	}
+
+	response, err = http.Get(base + "/v1/projects/mock-project")
+	if err != nil {
+		t.Fatalf("error getting project: %v", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading response: %v", err)
+	}
+	project := map[string]any{}
+	if err := json.Unmarshal(body, &project); err != nil {
+		t.Fatalf("error parsing response %q: %v", string(body), err)
+	}
+	if got := fmt.Sprintf("%v", project["projectId"]); got != "mock-project" {
+		t.Errorf("unexpected projectId %q in response %q", got, string(body))
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// FileStorage is a file-backed (persistent) implementation of Storage.
+// Objects are stored as protojson, in a directory per proto type, with a file per fqn.
+type FileStorage struct {
+	mutex sync.Mutex
+	dir   string
+}
+
+var _ Storage = &FileStorage{}
+
+// NewFileStorage constructs a FileStorage, storing objects under dir.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating directory %q: %w", dir, err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// DirEnvVar is the environment variable used by test harnesses to select file-backed storage.
+const DirEnvVar = "MOCKGCP_STORAGE_DIR"
+
+// New constructs a file-backed Storage under dir, or an in-memory Storage if dir is empty.
+func New(dir string) (Storage, error) {
+	if dir == "" {
+		return NewInMemoryStorage(), nil
+	}
+	return NewFileStorage(dir)
+}
+
+func (s *FileStorage) typeDir(name protoreflect.FullName) string {
+	return filepath.Join(s.dir, string(name))
+}
+
+// maxFileNameLength keeps file names under the 255 byte limit of most filesystems, with room for the extension.
+const maxFileNameLength = 200
+
+// longNamesDir holds the objects whose escaped fqn is too long to be a file name.
+// They are stored under a hash of the fqn, and the fqn is stored alongside in a .fqn file.
+const longNamesDir = "long-names"
+
+func (s *FileStorage) objectPath(name protoreflect.FullName, fqn string) string {
+	escaped := url.PathEscape(fqn)
+	if len(escaped) <= maxFileNameLength {
+		return filepath.Join(s.typeDir(name), escaped+".json")
+	}
+	hash := sha256.Sum256([]byte(fqn))
+	return filepath.Join(s.typeDir(name), longNamesDir, hex.EncodeToString(hash[:])+".json")
+}
+
+// fqnPath returns the path of the file holding the fqn of an object stored under a hash.
+func fqnPath(p string) (string, bool) {
+	if filepath.Base(filepath.Dir(p)) != longNamesDir {
+		return "", false
+	}
+	return strings.TrimSuffix(p, ".json") + ".fqn", true
+}
+
+// listObjects returns the paths of the stored objects of a type, by fqn.
+func (s *FileStorage) listObjects(name protoreflect.FullName) (map[string]string, error) {
+	paths := make(map[string]string)
+
+	dir := s.typeDir(name)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return paths, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		escaped, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		fqn, err := url.PathUnescape(escaped)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected file %q: %w", entry.Name(), err)
+		}
+		paths[fqn] = filepath.Join(dir, entry.Name())
+	}
+
+	dir = filepath.Join(dir, longNamesDir)
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return paths, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") || entry.IsDir() {
+			continue
+		}
+		p := filepath.Join(dir, entry.Name())
+		fqnFile, _ := fqnPath(p)
+		fqn, err := os.ReadFile(fqnFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading name of %q: %w", entry.Name(), err)
+		}
+		paths[string(fqn)] = p
+	}
+	return paths, nil
+}
+
+// objectTypeName is the name of the type used in error messages.
+func objectTypeName(name protoreflect.FullName) string {
+	s := string(name.Name())
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// Create stores the object, erroring if it already exists
+func (s *FileStorage) Create(ctx context.Context, fqn string, create proto.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	name := create.ProtoReflect().Descriptor().FullName()
+	p := s.objectPath(name, fqn)
+	if _, err := os.Stat(p); err == nil {
+		return status.Errorf(codes.AlreadyExists, "%v %q already exists", objectTypeName(name), fqn)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.Internal, "checking for %v %q: %v", objectTypeName(name), fqn, err)
+	}
+	return s.writeObject(p, fqn, create)
+}
+
+// Update stores a new version of an object, erroring if it does not already exist
+func (s *FileStorage) Update(ctx context.Context, fqn string, update proto.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	name := update.ProtoReflect().Descriptor().FullName()
+	p := s.objectPath(name, fqn)
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "%v %q not found", objectTypeName(name), fqn)
+		}
+		return status.Errorf(codes.Internal, "checking for %v %q: %v", objectTypeName(name), fqn, err)
+	}
+	return s.writeObject(p, fqn, update)
+}
+
+// Get returns an existing object
+func (s *FileStorage) Get(ctx context.Context, fqn string, dest proto.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	name := dest.ProtoReflect().Descriptor().FullName()
+	return s.readObject(s.objectPath(name, fqn), fqn, dest)
+}
+
+// Delete deletes the object, returning a not found error if it does not exist.
+func (s *FileStorage) Delete(ctx context.Context, fqn string, dest proto.Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	name := dest.ProtoReflect().Descriptor().FullName()
+	p := s.objectPath(name, fqn)
+	if err := s.readObject(p, fqn, dest); err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		return status.Errorf(codes.Internal, "deleting %v %q: %v", objectTypeName(name), fqn, err)
+	}
+	if fqnFile, ok := fqnPath(p); ok {
+		if err := os.Remove(fqnFile); err != nil {
+			return status.Errorf(codes.Internal, "deleting %v %q: %v", objectTypeName(name), fqn, err)
+		}
+	}
+	return nil
+}
+
+// List returns all matching objects
+func (s *FileStorage) List(ctx context.Context, kind protoreflect.Descriptor, options ListOptions, callback func(obj proto.Message) error) error {
+	matcher, err := newMatcher(options)
+	if err != nil {
+		return err
+	}
+
+	messageType, err := messageTypeFor(kind)
+	if err != nil {
+		return err
+	}
+
+	// We read the objects under the lock, but call the callback after releasing it,
+	// so that the callback can call back into the storage.
+	objects, err := s.readObjects(kind.FullName(), messageType, options.Prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if ok, err := matcher.matches(obj); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		if err := callback(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readObjects returns the stored objects of a type with the fqn prefix, sorted by fqn.
+func (s *FileStorage) readObjects(name protoreflect.FullName, messageType protoreflect.MessageType, prefix string) ([]proto.Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	paths, err := s.listObjects(name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing %v: %v", objectTypeName(name), err)
+	}
+
+	var keys []string
+	for fqn := range paths {
+		if strings.HasPrefix(fqn, prefix) {
+			keys = append(keys, fqn)
+		}
+	}
+	sort.Strings(keys)
+
+	var objects []proto.Message
+	for _, fqn := range keys {
+		obj := messageType.New().Interface()
+		if err := s.readObject(paths[fqn], fqn, obj); err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// messageTypeFor returns the message type for kind, preferring the registered (generated) type.
+func messageTypeFor(kind protoreflect.Descriptor) (protoreflect.MessageType, error) {
+	if messageType, err := protoregistry.GlobalTypes.FindMessageByName(kind.FullName()); err == nil {
+		return messageType, nil
+	}
+	messageDescriptor, ok := kind.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "%v is not a message type", kind.FullName())
+	}
+	return dynamicpb.NewMessageType(messageDescriptor), nil
+}
+
+func (s *FileStorage) readObject(p string, fqn string, dest proto.Message) error {
+	name := dest.ProtoReflect().Descriptor().FullName()
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "%v %q not found", objectTypeName(name), fqn)
+		}
+		return status.Errorf(codes.Internal, "reading %v %q: %v", objectTypeName(name), fqn, err)
+	}
+	obj := dest.ProtoReflect().New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: typeResolver}).Unmarshal(b, obj); err != nil {
+		return status.Errorf(codes.Internal, "parsing %v %q: %v", objectTypeName(name), fqn, err)
+	}
+	proto.Merge(dest, obj)
+	return nil
+}
+
+// writeObject writes the object stored under fqn to the file p.
+func (s *FileStorage) writeObject(p string, fqn string, obj proto.Message) error {
+	b, err := protojson.MarshalOptions{Multiline: true, Resolver: typeResolver}.Marshal(obj)
+	if err != nil {
+		return status.Errorf(codes.Internal, "serializing object: %v", err)
+	}
+	// The fqn is written first, so that an object is never listed without its fqn
+	if fqnFile, ok := fqnPath(p); ok {
+		if err := writeFile(fqnFile, []byte(fqn)); err != nil {
+			return err
+		}
+	}
+	return writeFile(p, b)
+}
+
+// writeFile writes to a temporary file and renames it into place, so that readers never see a partial object.
+func writeFile(p string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return status.Errorf(codes.Internal, "creating directory: %v", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return status.Errorf(codes.Internal, "creating temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return status.Errorf(codes.Internal, "writing object: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return status.Errorf(codes.Internal, "writing object: %v", err)
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return status.Errorf(codes.Internal, "writing object: %v", err)
+	}
+	return nil
+}
+
+// Snapshot returns all stored objects, of all types.
+func (s *FileStorage) Snapshot(ctx context.Context) (*Snapshot, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	typeDirs, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %q: %w", s.dir, err)
+	}
+
+	snapshot := &Snapshot{}
+	for _, typeDir := range typeDirs {
+		if !typeDir.IsDir() {
+			continue
+		}
+		name := protoreflect.FullName(typeDir.Name())
+		messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("finding message type %q: %w", name, err)
+		}
+		paths, err := s.listObjects(name)
+		if err != nil {
+			return nil, fmt.Errorf("listing %v: %w", name, err)
+		}
+		for fqn, p := range paths {
+			obj := messageType.New().Interface()
+			if err := s.readObject(p, fqn, obj); err != nil {
+				return nil, err
+			}
+			o, err := newSnapshotObject(fqn, obj)
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Objects = append(snapshot.Objects, o)
+		}
+	}
+	snapshot.sort()
+	return snapshot, nil
+}
+
+// Restore replaces all stored objects with the objects in the snapshot.
+func (s *FileStorage) Restore(ctx context.Context, snapshot *Snapshot) error {
+	objects := make([]proto.Message, len(snapshot.Objects))
+	for i := range snapshot.Objects {
+		obj, err := snapshot.Objects[i].Decode()
+		if err != nil {
+			return err
+		}
+		objects[i] = obj
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	typeDirs, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("reading directory %q: %w", s.dir, err)
+	}
+	for _, typeDir := range typeDirs {
+		if !typeDir.IsDir() {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(s.dir, typeDir.Name())); err != nil {
+			return fmt.Errorf("removing %q: %w", typeDir.Name(), err)
+		}
+	}
+
+	for i, obj := range objects {
+		name := obj.ProtoReflect().Descriptor().FullName()
+		if err := s.writeObject(s.objectPath(name, snapshot.Objects[i].Name), snapshot.Objects[i].Name, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	longrunningpb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pubsubpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+)
+
+func TestFileStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	if err := s.Create(ctx, "projects/p/topics/a", wrapperspb.String("a1")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.Create(ctx, "projects/p/topics/b", wrapperspb.String("b1")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.Create(ctx, "projects/q/topics/c", wrapperspb.String("c1")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.Create(ctx, "projects/p/topics/a", wrapperspb.String("a2")); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists creating duplicate, got %v", err)
+	}
+	if err := s.Update(ctx, "projects/p/topics/missing", wrapperspb.String("x")); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound updating missing object, got %v", err)
+	}
+	if err := s.Update(ctx, "projects/p/topics/a", wrapperspb.String("a2")); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// State should survive a restart
+	s, err = NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	got := &wrapperspb.StringValue{}
+	if err := s.Get(ctx, "projects/p/topics/a", got); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.GetValue() != "a2" {
+		t.Errorf("unexpected value %q from Get", got.GetValue())
+	}
+
+	var listed []string
+	if err := s.List(ctx, (&wrapperspb.StringValue{}).ProtoReflect().Descriptor(), ListOptions{Prefix: "projects/p/"}, func(obj proto.Message) error {
+		listed = append(listed, obj.(*wrapperspb.StringValue).GetValue())
+		return nil
+	}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(listed) != 2 || listed[0] != "a2" || listed[1] != "b1" {
+		t.Errorf("unexpected objects from List: %v", listed)
+	}
+
+	deleted := &wrapperspb.StringValue{}
+	if err := s.Delete(ctx, "projects/p/topics/b", deleted); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if deleted.GetValue() != "b1" {
+		t.Errorf("unexpected value %q from Delete", deleted.GetValue())
+	}
+	if err := s.Get(ctx, "projects/p/topics/b", &wrapperspb.StringValue{}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+	if err := s.Delete(ctx, "projects/p/topics/b", &wrapperspb.StringValue{}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound deleting twice, got %v", err)
+	}
+}
+
+func TestFileStorageLongNames(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	// The escaped name is longer than the 255 byte limit on file names
+	long := "projects/p/topics/" + strings.Repeat("x/", 200)
+	if err := s.Create(ctx, long, wrapperspb.String("long")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.Create(ctx, "projects/p/topics/short", wrapperspb.String("short")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got := &wrapperspb.StringValue{}
+	if err := s.Get(ctx, long, got); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.GetValue() != "long" {
+		t.Errorf("unexpected value %q from Get", got.GetValue())
+	}
+
+	// The callback can call back into the storage
+	var listed []string
+	if err := s.List(ctx, (&wrapperspb.StringValue{}).ProtoReflect().Descriptor(), ListOptions{Prefix: "projects/p/"}, func(obj proto.Message) error {
+		value := obj.(*wrapperspb.StringValue).GetValue()
+		listed = append(listed, value)
+		if value == "long" {
+			return s.Update(ctx, long, wrapperspb.String("updated"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(listed) != 2 || listed[0] != "short" || listed[1] != "long" {
+		t.Errorf("unexpected objects from List: %v", listed)
+	}
+
+	snapshot, err := s.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if len(snapshot.Objects) != 2 || snapshot.Objects[1].Name != long || string(snapshot.Objects[1].Object) != `"updated"` {
+		t.Errorf("unexpected objects in snapshot: %v", snapshot.Objects)
+	}
+
+	if err := s.Delete(ctx, long, &wrapperspb.StringValue{}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "google.protobuf.StringValue", longNamesDir))
+	if err != nil {
+		t.Fatalf("reading directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected Delete to remove all the files of the object, got %v", entries)
+	}
+}
+
+func TestFileStorageRewrittenOperationTypes(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	// Operations rename mockgcp. types to google. in their metadata
+	metadata, err := anypb.New(&pubsubpb.Topic{Name: "projects/p/topics/t"})
+	if err != nil {
+		t.Fatalf("building any: %v", err)
+	}
+	metadata.TypeUrl = "type.googleapis.com/google.pubsub.v1.Topic"
+	if err := s.Create(ctx, "operations/op1", &longrunningpb.Operation{Name: "operations/op1", Metadata: metadata}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got := &longrunningpb.Operation{}
+	if err := s.Get(ctx, "operations/op1", got); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.GetMetadata().GetTypeUrl() != metadata.TypeUrl {
+		t.Errorf("unexpected metadata type %q after Get", got.GetMetadata().GetTypeUrl())
+	}
+}
@@ -38,10 +38,20 @@ type Storage interface {
 	// Delete deletes the object, returning a not found error if it does not exist.
 	// The error is "ready to return", e.g. we return codes.NotFound if not found.
 	Delete(ctx context.Context, fqn string, dest proto.Message) error
+
+	// Snapshot returns all stored objects, of all types.
+	Snapshot(ctx context.Context) (*Snapshot, error)
+
+	// Restore replaces all stored objects with the objects in the snapshot.
+	Restore(ctx context.Context, snapshot *Snapshot) error
 }
 
 // ListOptions restricts the objects returned by a List
 type ListOptions struct {
 	// Prefix ensures that only objects whose key matches the prefix are returned
 	Prefix string
+
+	// Filter is an AIP-160 filter expression; only objects matching the filter are returned.
+	// An invalid filter is reported as codes.InvalidArgument.
+	Filter string
 }
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/filter"
+)
+
+// matcher applies the Filter from ListOptions.
+type matcher struct {
+	filter *filter.Filter
+}
+
+func newMatcher(options ListOptions) (*matcher, error) {
+	f, err := filter.Parse(options.Filter)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", options.Filter, err)
+	}
+	return &matcher{filter: f}, nil
+}
+
+func (m *matcher) matches(obj proto.Message) (bool, error) {
+	ok, err := m.filter.Matches(obj)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", m.filter, err)
+	}
+	return ok, nil
+}
+
+// listPageToken is the (decoded) contents of a page token.
+// The query hash ensures that a token is only used with the same query that produced it, as required by AIP-158.
+type listPageToken struct {
+	Offset int    `json:"offset"`
+	Query  string `json:"query"`
+}
+
+func queryHash(kind protoreflect.Descriptor, options ListOptions) string {
+	h := sha256.New()
+	h.Write([]byte(kind.FullName()))
+	h.Write([]byte{0})
+	h.Write([]byte(options.Prefix))
+	h.Write([]byte{0})
+	h.Write([]byte(options.Filter))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (t *listPageToken) encode() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(s string) (*listPageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	t := &listPageToken{}
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+	if t.Offset < 0 {
+		return nil, errors.New("negative offset")
+	}
+	return t, nil
+}
+
+// ListPage returns a single page of matching objects, implementing the pagination described in AIP-158.
+// It returns the next page token, which is empty when there are no more results, and the total number of matching objects.
+// A pageSize of 0 returns all the remaining objects; invalid page sizes and tokens are reported as codes.InvalidArgument.
+func ListPage(ctx context.Context, s Storage, kind protoreflect.Descriptor, options ListOptions, pageSize int32, pageToken string, callback func(obj proto.Message) error) (string, int, error) {
+	if pageSize < 0 {
+		return "", 0, status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	query := queryHash(kind, options)
+	offset := 0
+	if pageToken != "" {
+		token, err := decodePageToken(pageToken)
+		if err != nil || token.Query != query {
+			return "", 0, status.Errorf(codes.InvalidArgument, "invalid page_token %q", pageToken)
+		}
+		offset = token.Offset
+	}
+
+	// We keep going after the page is full, to count all the matching objects
+	total := 0
+	if err := s.List(ctx, kind, options, func(obj proto.Message) error {
+		i := total
+		total++
+		if i < offset {
+			return nil
+		}
+		if pageSize != 0 && i >= offset+int(pageSize) {
+			return nil
+		}
+		return callback(obj)
+	}); err != nil {
+		return "", 0, err
+	}
+
+	if pageSize == 0 || total <= offset+int(pageSize) {
+		return "", total, nil
+	}
+	next := &listPageToken{Offset: offset + int(pageSize), Query: query}
+	return next.encode(), total, nil
+}
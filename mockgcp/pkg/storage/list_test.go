@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestListPage(t *testing.T) {
+	ctx := context.Background()
+
+	fileStorage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	for name, s := range map[string]Storage{
+		"memory": NewInMemoryStorage(),
+		"file":   fileStorage,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				v := fmt.Sprintf("v%d", i)
+				if err := s.Create(ctx, "projects/p/values/"+v, wrapperspb.String(v)); err != nil {
+					t.Fatalf("Create failed: %v", err)
+				}
+			}
+			if err := s.Create(ctx, "projects/other/values/v0", wrapperspb.String("v0")); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			kind := (&wrapperspb.StringValue{}).ProtoReflect().Descriptor()
+			// totals holds the total size returned with the first page of each listing
+			var totals []int
+			listAll := func(options ListOptions, pageSize int32) ([][]string, error) {
+				var pages [][]string
+				pageToken := ""
+				for {
+					var page []string
+					next, total, err := ListPage(ctx, s, kind, options, pageSize, pageToken, func(obj proto.Message) error {
+						page = append(page, obj.(*wrapperspb.StringValue).GetValue())
+						return nil
+					})
+					if err != nil {
+						return nil, err
+					}
+					pages = append(pages, page)
+					if len(pages) == 1 {
+						totals = append(totals, total)
+					}
+					if next == "" {
+						return pages, nil
+					}
+					pageToken = next
+				}
+			}
+
+			prefix := ListOptions{Prefix: "projects/p/"}
+			pages, err := listAll(prefix, 2)
+			if err != nil {
+				t.Fatalf("listing failed: %v", err)
+			}
+			if diff := cmp.Diff([][]string{{"v0", "v1"}, {"v2", "v3"}, {"v4"}}, pages); diff != "" {
+				t.Errorf("unexpected pages (-want +got):\n%v", diff)
+			}
+
+			pages, err = listAll(prefix, 0)
+			if err != nil {
+				t.Fatalf("listing failed: %v", err)
+			}
+			if diff := cmp.Diff([][]string{{"v0", "v1", "v2", "v3", "v4"}}, pages); diff != "" {
+				t.Errorf("unexpected pages (-want +got):\n%v", diff)
+			}
+
+			filtered := ListOptions{Prefix: "projects/p/", Filter: `value = "v1" OR value = "v3" OR value = "v4"`}
+			pages, err = listAll(filtered, 2)
+			if err != nil {
+				t.Fatalf("listing failed: %v", err)
+			}
+			if diff := cmp.Diff([][]string{{"v1", "v3"}, {"v4"}}, pages); diff != "" {
+				t.Errorf("unexpected pages (-want +got):\n%v", diff)
+			}
+			if diff := cmp.Diff([]int{5, 5, 3}, totals); diff != "" {
+				t.Errorf("unexpected total sizes (-want +got):\n%v", diff)
+			}
+
+			// A page token is only valid for the query that produced it
+			next, _, err := ListPage(ctx, s, kind, prefix, 2, "", func(obj proto.Message) error { return nil })
+			if err != nil {
+				t.Fatalf("ListPage failed: %v", err)
+			}
+			noop := func(obj proto.Message) error { return nil }
+			if _, _, err := ListPage(ctx, s, kind, filtered, 2, next, noop); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for page token from another query, got %v", err)
+			}
+			if _, _, err := ListPage(ctx, s, kind, prefix, 2, "not-a-token", noop); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for invalid page token, got %v", err)
+			}
+			if _, _, err := ListPage(ctx, s, kind, prefix, -1, "", noop); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for negative page size, got %v", err)
+			}
+			if _, _, err := ListPage(ctx, s, kind, ListOptions{Filter: "value ="}, 0, "", noop); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for invalid filter, got %v", err)
+			}
+		})
+	}
+}
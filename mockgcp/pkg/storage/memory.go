@@ -54,17 +54,19 @@ func (s *InMemoryStorage) getTypeStorage(name protoreflect.FullName) *typeStorag
 
 	ts := s.byType[name]
 	if ts == nil {
-		objectTypeName := string(name.Name())
-		objectTypeName = strings.ToLower(string(objectTypeName[0])) + objectTypeName[1:]
-		ts = &typeStorage{
-			objectTypeName: objectTypeName,
-			byKey:          make(map[string]protoreflect.ProtoMessage),
-		}
+		ts = newTypeStorage(name)
 		s.byType[name] = ts
 	}
 	return ts
 }
 
+func newTypeStorage(name protoreflect.FullName) *typeStorage {
+	return &typeStorage{
+		objectTypeName: objectTypeName(name),
+		byKey:          make(map[string]protoreflect.ProtoMessage),
+	}
+}
+
 // Create stores the object, erroring if it already exists
 func (s *InMemoryStorage) Create(ctx context.Context, fqn string, create proto.Message) error {
 	return s.getTypeStorage(create.ProtoReflect().Descriptor().FullName()).Create(ctx, fqn, create)
@@ -142,6 +144,11 @@ func (s *InMemoryStorage) List(ctx context.Context, kind protoreflect.Descriptor
 }
 
 func (s *typeStorage) List(ctx context.Context, options ListOptions, callback func(obj proto.Message) error) error {
+	matcher, err := newMatcher(options)
+	if err != nil {
+		return err
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -156,6 +163,11 @@ func (s *typeStorage) List(ctx context.Context, options ListOptions, callback fu
 		if options.Prefix != "" && !strings.HasPrefix(fqn, options.Prefix) {
 			continue
 		}
+		if ok, err := matcher.matches(obj); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
 		// Technically we should clone here
 		if err := callback(obj); err != nil {
 			return err
@@ -163,3 +175,48 @@ func (s *typeStorage) List(ctx context.Context, options ListOptions, callback fu
 	}
 	return nil
 }
+
+// Snapshot returns all stored objects, of all types.
+func (s *InMemoryStorage) Snapshot(ctx context.Context) (*Snapshot, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := &Snapshot{}
+	for _, ts := range s.byType {
+		ts.mutex.Lock()
+		for fqn, obj := range ts.byKey {
+			o, err := newSnapshotObject(fqn, obj)
+			if err != nil {
+				ts.mutex.Unlock()
+				return nil, err
+			}
+			snapshot.Objects = append(snapshot.Objects, o)
+		}
+		ts.mutex.Unlock()
+	}
+	snapshot.sort()
+	return snapshot, nil
+}
+
+// Restore replaces all stored objects with the objects in the snapshot.
+func (s *InMemoryStorage) Restore(ctx context.Context, snapshot *Snapshot) error {
+	byType := make(map[protoreflect.FullName]*typeStorage)
+	for i := range snapshot.Objects {
+		obj, err := snapshot.Objects[i].Decode()
+		if err != nil {
+			return err
+		}
+		name := obj.ProtoReflect().Descriptor().FullName()
+		ts := byType[name]
+		if ts == nil {
+			ts = newTypeStorage(name)
+			byType[name] = ts
+		}
+		ts.byKey[snapshot.Objects[i].Name] = obj
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.byType = byType
+	return nil
+}
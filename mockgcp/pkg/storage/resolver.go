@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// mockgcpResolver resolves the types in Any fields (e.g. operation metadata).
+// Operations rename our mockgcp. protos back to google. (see operations.rewriteTypes),
+// so if a google. type is not registered, we fall back to the equivalent mockgcp. type.
+type mockgcpResolver struct {
+	*protoregistry.Types
+}
+
+var typeResolver = &mockgcpResolver{Types: protoregistry.GlobalTypes}
+
+func (r *mockgcpResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	mt, err := r.Types.FindMessageByName(name)
+	if err == protoregistry.NotFound {
+		if rest, ok := strings.CutPrefix(string(name), "google."); ok {
+			return r.Types.FindMessageByName(protoreflect.FullName("mockgcp." + rest))
+		}
+	}
+	return mt, err
+}
+
+func (r *mockgcpResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	name := url
+	if i := strings.LastIndexByte(url, '/'); i >= 0 {
+		name = url[i+1:]
+	}
+	return r.FindMessageByName(protoreflect.FullName(name))
+}
+
+// UnpackAny decodes an Any (e.g. operation metadata), allowing for the google. => mockgcp. renaming of our protos.
+// It returns nil if the Any is nil, or if the type is unknown or the value is invalid.
+func UnpackAny(a *anypb.Any) proto.Message {
+	if a == nil {
+		return nil
+	}
+	msg, err := anypb.UnmarshalNew(a, proto.UnmarshalOptions{Resolver: typeResolver})
+	if err != nil {
+		return nil
+	}
+	return msg
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Snapshot is the full state of a Storage, including operations and projects.
+// Objects are sorted by type and name, so the serialized form is deterministic.
+type Snapshot struct {
+	Objects []SnapshotObject `json:"objects"`
+}
+
+// SnapshotObject is a single stored object.
+type SnapshotObject struct {
+	// Type is the full name of the proto message type
+	Type string `json:"type"`
+	// Name is the fqn the object is stored under
+	Name string `json:"name"`
+	// Object is the protojson encoding of the object
+	Object json.RawMessage `json:"object"`
+}
+
+// newSnapshotObject encodes obj, stored under fqn, for a snapshot.
+func newSnapshotObject(fqn string, obj proto.Message) (SnapshotObject, error) {
+	b, err := protojson.MarshalOptions{Resolver: typeResolver}.Marshal(obj)
+	if err != nil {
+		return SnapshotObject{}, fmt.Errorf("serializing %q: %w", fqn, err)
+	}
+	// protojson does not guarantee stable whitespace, so we normalize it
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, b); err != nil {
+		return SnapshotObject{}, fmt.Errorf("serializing %q: %w", fqn, err)
+	}
+	return SnapshotObject{
+		Type:   string(obj.ProtoReflect().Descriptor().FullName()),
+		Name:   fqn,
+		Object: compact.Bytes(),
+	}, nil
+}
+
+// Decode returns the stored object.  The message type must be registered.
+func (o *SnapshotObject) Decode() (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(o.Type))
+	if err != nil {
+		return nil, fmt.Errorf("finding message type %q: %w", o.Type, err)
+	}
+	obj := messageType.New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: typeResolver}).Unmarshal(o.Object, obj); err != nil {
+		return nil, fmt.Errorf("parsing %v %q: %w", o.Type, o.Name, err)
+	}
+	return obj, nil
+}
+
+func (s *Snapshot) sort() {
+	sort.Slice(s.Objects, func(i, j int) bool {
+		if s.Objects[i].Type != s.Objects[j].Type {
+			return s.Objects[i].Type < s.Objects[j].Type
+		}
+		return s.Objects[i].Name < s.Objects[j].Name
+	})
+}
+
+// WriteTo writes the snapshot as indented json.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	s.sort()
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("serializing snapshot: %w", err)
+	}
+	b = append(b, '\n')
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadSnapshot reads a snapshot written by WriteTo.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("parsing snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SnapshotEnvVar is the environment variable used by test harnesses to restore a snapshot before the test.
+const SnapshotEnvVar = "MOCKGCP_SNAPSHOT"
+
+// SaveSnapshotFile writes a snapshot of s to the file p.
+func SaveSnapshotFile(ctx context.Context, s Storage, p string) error {
+	snapshot, err := s.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("creating directory for %q: %w", p, err)
+	}
+	var b bytes.Buffer
+	if _, err := snapshot.WriteTo(&b); err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing snapshot to %q: %w", p, err)
+	}
+	return nil
+}
+
+// RestoreSnapshotFile replaces the contents of s with the snapshot in the file p.
+func RestoreSnapshotFile(ctx context.Context, s Storage, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return fmt.Errorf("opening snapshot %q: %w", p, err)
+	}
+	defer f.Close()
+	snapshot, err := ReadSnapshot(f)
+	if err != nil {
+		return fmt.Errorf("reading snapshot %q: %w", p, err)
+	}
+	return s.Restore(ctx, snapshot)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	longrunningpb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pubsubpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+
+	fileStorage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage failed: %v", err)
+	}
+
+	for name, s := range map[string]Storage{
+		"memory": NewInMemoryStorage(),
+		"file":   fileStorage,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Create(ctx, "projects/p/topics/b", wrapperspb.String("b")); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if err := s.Create(ctx, "projects/p/topics/a", wrapperspb.String("a")); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if err := s.Create(ctx, "operations/op1", durationpb.New(0)); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+
+			snapshot, err := s.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Snapshot failed: %v", err)
+			}
+			var out bytes.Buffer
+			if _, err := snapshot.WriteTo(&out); err != nil {
+				t.Fatalf("WriteTo failed: %v", err)
+			}
+			want := `{
+  "objects": [
+    {
+      "type": "google.protobuf.Duration",
+      "name": "operations/op1",
+      "object": "0s"
+    },
+    {
+      "type": "google.protobuf.StringValue",
+      "name": "projects/p/topics/a",
+      "object": "a"
+    },
+    {
+      "type": "google.protobuf.StringValue",
+      "name": "projects/p/topics/b",
+      "object": "b"
+    }
+  ]
+}
+`
+			if diff := cmp.Diff(want, out.String()); diff != "" {
+				t.Errorf("unexpected snapshot (-want +got):\n%v", diff)
+			}
+
+			// Changes after the snapshot should be discarded by Restore
+			if err := s.Create(ctx, "projects/p/topics/c", wrapperspb.String("c")); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if err := s.Delete(ctx, "projects/p/topics/a", &wrapperspb.StringValue{}); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+
+			restored, err := ReadSnapshot(&out)
+			if err != nil {
+				t.Fatalf("ReadSnapshot failed: %v", err)
+			}
+			if err := s.Restore(ctx, restored); err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+
+			got := &wrapperspb.StringValue{}
+			if err := s.Get(ctx, "projects/p/topics/a", got); err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if got.GetValue() != "a" {
+				t.Errorf("unexpected value %q after restore", got.GetValue())
+			}
+			if err := s.Get(ctx, "projects/p/topics/c", &wrapperspb.StringValue{}); status.Code(err) != codes.NotFound {
+				t.Errorf("expected NotFound for object created after snapshot, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSnapshotRewrittenOperationTypes(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStorage()
+
+	// Operations rename mockgcp. types to google. in their metadata
+	metadata, err := anypb.New(&pubsubpb.Topic{Name: "projects/p/topics/t"})
+	if err != nil {
+		t.Fatalf("building any: %v", err)
+	}
+	metadata.TypeUrl = "type.googleapis.com/google.pubsub.v1.Topic"
+	if err := s.Create(ctx, "operations/op1", &longrunningpb.Operation{Name: "operations/op1", Metadata: metadata}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	snapshot, err := s.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	obj, err := snapshot.Objects[0].Decode()
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got := obj.(*longrunningpb.Operation).GetMetadata().GetTypeUrl(); got != metadata.TypeUrl {
+		t.Errorf("unexpected metadata type %q after decode", got)
+	}
+}
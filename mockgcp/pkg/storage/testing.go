@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// NewForTest constructs the Storage for a test harness, configured from the environment.
+// Objects are stored under MOCKGCP_STORAGE_DIR if set, and the snapshot in MOCKGCP_SNAPSHOT is restored if set.
+// If ARTIFACTS is set, a snapshot of the mock state is saved there when the test fails, for debugging.
+func NewForTest(ctx context.Context, t testing.TB) Storage {
+	s, err := New(os.Getenv(DirEnvVar))
+	if err != nil {
+		t.Fatalf("building mockgcp storage: %v", err)
+	}
+	if snapshotPath := os.Getenv(SnapshotEnvVar); snapshotPath != "" {
+		if err := RestoreSnapshotFile(ctx, s, snapshotPath); err != nil {
+			t.Fatalf("restoring mockgcp snapshot: %v", err)
+		}
+	}
+	if artifacts := os.Getenv("ARTIFACTS"); artifacts != "" {
+		t.Cleanup(func() {
+			if !t.Failed() {
+				return
+			}
+			p := filepath.Join(artifacts, "mockgcp-snapshots", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+			if err := SaveSnapshotFile(context.Background(), s, p); err != nil {
+				t.Logf("error saving mockgcp snapshot: %v", err)
+			}
+		})
+	}
+	return s
+}
@@ -41,7 +41,8 @@ type ControllerResourceSpec struct {
 	// +optional
 	Containers []ContainerResourceSpec `json:"containers,omitempty"`
 	// The number of desired replicas of the config connector controller.
-	// This field takes effect only if the controller name is "cnrm-webhook-manager".
+	// This field takes effect only if the controller name is "cnrm-webhook-manager" or "cnrm-controller-manager".
+	// The replicas of "cnrm-controller-manager" split the resources of the cluster between them.
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
 }
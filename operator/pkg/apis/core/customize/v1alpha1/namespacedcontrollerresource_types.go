@@ -39,6 +39,10 @@ type NamespacedControllerResourceSpec struct {
 	// The list of containers whose resource requirements to be customized.
 	// Required
 	Containers []ContainerResourceSpec `json:"containers"`
+	// The number of desired replicas of the config connector controller.
+	// The replicas of "cnrm-controller-manager" split the resources of the namespace between them.
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
 }
 
 // NamespacedControllerResourceStatus defines the observed state of NamespacedControllerResource.
@@ -455,6 +455,11 @@ func (in *NamespacedControllerResourceSpec) DeepCopyInto(out *NamespacedControll
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedControllerResourceSpec.
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ActuationSchedule defines freezes, periods in which actuation is paused as if the actuation mode were 'Paused'.
+type ActuationSchedule struct {
+	// The IANA time zone of the recurring freezes, e.g. 'America/New_York'. The default is 'UTC'.
+	//+kubebuilder:validation:MaxLength=64
+	//+kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`
+	//+kubebuilder:validation:XValidation:rule="timestamp('2000-01-01T00:00:00Z').getHours(self) >= 0",message="timeZone must be an IANA time zone, e.g. 'America/New_York'"
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// One-off freezes, e.g. holidays or release freezes.
+	// +optional
+	Freezes []ActuationFreeze `json:"freezes,omitempty"`
+
+	// Freezes that recur every week, e.g. weekends.
+	// +optional
+	RecurringFreezes []RecurringActuationFreeze `json:"recurringFreezes,omitempty"`
+}
+
+// ActuationFreeze is a one-off period in which actuation is paused.
+type ActuationFreeze struct {
+	// The start of the freeze.
+	Start metav1.Time `json:"start"`
+
+	// The end of the freeze.
+	End metav1.Time `json:"end"`
+
+	// Why actuation is paused, e.g. 'End of year freeze'.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// RecurringActuationFreeze is a period in which actuation is paused, that recurs every week.
+type RecurringActuationFreeze struct {
+	// The days of the week on which the freeze starts. The default is every day.
+	// +optional
+	Days []DayOfWeek `json:"days,omitempty"`
+
+	// The time of day at which the freeze starts, as 'HH:MM' in the time zone of the schedule.
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// How long the freeze lasts, e.g. '62h' for a freeze from Friday evening to Monday morning. At most a week.
+	//+kubebuilder:validation:XValidation:rule="duration(self) > duration('0s') && duration(self) <= duration('168h')",message="duration must be positive and at most a week"
+	Duration metav1.Duration `json:"duration"`
+
+	// Why actuation is paused, e.g. 'Weekend'.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// DayOfWeek is a day of the week, e.g. 'Monday'.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type DayOfWeek string
+
+// ActuationStatus is the actuation mode in effect, taking the actuation schedule into account.
+type ActuationStatus struct {
+	// The actuation mode in effect.
+	Mode ActuationMode `json:"mode"`
+
+	// Why the mode is in effect, e.g. the reason of the current freeze.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// When the actuation schedule next changes the mode in effect, if it does.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+}
@@ -51,6 +51,11 @@ type ConfigConnectorSpec struct {
 	//+kubebuilder:validation:Optional
 	Actuation ActuationMode `json:"actuationMode,omitempty"`
 
+	// ActuationSchedule pauses actuation during freezes, regardless of the actuation mode.
+	// The freezes apply to all namespaces, in addition to those of the ConfigConnectorContext objects in 'namespaced' mode.
+	// +optional
+	ActuationSchedule *ActuationSchedule `json:"actuationSchedule,omitempty"`
+
 	// StateIntoSpec is the user override of the default value for the
 	// 'cnrm.cloud.google.com/state-into-spec' annotation if the annotation is
 	// unset for a resource.
@@ -69,6 +74,15 @@ type ConfigConnectorSpec struct {
 
 	// ConfigConnector specific experiments
 	Experiments *CCExperiments `json:"experiments,omitempty"`
+
+	// RolloutPolicy rolls out a new version of Config Connector progressively across namespaces.
+	// This field is used only when in namespaced mode.
+	// Namespaces start at `baselineVersion`, and are moved to `targetVersion` in stages: first the canary namespaces,
+	// then each batch in order, then all remaining namespaces. Each stage must stay healthy for the soak duration
+	// before the next stage starts; if it does not, all namespaces are rolled back to `baselineVersion`.
+	// Namespaces whose ConfigConnectorContext sets `version` are not part of the rollout.
+	// +optional
+	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy,omitempty"`
 }
 
 type CCExperiments struct {
@@ -97,9 +111,117 @@ type MultiClusterLeaseSpec struct {
 	GlobalLockName string `json:"globalLockName"`
 }
 
+// RolloutPolicy defines a progressive rollout of a Config Connector version across namespaces.
+type RolloutPolicy struct {
+	// The version that namespaces run before the rollout reaches them, and after a rollback, e.g. '1.130.2'.
+	BaselineVersion string `json:"baselineVersion"`
+
+	// The version to roll out, e.g. '1.139.0'. The default is the latest version in the stable channel.
+	// +optional
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// The namespaces that are upgraded first.
+	// +optional
+	CanaryNamespaces []string `json:"canaryNamespaces,omitempty"`
+
+	// The batches of namespaces that are upgraded after the canary namespaces, in order.
+	// Namespaces that are not in any batch are upgraded after the last batch.
+	// +optional
+	Batches []RolloutBatch `json:"batches,omitempty"`
+
+	// HealthGate decides whether an upgraded stage is healthy enough for the rollout to continue.
+	// +optional
+	HealthGate *RolloutHealthGate `json:"healthGate,omitempty"`
+}
+
+// RolloutBatch selects a batch of namespaces to upgrade together.
+type RolloutBatch struct {
+	// Selects the namespaces in the batch by their labels. If not set, the batch is chosen from all namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// The percentage of the selected namespaces that will have been upgraded once the batch is done, including namespaces upgraded by earlier stages.
+	// Namespaces are chosen in order of their names. The default is 100.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// RolloutHealthGate defines when an upgraded stage is considered healthy.
+type RolloutHealthGate struct {
+	// The minimum percentage of resources in the upgraded namespaces that must be up to date, of those that are up to date or failed to update.
+	// If fewer resources were up to date before the rollout started, that lower percentage is used instead. The default is 90.
+	// The resources are counted once the soak of the stage ends, and the controller manager pods of the upgraded
+	// namespaces must be ready with the target version.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	// +optional
+	MinUpToDatePercent *int32 `json:"minUpToDatePercent,omitempty"`
+
+	// How long to wait after upgrading a stage before checking its health. The default is 10m.
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+}
+
+// RolloutPhase is the phase of a rollout.
+type RolloutPhase string
+
+const (
+	// RolloutProgressing means stages are still being upgraded.
+	RolloutProgressing RolloutPhase = "Progressing"
+	// RolloutCompleted means all namespaces run the target version.
+	RolloutCompleted RolloutPhase = "Completed"
+	// RolloutRolledBack means a stage was unhealthy, and all namespaces run the baseline version.
+	RolloutRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutStatus reports the progress of a rollout.
+type RolloutStatus struct {
+	// The version that namespaces run before the rollout reaches them.
+	BaselineVersion string `json:"baselineVersion,omitempty"`
+
+	// The version being rolled out.
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// The phase of the rollout: 'Progressing', 'Completed' or 'RolledBack'.
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// The stage being upgraded: 0 is the canary namespaces, 1 is the first batch, and so on.
+	Stage int32 `json:"stage"`
+
+	// When the current stage was upgraded.
+	// +optional
+	StageStartTime *metav1.Time `json:"stageStartTime,omitempty"`
+
+	// The percentage of resources that were up to date when the rollout started.
+	// +optional
+	BaselineUpToDatePercent *int32 `json:"baselineUpToDatePercent,omitempty"`
+
+	// The namespaces that have been upgraded to the target version.
+	// +optional
+	UpdatedNamespaces []string `json:"updatedNamespaces,omitempty"`
+
+	// A human-readable description of the state of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // ConfigConnectorStatus defines the observed state of ConfigConnector
 type ConfigConnectorStatus struct {
 	addonv1alpha1.CommonStatus `json:",inline"`
+
+	// The progress of the rollout defined by `spec.rolloutPolicy`.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// The actuation mode in effect, taking `spec.actuationSchedule` into account.
+	// +optional
+	Actuation *ActuationStatus `json:"actuation,omitempty"`
+
+	// A summary of the health of the Config Connector resources in the cluster.
+	// +optional
+	ResourceHealth *ResourceHealth `json:"resourceHealth,omitempty"`
 }
 
 // +kubebuilder:object:root=true
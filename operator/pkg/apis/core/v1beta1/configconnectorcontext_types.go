@@ -71,6 +71,19 @@ type ConfigConnectorContextSpec struct {
 	//+kubebuilder:validation:Optional
 	Actuation ActuationMode `json:"actuationMode,omitempty"`
 
+	// ActuationSchedule pauses actuation in the namespace during freezes, regardless of the actuation mode.
+	// The freezes of the ConfigConnector object also apply. This field is used only when in namespaced mode.
+	// +optional
+	ActuationSchedule *ActuationSchedule `json:"actuationSchedule,omitempty"`
+
+	// PermissionCheck verifies that the Google Service Account has the given permissions before Config Connector
+	// is enabled in the namespace, and reports the missing permissions in status.permissionCheck.
+	// The operator impersonates the Google Service Account to check its permissions, so the operator's own
+	// identity needs the 'iam.serviceAccounts.getAccessToken' permission on it
+	// (e.g. 'roles/iam.serviceAccountTokenCreator').
+	// +optional
+	PermissionCheck *PermissionCheckSpec `json:"permissionCheck,omitempty"`
+
 	// ManagerNamespace instructs Config Connector to deploy
 	// controller managers and related resources in the namespace
 	// specified as 'ManagerNamespace' instead of standard 'cnrm-system'
@@ -106,6 +119,18 @@ const (
 // ConfigConnectorContextStatus defines the observed state of ConfigConnectorContext
 type ConfigConnectorContextStatus struct {
 	addonv1alpha1.CommonStatus `json:",inline"`
+
+	// The actuation mode in effect in the namespace, taking the actuation schedules into account.
+	// +optional
+	Actuation *ActuationStatus `json:"actuation,omitempty"`
+
+	// A summary of the health of the Config Connector resources in the namespace.
+	// +optional
+	ResourceHealth *ResourceHealth `json:"resourceHealth,omitempty"`
+
+	// The result of the most recent check of spec.permissionCheck.
+	// +optional
+	PermissionCheck *PermissionCheckStatus `json:"permissionCheck,omitempty"`
 }
 
 // +kubebuilder:object:root=true
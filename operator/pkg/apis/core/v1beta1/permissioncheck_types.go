@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PermissionCheckSpec defines the permissions that the Google Service Account must have before Config Connector
+// is enabled in the namespace.
+type PermissionCheckSpec struct {
+	// The project, folder or organization on which the permissions are checked, e.g. 'projects/my-project' or
+	// 'folders/123'. The default is the project, folder or organization of the namespace's
+	// 'cnrm.cloud.google.com/project-id', 'cnrm.cloud.google.com/folder-id' or
+	// 'cnrm.cloud.google.com/organization-id' annotation, or else the project named after the namespace.
+	// +kubebuilder:validation:Pattern=`^(projects|folders|organizations)/[^/]+$`
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// The permissions that the Google Service Account must have, e.g. 'storage.buckets.create'.
+	// +kubebuilder:validation:MinItems=1
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionCheckStatus is the result of the most recent permission check.
+type PermissionCheckStatus struct {
+	// When the permissions were last checked.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// The generation of the ConfigConnectorContext that was checked.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The Google Service Account whose permissions were checked.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// The project, folder or organization on which the permissions were checked.
+	Resource string `json:"resource"`
+
+	// The permissions that the Google Service Account is missing. Config Connector is not enabled in the
+	// namespace until it has them.
+	// +optional
+	MissingPermissions []string `json:"missingPermissions,omitempty"`
+
+	// Why the permissions could not be checked, e.g. because the operator could not impersonate the Google
+	// Service Account.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceHealth summarizes the health of the Config Connector resources
+// managed through a ConfigConnector or ConfigConnectorContext object.
+type ResourceHealth struct {
+	// The time the summary was last refreshed.
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+	// The number of resources of each kind, by the reason of their Ready condition.
+	// +optional
+	Kinds []KindHealth `json:"kinds,omitempty"`
+	// The most frequent error messages of resources that are not ready, most frequent first.
+	// +optional
+	TopErrors []ResourceErrorSummary `json:"topErrors,omitempty"`
+	// The resource that has been waiting the longest to be reconciled.
+	// +optional
+	OldestUnreconciled *UnreconciledResource `json:"oldestUnreconciled,omitempty"`
+}
+
+// KindHealth counts the resources of a kind by the reason of their Ready condition.
+type KindHealth struct {
+	// The kind of the resources, e.g. "PubSubTopic".
+	Kind string `json:"kind"`
+	// The number of resources of the kind.
+	Total int32 `json:"total"`
+	// The number of resources that are up to date with their spec.
+	// +optional
+	UpToDate int32 `json:"upToDate,omitempty"`
+	// The number of resources that failed to be created or updated.
+	// +optional
+	UpdateFailed int32 `json:"updateFailed,omitempty"`
+	// The number of resources waiting for a dependency to be ready.
+	// +optional
+	DependencyNotReady int32 `json:"dependencyNotReady,omitempty"`
+	// The number of resources that failed to be deleted.
+	// +optional
+	DeleteFailed int32 `json:"deleteFailed,omitempty"`
+}
+
+// ResourceErrorSummary is an error message shared by resources that are not ready.
+type ResourceErrorSummary struct {
+	// The reason of the resources' Ready condition.
+	Reason string `json:"reason"`
+	// The message of the resources' Ready condition.
+	Message string `json:"message"`
+	// The number of resources with this error.
+	Count int32 `json:"count"`
+	// One of the resources with this error, as "<namespace>/<kind>/<name>".
+	Example string `json:"example"`
+}
+
+// UnreconciledResource is a resource whose latest spec has not been reconciled.
+type UnreconciledResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// The time of the last transition of the resource's Ready condition or, if it has none, the creation of the resource.
+	Since metav1.Time `json:"since"`
+}
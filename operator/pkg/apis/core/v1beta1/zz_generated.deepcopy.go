@@ -6,9 +6,75 @@ package v1beta1
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActuationFreeze) DeepCopyInto(out *ActuationFreeze) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActuationFreeze.
+func (in *ActuationFreeze) DeepCopy() *ActuationFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ActuationFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActuationSchedule) DeepCopyInto(out *ActuationSchedule) {
+	*out = *in
+	if in.Freezes != nil {
+		in, out := &in.Freezes, &out.Freezes
+		*out = make([]ActuationFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecurringFreezes != nil {
+		in, out := &in.RecurringFreezes, &out.RecurringFreezes
+		*out = make([]RecurringActuationFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActuationSchedule.
+func (in *ActuationSchedule) DeepCopy() *ActuationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ActuationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActuationStatus) DeepCopyInto(out *ActuationStatus) {
+	*out = *in
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActuationStatus.
+func (in *ActuationStatus) DeepCopy() *ActuationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActuationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CCExperiments) DeepCopyInto(out *CCExperiments) {
 	*out = *in
@@ -123,6 +189,16 @@ func (in *ConfigConnectorContextSpec) DeepCopyInto(out *ConfigConnectorContextSp
 		*out = new(StateIntoSpecValue)
 		**out = **in
 	}
+	if in.ActuationSchedule != nil {
+		in, out := &in.ActuationSchedule, &out.ActuationSchedule
+		*out = new(ActuationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PermissionCheck != nil {
+		in, out := &in.PermissionCheck, &out.PermissionCheck
+		*out = new(PermissionCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Experiments != nil {
 		in, out := &in.Experiments, &out.Experiments
 		*out = new(Experiments)
@@ -144,6 +220,21 @@ func (in *ConfigConnectorContextSpec) DeepCopy() *ConfigConnectorContextSpec {
 func (in *ConfigConnectorContextStatus) DeepCopyInto(out *ConfigConnectorContextStatus) {
 	*out = *in
 	in.CommonStatus.DeepCopyInto(&out.CommonStatus)
+	if in.Actuation != nil {
+		in, out := &in.Actuation, &out.Actuation
+		*out = new(ActuationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceHealth != nil {
+		in, out := &in.ResourceHealth, &out.ResourceHealth
+		*out = new(ResourceHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PermissionCheck != nil {
+		in, out := &in.PermissionCheck, &out.PermissionCheck
+		*out = new(PermissionCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorContextStatus.
@@ -192,6 +283,11 @@ func (in *ConfigConnectorList) DeepCopyObject() runtime.Object {
 func (in *ConfigConnectorSpec) DeepCopyInto(out *ConfigConnectorSpec) {
 	*out = *in
 	out.CommonSpec = in.CommonSpec
+	if in.ActuationSchedule != nil {
+		in, out := &in.ActuationSchedule, &out.ActuationSchedule
+		*out = new(ActuationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StateIntoSpec != nil {
 		in, out := &in.StateIntoSpec, &out.StateIntoSpec
 		*out = new(StateIntoSpecValue)
@@ -202,6 +298,11 @@ func (in *ConfigConnectorSpec) DeepCopyInto(out *ConfigConnectorSpec) {
 		*out = new(CCExperiments)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RolloutPolicy != nil {
+		in, out := &in.RolloutPolicy, &out.RolloutPolicy
+		*out = new(RolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorSpec.
@@ -218,6 +319,21 @@ func (in *ConfigConnectorSpec) DeepCopy() *ConfigConnectorSpec {
 func (in *ConfigConnectorStatus) DeepCopyInto(out *ConfigConnectorStatus) {
 	*out = *in
 	in.CommonStatus.DeepCopyInto(&out.CommonStatus)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actuation != nil {
+		in, out := &in.Actuation, &out.Actuation
+		*out = new(ActuationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceHealth != nil {
+		in, out := &in.ResourceHealth, &out.ResourceHealth
+		*out = new(ResourceHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConnectorStatus.
@@ -252,6 +368,21 @@ func (in *Experiments) DeepCopy() *Experiments {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KindHealth) DeepCopyInto(out *KindHealth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KindHealth.
+func (in *KindHealth) DeepCopy() *KindHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(KindHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderElectionSpec) DeepCopyInto(out *LeaderElectionSpec) {
 	*out = *in
@@ -286,3 +417,241 @@ func (in *MultiClusterLeaseSpec) DeepCopy() *MultiClusterLeaseSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionCheckSpec) DeepCopyInto(out *PermissionCheckSpec) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionCheckSpec.
+func (in *PermissionCheckSpec) DeepCopy() *PermissionCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionCheckStatus) DeepCopyInto(out *PermissionCheckStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.MissingPermissions != nil {
+		in, out := &in.MissingPermissions, &out.MissingPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionCheckStatus.
+func (in *PermissionCheckStatus) DeepCopy() *PermissionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringActuationFreeze) DeepCopyInto(out *RecurringActuationFreeze) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]DayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringActuationFreeze.
+func (in *RecurringActuationFreeze) DeepCopy() *RecurringActuationFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringActuationFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceErrorSummary) DeepCopyInto(out *ResourceErrorSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceErrorSummary.
+func (in *ResourceErrorSummary) DeepCopy() *ResourceErrorSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceErrorSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceHealth) DeepCopyInto(out *ResourceHealth) {
+	*out = *in
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]KindHealth, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopErrors != nil {
+		in, out := &in.TopErrors, &out.TopErrors
+		*out = make([]ResourceErrorSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.OldestUnreconciled != nil {
+		in, out := &in.OldestUnreconciled, &out.OldestUnreconciled
+		*out = new(UnreconciledResource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceHealth.
+func (in *ResourceHealth) DeepCopy() *ResourceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutBatch) DeepCopyInto(out *RolloutBatch) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutBatch.
+func (in *RolloutBatch) DeepCopy() *RolloutBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutHealthGate) DeepCopyInto(out *RolloutHealthGate) {
+	*out = *in
+	if in.MinUpToDatePercent != nil {
+		in, out := &in.MinUpToDatePercent, &out.MinUpToDatePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutHealthGate.
+func (in *RolloutHealthGate) DeepCopy() *RolloutHealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutHealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	if in.CanaryNamespaces != nil {
+		in, out := &in.CanaryNamespaces, &out.CanaryNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Batches != nil {
+		in, out := &in.Batches, &out.Batches
+		*out = make([]RolloutBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(RolloutHealthGate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.StageStartTime != nil {
+		in, out := &in.StageStartTime, &out.StageStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BaselineUpToDatePercent != nil {
+		in, out := &in.BaselineUpToDatePercent, &out.BaselineUpToDatePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdatedNamespaces != nil {
+		in, out := &in.UpdatedNamespaces, &out.UpdatedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreconciledResource) DeepCopyInto(out *UnreconciledResource) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreconciledResource.
+func (in *UnreconciledResource) DeepCopy() *UnreconciledResource {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreconciledResource)
+	in.DeepCopyInto(out)
+	return out
+}
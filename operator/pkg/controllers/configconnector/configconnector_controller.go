@@ -27,6 +27,9 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	cnrmmanifest "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/preflight"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/resourcehealth"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/rollout"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceactuation"
 	corekcck8s "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
 
 	"github.com/go-logr/logr"
@@ -35,6 +38,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -80,6 +84,7 @@ type Reconciler struct {
 	log                       logr.Logger
 	customizationWatcher      *controllers.CustomizationWatcher
 	managerNamespaceIsolation string
+	rollout                   *rollout.Controller
 }
 
 func Add(mgr ctrl.Manager, opt *ReconcilerOptions) (*Reconciler, error) {
@@ -119,6 +124,7 @@ func newReconciler(mgr ctrl.Manager, opt *ReconcilerOptions) (*Reconciler, error
 		labelMaker:                declarative.SourceLabel(mgr.GetScheme()),
 		log:                       ctrl.Log.WithName(controllerName),
 		managerNamespaceIsolation: opt.ManagerNamespaceIsolation,
+		rollout:                   rollout.NewController(mgr.GetClient(), repo),
 	}
 
 	r.customizationWatcher = controllers.NewWithDynamicClient(
@@ -137,6 +143,7 @@ func newReconciler(mgr ctrl.Manager, opt *ReconcilerOptions) (*Reconciler, error
 		declarative.WithObjectTransform(r.handleConfigConnectorLifecycle()),
 		declarative.WithObjectTransform(r.installV1Beta1CRDsOnly()),
 		declarative.WithObjectTransform(r.applyCustomizations()),
+		declarative.WithObjectTransform(r.grantShardLeasePermissions()),
 		declarative.WithObjectTransform(r.transformForExperiments()),
 		declarative.WithStatus(&declarative.StatusBuilder{
 			PreflightImpl: preflight,
@@ -180,7 +187,132 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	r.log.Info("successfully finished reconcile", "ConfigConnector", req.NamespacedName)
-	return reconcile.Result{RequeueAfter: corekcck8s.MeanReconcileReenqueuePeriod}, r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	if err := r.handleReconcileSucceeded(ctx, req.NamespacedName); err != nil {
+		return reconcile.Result{}, err
+	}
+	requeueAfter := corekcck8s.MeanReconcileReenqueuePeriod
+	rolloutRequeueAfter, err := r.reconcileRollout(ctx, req.NamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if rolloutRequeueAfter > 0 && rolloutRequeueAfter < requeueAfter {
+		requeueAfter = rolloutRequeueAfter
+	}
+	actuationRequeueAfter, err := r.reconcileActuationStatus(ctx, req.NamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if actuationRequeueAfter > 0 && actuationRequeueAfter < requeueAfter {
+		requeueAfter = actuationRequeueAfter
+	}
+	healthRequeueAfter, err := r.reconcileResourceHealth(ctx, req.NamespacedName)
+	if err != nil {
+		r.log.Error(err, "error refreshing the health of the resources", "ConfigConnector", req.NamespacedName)
+		// Don't fail entire reconciliation if we cannot summarize the health of the resources.
+	}
+	if healthRequeueAfter > 0 && healthRequeueAfter < requeueAfter {
+		requeueAfter = healthRequeueAfter
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileActuationStatus surfaces the actuation mode in effect, taking `spec.actuationSchedule` into account.
+// It returns how long to wait before the schedule next changes the mode, or 0 if it does not.
+func (r *Reconciler) reconcileActuationStatus(ctx context.Context, nn types.NamespacedName) (time.Duration, error) {
+	cc, err := controllers.GetConfigConnector(ctx, r.client, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting ConfigConnector object %v: %w", nn.Name, err)
+	}
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(*cc, corev1beta1.ConfigConnectorContext{}, now)
+	var requeueAfter time.Duration
+	if actuation.NextTransitionTime != nil {
+		requeueAfter = actuation.NextTransitionTime.Sub(now)
+	}
+	if equality.Semantic.DeepEqual(cc.Status.Actuation, &actuation) {
+		return requeueAfter, nil
+	}
+	if previous := cc.Status.Actuation; previous != nil && previous.Mode != actuation.Mode {
+		r.recordEvent(cc, corev1.EventTypeNormal, "Actuation"+string(actuation.Mode), controllers.ActuationMessage(actuation))
+	}
+	cc.Status.Actuation = &actuation
+	return requeueAfter, r.updateConfigConnectorStatus(ctx, cc)
+}
+
+// reconcileResourceHealth summarizes the health of the Config Connector resources in the status of the
+// ConfigConnector object, unless it was refreshed within the refresh period. In cluster mode, it lists the
+// resources in all namespaces and records their metrics; in namespaced mode, it merges the summaries of the
+// ConfigConnectorContext objects, which record the metrics of their namespaces.
+// It returns how long to wait before the summary needs to be refreshed again.
+func (r *Reconciler) reconcileResourceHealth(ctx context.Context, nn types.NamespacedName) (time.Duration, error) {
+	cc, err := controllers.GetConfigConnector(ctx, r.client, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting ConfigConnector object %v: %w", nn.Name, err)
+	}
+	now := time.Now()
+	if h := cc.Status.ResourceHealth; h != nil && h.LastRefreshTime != nil {
+		if age := now.Sub(h.LastRefreshTime.Time); age < resourcehealth.RefreshPeriod {
+			return resourcehealth.RefreshPeriod - age, nil
+		}
+	}
+
+	var summary *resourcehealth.Summary
+	if cc.GetMode() == k8s.ClusterMode {
+		summaries, err := resourcehealth.Collect(ctx, r.client, "")
+		if err != nil {
+			return resourcehealth.RefreshPeriod, err
+		}
+		resourcehealth.RecordClusterMetrics(summaries, now)
+		summary = resourcehealth.Merged(summaries)
+	} else {
+		cccs := &corev1beta1.ConfigConnectorContextList{}
+		if err := r.client.List(ctx, cccs); err != nil {
+			return resourcehealth.RefreshPeriod, fmt.Errorf("error listing ConfigConnectorContext objects: %w", err)
+		}
+		summary = resourcehealth.NewSummary()
+		for _, ccc := range cccs.Items {
+			summary.Merge(ccc.Status.ResourceHealth)
+		}
+	}
+	cc.Status.ResourceHealth = summary.Status(now)
+	return resourcehealth.RefreshPeriod, r.updateConfigConnectorStatus(ctx, cc)
+}
+
+// reconcileRollout advances the progressive rollout of Config Connector versions across namespaces, if any.
+// It returns how long to wait before the rollout needs to be reconciled again, or 0 if nothing is pending.
+func (r *Reconciler) reconcileRollout(ctx context.Context, nn types.NamespacedName) (time.Duration, error) {
+	cc, err := controllers.GetConfigConnector(ctx, r.client, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting ConfigConnector object %v: %w", nn.Name, err)
+	}
+	if cc.Spec.RolloutPolicy == nil && cc.Status.Rollout == nil {
+		return 0, nil
+	}
+	previous := cc.Status.Rollout.DeepCopy()
+	requeueAfter, err := r.rollout.Reconcile(ctx, cc)
+	if err != nil {
+		return 0, fmt.Errorf("error reconciling the rollout: %w", err)
+	}
+	if equality.Semantic.DeepEqual(previous, cc.Status.Rollout) {
+		return requeueAfter, nil
+	}
+	if status := cc.Status.Rollout; status != nil && (previous == nil || previous.Phase != status.Phase || previous.Stage != status.Stage) {
+		eventType := corev1.EventTypeNormal
+		if status.Phase == corev1beta1.RolloutRolledBack {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recordEvent(cc, eventType, "Rollout"+string(status.Phase), status.Message)
+	}
+	return requeueAfter, r.updateConfigConnectorStatus(ctx, cc)
 }
 
 func (r *Reconciler) handleReconcileFailed(ctx context.Context, nn types.NamespacedName, reconcileErr error) error {
@@ -633,6 +765,18 @@ func (r *Reconciler) applyCustomizations() declarative.ObjectTransform {
 	}
 }
 
+// grantShardLeasePermissions lets the controller managers claim shards, so that they can be scaled out
+// with the "replicas" field of ControllerResource or NamespacedControllerResource.
+func (r *Reconciler) grantShardLeasePermissions() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		cc, ok := o.(*corev1beta1.ConfigConnector)
+		if !ok {
+			return fmt.Errorf("expected the resource to be a ConfigConnector, but it was not. Object: %v", o)
+		}
+		return controllers.AddShardLeaseRole(m, cc.GetMode())
+	}
+}
+
 // fetchAndApplyAllControllerResourceCRs lists all cluster-scoped controller resource CRs, and applies them to
 // the corresponding manifest objects.
 func (r *Reconciler) fetchAndApplyAllControllerResourceCRs(ctx context.Context, m *manifest.Objects) error {
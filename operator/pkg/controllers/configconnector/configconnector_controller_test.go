@@ -1517,10 +1517,10 @@ func TestClusterScopedApplyCustomizations(t *testing.T) {
 			},
 		},
 		{
-			name:                         "customize the replicas for cnrm-controller-manager has no effect",
+			name:                         "customize the replicas for cnrm-controller-manager shards the resources",
 			manifests:                    testcontroller.ClusterModeComponents,
 			clusterScopedCustomizationCR: testcontroller.ControllerResourceCRForControllerManagerReplicas,
-			expectedManifests:            testcontroller.ClusterModeComponents, // same as the input manifests
+			expectedManifests:            testcontroller.ClusterModeComponentsWithShardedControllerManager,
 			expectedCustomizationCRStatus: customizev1beta1.ControllerResourceStatus{
 				CommonStatus: addonv1alpha1.CommonStatus{
 					Healthy:            true,
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	customizev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/customize/v1beta1"
 	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
@@ -25,25 +26,32 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	cnrmmanifest "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/preflight"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/resourcehealth"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/rollout"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cluster"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/jitter"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceactuation"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/apis/v1alpha1"
@@ -51,7 +59,12 @@ import (
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
 )
 
-const controllerName = "configconnectorcontext-controller"
+const (
+	controllerName = "configconnectorcontext-controller"
+
+	// healthRefreshJitterFactor spreads the refreshes of the resource health summaries of the namespaces.
+	healthRefreshJitterFactor = 0.2
+)
 
 // ReconcilerOptions holds configuration options for the reconciler
 type ReconcilerOptions struct {
@@ -91,6 +104,10 @@ func Add(mgr ctrl.Manager, opt *ReconcilerOptions) error {
 		Named(controllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 20}).
 		WatchesRawSource(source.TypedChannel(r.customizationWatcher.Events(), &handler.EnqueueRequestForObject{})).
+		// Changes to the rollout of the ConfigConnector object change the version of namespaces.
+		Watches(&corev1beta1.ConfigConnector{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllConfigConnectorContexts),
+			builder.WithPredicates(rolloutChangedPredicate())).
 		For(obj, builder.OnlyMetadata).
 		Build(r)
 	if err != nil {
@@ -100,13 +117,57 @@ func Add(mgr ctrl.Manager, opt *ReconcilerOptions) error {
 	return nil
 }
 
+// enqueueAllConfigConnectorContexts enqueues every ConfigConnectorContext, so that each picks up its version from the rollout.
+func (r *Reconciler) enqueueAllConfigConnectorContexts(ctx context.Context, _ client.Object) []reconcile.Request {
+	cccs := &metav1.PartialObjectMetadataList{}
+	cccs.SetGroupVersionKind(corev1beta1.ConfigConnectorContextGroupVersionKind.GroupVersion().WithKind("ConfigConnectorContextList"))
+	if err := r.client.List(ctx, cccs); err != nil {
+		r.log.Error(err, "error listing ConfigConnectorContexts to enqueue after a rollout change")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ccc := range cccs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ccc.Namespace, Name: ccc.Name}})
+	}
+	return requests
+}
+
+// rolloutChangedPredicate filters ConfigConnector events to those that change the rollout status.
+func rolloutChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCC, ok := e.ObjectOld.(*corev1beta1.ConfigConnector)
+			if !ok {
+				return false
+			}
+			newCC, ok := e.ObjectNew.(*corev1beta1.ConfigConnector)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldCC.Status.Rollout, newCC.Status.Rollout)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			cc, ok := e.Object.(*corev1beta1.ConfigConnector)
+			return ok && cc.Status.Rollout != nil
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 func newReconciler(mgr ctrl.Manager, opt *ReconcilerOptions) (*Reconciler, error) {
 	repo := cnrmmanifest.NewLocalRepository(opt.RepoPath)
-	manifestLoader := cnrmmanifest.NewPerNamespaceManifestLoader(repo)
+	manifestLoader := cnrmmanifest.NewPerNamespaceManifestLoader(repo).
+		WithVersionResolver(rollout.NewVersionResolver(mgr.GetClient()))
 	preflight := preflight.NewCompositePreflight([]declarative.Preflight{
 		preflight.NewNameChecker(mgr.GetClient(), corev1beta1.ConfigConnectorContextAllowedName),
 		preflight.NewUpgradeChecker(mgr.GetClient(), repo),
 		preflight.NewConfigConnectorContextChecker(),
+		preflight.NewPermissionChecker(mgr.GetClient()),
 	})
 
 	r := &Reconciler{
@@ -137,6 +198,7 @@ func newReconciler(mgr ctrl.Manager, opt *ReconcilerOptions) (*Reconciler, error
 	}
 
 	options = append(options,
+		declarative.WithObjectTransform(r.grantShardLeasePermissions()),
 		declarative.WithObjectTransform(r.addLabels()),
 		declarative.WithObjectTransform(r.handleCCContextLifecycle()),
 		declarative.WithObjectTransform(r.applyNamespacedCustomizations()),
@@ -158,6 +220,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			r.log.Info("ConfigConnectorContext not found in API server; skipping the reconciliation", "name", req.NamespacedName)
+			resourcehealth.ForgetMetrics(req.Namespace)
 			return reconcile.Result{}, nil
 		}
 	}
@@ -175,9 +238,125 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// Don't fail entire reconciliation if we cannot start watch for customization CRDs.
 		// return reconcile.Result{}, err
 	}
+	if err := r.handleReconcileSucceeded(ctx, req.NamespacedName); err != nil {
+		return reconcile.Result{}, err
+	}
+	actuationRequeueAfter, err := r.reconcileActuationStatus(ctx, req.NamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	healthRequeueAfter, err := r.refreshResourceHealth(ctx, req.NamespacedName)
+	if err != nil {
+		r.log.Error(err, "error refreshing the health of the resources", "ConfigConnectorContext", req.NamespacedName)
+		// Don't fail entire reconciliation if we cannot summarize the health of the resources.
+	}
 	jitteredPeriod := r.jitterGen.WatchJitteredTimeout()
+	for _, requeueAfter := range []time.Duration{actuationRequeueAfter, healthRequeueAfter} {
+		if requeueAfter > 0 && requeueAfter < jitteredPeriod {
+			jitteredPeriod = requeueAfter
+		}
+	}
 	r.log.Info("successfully finished reconcile", "ConfigConnectorContext", req.NamespacedName, "time to next reconciliation", jitteredPeriod)
-	return reconcile.Result{RequeueAfter: jitteredPeriod}, r.handleReconcileSucceeded(ctx, req.NamespacedName)
+	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
+}
+
+// reconcileActuationStatus surfaces the actuation mode in effect in the namespace, taking the actuation schedules
+// of the ConfigConnectorContext and ConfigConnector objects into account.
+// It returns how long to wait before the schedules next change the mode, or 0 if they do not or if there is no
+// ConfigConnector object.
+func (r *Reconciler) reconcileActuationStatus(ctx context.Context, nn types.NamespacedName) (time.Duration, error) {
+	ccc, err := r.getConfigConnectorContext(ctx, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting ConfigConnectorContext object %v/%v: %w", nn.Namespace, nn.Name, err)
+	}
+	cc, err := controllers.GetConfigConnector(ctx, r.client, controllers.ValidConfigConnectorNamespacedName)
+	if err != nil {
+		// Without a ConfigConnector, nothing is actuated in the namespace, so there is no mode to surface.
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting the ConfigConnector object %v: %w", controllers.ValidConfigConnectorNamespacedName, err)
+	}
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(*cc, *ccc, now)
+	var requeueAfter time.Duration
+	if actuation.NextTransitionTime != nil {
+		requeueAfter = actuation.NextTransitionTime.Sub(now)
+	}
+	if equality.Semantic.DeepEqual(ccc.Status.Actuation, &actuation) {
+		return requeueAfter, nil
+	}
+	if previous := ccc.Status.Actuation; previous != nil && previous.Mode != actuation.Mode {
+		r.recorder.Event(ccc, corev1.EventTypeNormal, "Actuation"+string(actuation.Mode), controllers.ActuationMessage(actuation))
+	}
+	ccc.Status.Actuation = &actuation
+	return requeueAfter, r.updateConfigConnectorContextStatus(ctx, ccc)
+}
+
+// refreshResourceHealth summarizes the health of the Config Connector resources in the namespace in the
+// status of the ConfigConnectorContext, and in metrics, unless it was refreshed within the refresh period.
+// While the namespace is part of a rollout stage, the summary is refreshed again once the soak of the stage ends,
+// for the rollout to judge the stage by it.
+// It returns how long to wait before the summary needs to be refreshed again, jittered so that the
+// ConfigConnectorContexts do not all list their resources at once.
+func (r *Reconciler) refreshResourceHealth(ctx context.Context, nn types.NamespacedName) (time.Duration, error) {
+	ccc, err := r.getConfigConnectorContext(ctx, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("error getting ConfigConnectorContext object %v/%v: %w", nn.Namespace, nn.Name, err)
+	}
+	var soakEnd time.Time
+	inStage := false
+	cc, err := controllers.GetConfigConnector(ctx, r.client, controllers.ValidConfigConnectorNamespacedName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("error getting the ConfigConnector object %v: %w", controllers.ValidConfigConnectorNamespacedName, err)
+		}
+	} else {
+		_, soakEnd, inStage = rollout.StageOfNamespace(cc, nn.Namespace)
+	}
+
+	now := time.Now()
+	if h := ccc.Status.ResourceHealth; h != nil && h.LastRefreshTime != nil && !healthStale(h, now, inStage, soakEnd) {
+		return nextHealthRefresh(h.LastRefreshTime.Time, now, inStage, soakEnd), nil
+	}
+	summaries, err := resourcehealth.Collect(ctx, r.client, nn.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	summary, ok := summaries[nn.Namespace]
+	if !ok {
+		summary = resourcehealth.NewSummary()
+	}
+	resourcehealth.RecordNamespaceMetrics(nn.Namespace, summary, now)
+	ccc.Status.ResourceHealth = summary.Status(now)
+	return nextHealthRefresh(now, now, inStage, soakEnd), r.updateConfigConnectorContextStatus(ctx, ccc)
+}
+
+// healthStale returns true if the summary in status must be refreshed: it is older than the refresh period,
+// or it predates the end of the soak of the current rollout stage, which has passed.
+func healthStale(h *corev1beta1.ResourceHealth, now time.Time, inStage bool, soakEnd time.Time) bool {
+	lastRefresh := h.LastRefreshTime.Time
+	if now.Sub(lastRefresh) >= resourcehealth.RefreshPeriod {
+		return true
+	}
+	// Times in status have a resolution of seconds.
+	return inStage && lastRefresh.Before(soakEnd.Truncate(time.Second)) && !now.Before(soakEnd)
+}
+
+// nextHealthRefresh returns how long to wait before refreshing a summary that was last refreshed at lastRefresh:
+// until the refresh period passes or, during a rollout stage, until its soak ends if that is sooner.
+func nextHealthRefresh(lastRefresh, now time.Time, inStage bool, soakEnd time.Time) time.Duration {
+	next := lastRefresh.Add(resourcehealth.RefreshPeriod)
+	if inStage && now.Before(soakEnd) && soakEnd.Before(next) {
+		next = soakEnd
+	}
+	return wait.Jitter(next.Sub(now), healthRefreshJitterFactor)
 }
 
 func (r *Reconciler) getConfigConnectorContext(ctx context.Context, nn types.NamespacedName) (*corev1beta1.ConfigConnectorContext, error) {
@@ -266,6 +445,14 @@ func (r *Reconciler) transformPerNamespaceComponents() declarative.ObjectTransfo
 	}
 }
 
+// grantShardLeasePermissions lets the controller manager of the namespace claim shards, so that it can be scaled out
+// with the "replicas" field of NamespacedControllerResource.
+func (r *Reconciler) grantShardLeasePermissions() declarative.ObjectTransform {
+	return func(ctx context.Context, o declarative.DeclarativeObject, m *manifest.Objects) error {
+		return controllers.AddShardLeaseRoleBinding(m)
+	}
+}
+
 // Add labels that will be used for the controller to dynamically watch on deployed KCC components.
 func (r *Reconciler) addLabels() declarative.ObjectTransform {
 	return func(ctx context.Context, o declarative.DeclarativeObject, manifest *manifest.Objects) error {
@@ -562,7 +749,7 @@ func (r *Reconciler) applyNamespacedControllerResource(ctx context.Context, cr *
 		Version: appsv1.SchemeGroupVersion.Version,
 		Kind:    "StatefulSet",
 	}
-	if err := controllers.ApplyContainerResourceCustomization(true, m, cr.Name, controllerGVK, cr.Spec.Containers, nil); err != nil {
+	if err := controllers.ApplyContainerResourceCustomization(true, m, cr.Name, controllerGVK, cr.Spec.Containers, cr.Spec.Replicas); err != nil {
 		r.log.Error(err, "failed to apply customization", "Namespace", cr.Namespace, "Name", cr.Name)
 		return r.handleApplyNamespacedControllerResourceFailed(ctx, cr.Namespace, cr.Name, fmt.Sprintf("failed to apply customization %s: %v", cr.Name, err))
 	}
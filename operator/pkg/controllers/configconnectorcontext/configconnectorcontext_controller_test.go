@@ -26,6 +26,7 @@ import (
 	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/resourcehealth"
 	testcontroller "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/controller"
 	testmain "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/main"
 	testmocks "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/test/mocks"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	addonv1alpha1 "sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/addon/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
 )
@@ -1453,3 +1455,86 @@ func TestControllerOverridesField(t *testing.T) {
 		t.Fatalf("field .spec.experiments.controllerOverrides not found in unstructured object")
 	}
 }
+
+func TestReconcileActuationStatusWithoutConfigConnector(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("error building scheme: %v", err)
+	}
+	ccc := &corev1beta1.ConfigConnectorContext{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1beta1.ConfigConnectorContextAllowedName, Namespace: "foo-ns"},
+	}
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ccc).Build(),
+		log:    logr.Discard(),
+	}
+	requeueAfter, err := r.reconcileActuationStatus(ctx, client.ObjectKeyFromObject(ccc))
+	if err != nil || requeueAfter != 0 {
+		t.Errorf("reconcileActuationStatus() = (%v, %v), want (0, nil)", requeueAfter, err)
+	}
+}
+
+func TestHealthRefresh(t *testing.T) {
+	stageStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	soakEnd := stageStart.Add(10 * time.Minute)
+	refreshedAt := func(refreshed time.Time) *corev1beta1.ResourceHealth {
+		return &corev1beta1.ResourceHealth{LastRefreshTime: &metav1.Time{Time: refreshed}}
+	}
+	tests := []struct {
+		name    string
+		health  *corev1beta1.ResourceHealth
+		now     time.Time
+		inStage bool
+		want    bool
+	}{
+		{
+			name:   "refreshed within the refresh period",
+			health: refreshedAt(stageStart),
+			now:    stageStart.Add(time.Minute),
+		},
+		{
+			name:   "refreshed before the refresh period",
+			health: refreshedAt(stageStart),
+			now:    stageStart.Add(resourcehealth.RefreshPeriod),
+			want:   true,
+		},
+		{
+			name:    "refreshed during the soak",
+			health:  refreshedAt(stageStart.Add(time.Minute)),
+			now:     stageStart.Add(2 * time.Minute),
+			inStage: true,
+		},
+		{
+			name:    "refreshed before the soak ended",
+			health:  refreshedAt(soakEnd.Add(-time.Minute)),
+			now:     soakEnd,
+			inStage: true,
+			want:    true,
+		},
+		{
+			name:    "refreshed after the soak ended",
+			health:  refreshedAt(soakEnd),
+			now:     soakEnd.Add(time.Minute),
+			inStage: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := healthStale(tc.health, tc.now, tc.inStage, soakEnd); got != tc.want {
+				t.Errorf("healthStale() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// The next refresh is jittered, but not before the refresh period passes or the soak ends.
+	for i := 0; i < 10; i++ {
+		if got := nextHealthRefresh(stageStart, stageStart, false, time.Time{}); got < resourcehealth.RefreshPeriod || got > resourcehealth.RefreshPeriod*6/5 {
+			t.Errorf("unexpected next refresh in %v, want between %v and %v", got, resourcehealth.RefreshPeriod, resourcehealth.RefreshPeriod*6/5)
+		}
+		now := soakEnd.Add(-time.Minute)
+		if got := nextHealthRefresh(now, now, true, soakEnd); got < time.Minute || got > time.Minute*6/5 {
+			t.Errorf("unexpected next refresh in %v before the soak ends, want between 1m and 1m12s", got)
+		}
+	}
+}
@@ -19,9 +19,12 @@ import (
 	"testing"
 
 	customizev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/customize/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative/pkg/manifest"
 )
 
 func TestValidateContainerResourceCustomizationValues(t *testing.T) {
@@ -351,3 +354,74 @@ func TestApplyRateLimitToContainerArg(t *testing.T) {
 		})
 	}
 }
+
+func TestAddShardLeaseRole(t *testing.T) {
+	for _, mode := range []string{k8s.ClusterMode, k8s.NamespacedMode} {
+		m := &manifest.Objects{}
+		if err := AddShardLeaseRole(m, mode); err != nil {
+			t.Fatalf("error adding the shard lease role: %v", err)
+		}
+		// adding it twice has no effect
+		if err := AddShardLeaseRole(m, mode); err != nil {
+			t.Fatalf("error adding the shard lease role: %v", err)
+		}
+		var got []string
+		for _, item := range m.Items {
+			if ns := item.UnstructuredObject().GetNamespace(); ns != k8s.CNRMSystemNamespace {
+				t.Errorf("expected %v %v to be in %v, got %v", item.Kind, item.GetName(), k8s.CNRMSystemNamespace, ns)
+			}
+			got = append(got, item.Kind+"/"+item.GetName())
+		}
+		want := []string{"Role/" + k8s.ShardLeaseRole}
+		if mode == k8s.ClusterMode {
+			want = append(want, "RoleBinding/"+k8s.ShardLeaseRoleBinding)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected objects in %v mode: got %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func TestAddShardLeaseRoleBinding(t *testing.T) {
+	newRoleBinding := func(name, namespace string) *manifest.Object {
+		obj, err := manifest.NewObject(&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "RoleBinding",
+			"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+			"roleRef": map[string]interface{}{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "ClusterRole",
+				"name":     "cnrm-manager-ns-role",
+			},
+			"subjects": []interface{}{
+				map[string]interface{}{"kind": "ServiceAccount", "name": "cnrm-controller-manager-foo", "namespace": "cnrm-system"},
+			},
+		}})
+		if err != nil {
+			t.Fatalf("error creating RoleBinding: %v", err)
+		}
+		return obj
+	}
+	m := &manifest.Objects{Items: []*manifest.Object{
+		newRoleBinding("cnrm-manager-ns-binding-foo", "foo"),
+		newRoleBinding("cnrm-manager-ns-binding-foo", k8s.CNRMSystemNamespace),
+	}}
+	if err := AddShardLeaseRoleBinding(m); err != nil {
+		t.Fatalf("error adding the shard lease role binding: %v", err)
+	}
+	if len(m.Items) != 3 {
+		t.Fatalf("expected one RoleBinding to be added, got %v objects", len(m.Items))
+	}
+	u := m.Items[2].UnstructuredObject()
+	if u.GetName() != "cnrm-manager-shard-lease-binding-foo" || u.GetNamespace() != k8s.CNRMSystemNamespace {
+		t.Errorf("unexpected RoleBinding %v/%v", u.GetNamespace(), u.GetName())
+	}
+	roleRef, _, _ := unstructured.NestedStringMap(u.Object, "roleRef")
+	if roleRef["kind"] != "Role" || roleRef["name"] != k8s.ShardLeaseRole {
+		t.Errorf("unexpected roleRef %v", roleRef)
+	}
+	subjects, _, _ := unstructured.NestedSlice(u.Object, "subjects")
+	if len(subjects) != 1 || subjects[0].(map[string]interface{})["name"] != "cnrm-controller-manager-foo" {
+		t.Errorf("unexpected subjects %v", subjects)
+	}
+}
@@ -47,6 +47,14 @@ func GetConfigConnector(ctx context.Context, client client.Client, nn types.Name
 	return cc, nil
 }
 
+// ActuationMessage describes the actuation mode in effect, e.g. for an event when it changes.
+func ActuationMessage(actuation corev1beta1.ActuationStatus) string {
+	if actuation.Reason == "" {
+		return fmt.Sprintf("actuation mode in effect is '%v'", actuation.Mode)
+	}
+	return fmt.Sprintf("actuation mode in effect is '%v': %v", actuation.Mode, actuation.Reason)
+}
+
 func RemoveOperatorFinalizer(o metav1.Object) (found bool) {
 	var finalizers []string
 	for _, f := range o.GetFinalizers() {
@@ -221,6 +229,15 @@ func ApplyContainerResourceCustomization(isNamespaced bool, m *manifest.Objects,
 					}
 					shouldUpdateHPA = true
 				}
+				// the controller manager shards resources across its replicas.
+				if replicas != nil && controllerName == "cnrm-controller-manager" {
+					if err := item.SetNestedField(*replicas, "spec", "replicas"); err != nil {
+						return err
+					}
+					if err := item.MutateContainers(customizeShardsFn("manager", *replicas)); err != nil {
+						return err
+					}
+				}
 				break // we already found the matching controller, no need to keep looking.
 			}
 		}
@@ -506,6 +523,156 @@ func validateContainerResourceCustomizationValues(r customizev1beta1.ResourceReq
 	return nil
 }
 
+func customizeShardsFn(target string, replicas int64) func(container map[string]interface{}) error {
+	return func(container map[string]interface{}) error {
+		name, _, err := unstructured.NestedString(container, "name")
+		if err != nil {
+			return fmt.Errorf("error reading container name: %w", err)
+		}
+		if name != target {
+			return nil
+		}
+		return applyShardsToContainer(container, replicas)
+	}
+}
+
+// applyShardsToContainer sets the number of shards of the controller manager to its number of replicas,
+// and exposes the pod name that identifies each replica when it claims a shard.
+func applyShardsToContainer(container map[string]interface{}, replicas int64) error {
+	origArgs, found, err := unstructured.NestedStringSlice(container, "args")
+	if err != nil {
+		return fmt.Errorf("error getting args in container: %w", err)
+	}
+	wantArgs := []string{}
+	if replicas > 1 {
+		wantArgs = append(wantArgs, fmt.Sprintf("--shards=%d", replicas))
+	}
+	if found {
+		for _, arg := range origArgs {
+			if strings.HasPrefix(arg, "--shards") {
+				continue
+			}
+			wantArgs = append(wantArgs, arg)
+		}
+	}
+	if err := unstructured.SetNestedStringSlice(container, wantArgs, "args"); err != nil {
+		return fmt.Errorf("error setting args in container: %w", err)
+	}
+
+	envs, _, err := unstructured.NestedSlice(container, "env")
+	if err != nil {
+		return fmt.Errorf("error getting container env list: %w", err)
+	}
+	for _, e := range envs {
+		if env, ok := e.(map[string]interface{}); ok && env["name"] == "POD_NAME" {
+			return nil
+		}
+	}
+	envs = append(envs, map[string]interface{}{
+		"name": "POD_NAME",
+		"valueFrom": map[string]interface{}{
+			"fieldRef": map[string]interface{}{
+				"fieldPath": "metadata.name",
+			},
+		},
+	})
+	if err := unstructured.SetNestedSlice(container, envs, "env"); err != nil {
+		return fmt.Errorf("error setting container env list: %w", err)
+	}
+	return nil
+}
+
+// AddShardLeaseRole grants the controller manager access to the Leases it uses to claim shards.
+// The Leases live in the cnrm-system namespace, so the Role and its bindings are scoped to that namespace
+// rather than added to cnrm-manager-ns-role, which is bound in every watched namespace.
+// In cluster mode, the Role is also bound to the cluster-mode controller manager.
+func AddShardLeaseRole(m *manifest.Objects, mode string) error {
+	role := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "Role",
+		"metadata": map[string]interface{}{
+			"name":      k8s.ShardLeaseRole,
+			"namespace": k8s.CNRMSystemNamespace,
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"apiGroups": []interface{}{"coordination.k8s.io"},
+				"resources": []interface{}{"leases"},
+				"verbs":     []interface{}{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+		},
+	}}
+	if err := addObjectIfMissing(m, role); err != nil {
+		return err
+	}
+	if mode != k8s.ClusterMode {
+		return nil
+	}
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "RoleBinding",
+		"metadata": map[string]interface{}{
+			"name":      k8s.ShardLeaseRoleBinding,
+			"namespace": k8s.CNRMSystemNamespace,
+		},
+		"roleRef": map[string]interface{}{
+			"apiGroup": "rbac.authorization.k8s.io",
+			"kind":     "Role",
+			"name":     k8s.ShardLeaseRole,
+		},
+		"subjects": []interface{}{
+			map[string]interface{}{
+				"kind":      "ServiceAccount",
+				"name":      k8s.KCCControllerManagerComponent,
+				"namespace": k8s.CNRMSystemNamespace,
+			},
+		},
+	}}
+	return addObjectIfMissing(m, binding)
+}
+
+// AddShardLeaseRoleBinding binds the shard Lease Role to the controller manager of a namespace.
+// The binding is derived from the RoleBinding of cnrm-manager-ns-role in cnrm-system, so that it has the same
+// subjects, labels and name suffix as the other per-namespace components.
+func AddShardLeaseRoleBinding(m *manifest.Objects) error {
+	for _, item := range m.Items {
+		if item.Kind != "RoleBinding" || item.UnstructuredObject().GetNamespace() != k8s.CNRMSystemNamespace {
+			continue
+		}
+		roleName, _, err := unstructured.NestedString(item.UnstructuredObject().Object, "roleRef", "name")
+		if err != nil {
+			return fmt.Errorf("error getting roleRef of RoleBinding %v: %w", item.GetName(), err)
+		}
+		if roleName != "cnrm-manager-ns-role" {
+			continue
+		}
+		u := item.UnstructuredObject().DeepCopy()
+		u.SetName(strings.Replace(u.GetName(), "cnrm-manager-ns-binding", k8s.ShardLeaseRoleBinding, 1))
+		if err := unstructured.SetNestedField(u.Object, "Role", "roleRef", "kind"); err != nil {
+			return fmt.Errorf("error setting roleRef of RoleBinding %v: %w", u.GetName(), err)
+		}
+		if err := unstructured.SetNestedField(u.Object, k8s.ShardLeaseRole, "roleRef", "name"); err != nil {
+			return fmt.Errorf("error setting roleRef of RoleBinding %v: %w", u.GetName(), err)
+		}
+		return addObjectIfMissing(m, u)
+	}
+	return nil
+}
+
+func addObjectIfMissing(m *manifest.Objects, u *unstructured.Unstructured) error {
+	for _, item := range m.Items {
+		if item.Kind == u.GetKind() && item.GetName() == u.GetName() && item.UnstructuredObject().GetNamespace() == u.GetNamespace() {
+			return nil
+		}
+	}
+	obj, err := manifest.NewObject(u)
+	if err != nil {
+		return fmt.Errorf("error creating %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+	}
+	m.Items = append(m.Items, obj)
+	return nil
+}
+
 func ApplyContainerRateLimit(m *manifest.Objects, targetControllerName string, ratelimit *customizev1beta1.RateLimit) error {
 	if ratelimit == nil {
 		return nil
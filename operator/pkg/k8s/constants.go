@@ -38,11 +38,15 @@ const (
 	VersionAnnotation                  = "cnrm.cloud.google.com/version"
 	OperatorVersionAnnotation          = "cnrm.cloud.google.com/operator-version"
 	ProjectIDAnnotation                = "cnrm.cloud.google.com/project-id"
+	FolderIDAnnotation                 = "cnrm.cloud.google.com/folder-id"
+	OrganizationIDAnnotation           = "cnrm.cloud.google.com/organization-id"
 	CoreCNRMGroup                      = "core.cnrm.cloud.google.com"
 	UpToDate                           = "UpToDate"
 	UpToDateMessage                    = "ConfigConnector is up to date"
 	UpdateFailed                       = "UpdateFailed"
 	ControllerManagerService           = "cnrm-manager"
+	ShardLeaseRole                     = "cnrm-manager-shard-lease-role"
+	ShardLeaseRoleBinding              = "cnrm-manager-shard-lease-binding"
 	NamespacedManagerServicePrefix     = "cnrm-manager-"
 	NamespacedManagerServiceTmpl       = "cnrm-manager-${NAMESPACE?}"
 	ClusterMode                        = "cluster"
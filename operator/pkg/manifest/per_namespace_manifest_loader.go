@@ -29,8 +29,16 @@ const (
 	StableChannel                = "stable"
 )
 
+// NamespaceVersionResolver decides which version of Config Connector a namespace runs,
+// when its ConfigConnectorContext does not set the version.
+type NamespaceVersionResolver interface {
+	// ResolveNamespaceVersion returns the version for the namespace, or "" to use the latest version in the stable channel.
+	ResolveNamespaceVersion(ctx context.Context, namespace string) (string, error)
+}
+
 type PerNamespaceManifestLoader struct {
-	repo Repository
+	repo            Repository
+	versionResolver NamespaceVersionResolver
 }
 
 // Ensure that PerNamespaceManifestLoader implements declarative.ManifestController.
@@ -42,6 +50,12 @@ func NewPerNamespaceManifestLoader(repo Repository) *PerNamespaceManifestLoader
 	}
 }
 
+// WithVersionResolver sets the resolver that decides the version of namespaces whose ConfigConnectorContext does not set it.
+func (p *PerNamespaceManifestLoader) WithVersionResolver(resolver NamespaceVersionResolver) *PerNamespaceManifestLoader {
+	p.versionResolver = resolver
+	return p
+}
+
 func (p *PerNamespaceManifestLoader) ResolveManifest(ctx context.Context, o runtime.Object) (map[string]string, error) {
 	ccc, ok := o.(*corev1beta1.ConfigConnectorContext)
 	if !ok {
@@ -52,6 +66,13 @@ func (p *PerNamespaceManifestLoader) ResolveManifest(ctx context.Context, o runt
 	channelName := StableChannel
 
 	version := ccc.Spec.Version
+	if version == "" && p.versionResolver != nil {
+		v, err := p.versionResolver.ResolveNamespaceVersion(ctx, ccc.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving the version for namespace %v: %w", ccc.Namespace, err)
+		}
+		version = v
+	}
 	if version == "" {
 		v, err := ResolveVersion(ctx, p.repo, componentName, channelName)
 		if err != nil {
@@ -0,0 +1,226 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/pkg/patterns/declarative"
+)
+
+const (
+	// permissionRecheckPeriod is how long a successful check is trusted, so that we don't call GCP on every reconcile.
+	permissionRecheckPeriod = time.Hour
+
+	// maxPermissionsPerTest is the maximum number of permissions in a testIamPermissions request.
+	maxPermissionsPerTest = 100
+
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+var (
+	plog = ctrl.Log.WithName("PermissionChecker")
+)
+
+// NewPermissionChecker provides an implementation of declarative.Preflight that checks that the Google Service Account
+// of a ConfigConnectorContext has the permissions in spec.permissionCheck, before Config Connector is enabled in the namespace.
+// It impersonates the Google Service Account with the operator's credentials, calls testIamPermissions on the project,
+// folder or organization of the namespace, and surfaces the missing permissions in status.permissionCheck.
+func NewPermissionChecker(client client.Client) *PermissionChecker {
+	return &PermissionChecker{client: client, now: time.Now}
+}
+
+type PermissionChecker struct {
+	client client.Client
+
+	// transport sends the requests to GCP, or is nil to use http.DefaultTransport.
+	transport http.RoundTripper
+	// tokenSource provides the operator's credentials, or is nil to use the application default credentials.
+	tokenSource oauth2.TokenSource
+
+	now func() time.Time
+}
+
+func (p *PermissionChecker) Preflight(ctx context.Context, o declarative.DeclarativeObject) error {
+	plog.Info("preflight check before reconciling the object", "kind", o.GetObjectKind().GroupVersionKind().Kind, "name", o.GetName(), "namespace", o.GetNamespace())
+
+	ccc, ok := o.(*corev1beta1.ConfigConnectorContext)
+	if !ok {
+		return fmt.Errorf("expected the resource to be a ConfigConnectorContext, but it was not. Object: %v", o)
+	}
+	if !ccc.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if ccc.Spec.PermissionCheck == nil {
+		if ccc.Status.PermissionCheck == nil {
+			return nil
+		}
+		return p.updateStatus(ctx, ccc, nil)
+	}
+
+	gsa := ccc.Spec.GoogleServiceAccount
+	if gsa == "" {
+		return fmt.Errorf("spec.googleServiceAccount must be set if spec.permissionCheck is set")
+	}
+	resource, err := p.resourceToCheck(ctx, ccc)
+	if err != nil {
+		return err
+	}
+	if last := ccc.Status.PermissionCheck; last != nil && last.ObservedGeneration == ccc.Generation &&
+		last.ServiceAccount == gsa && last.Resource == resource && last.Message == "" && len(last.MissingPermissions) == 0 &&
+		p.now().Sub(last.LastCheckTime.Time) < permissionRecheckPeriod {
+		return nil
+	}
+
+	result := &corev1beta1.PermissionCheckStatus{
+		LastCheckTime:      metav1.NewTime(p.now()),
+		ObservedGeneration: ccc.Generation,
+		ServiceAccount:     gsa,
+		Resource:           resource,
+	}
+	missing, checkErr := p.missingPermissions(ctx, gsa, resource, ccc.Spec.PermissionCheck.Permissions)
+	if checkErr != nil {
+		result.Message = checkErr.Error()
+	}
+	result.MissingPermissions = missing
+	if err := p.updateStatus(ctx, ccc, result); err != nil {
+		return err
+	}
+
+	if checkErr != nil {
+		return fmt.Errorf("error checking the permissions of %v on %v: %w", gsa, resource, checkErr)
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("%v is missing permissions on %v: %v", gsa, resource, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// resourceToCheck returns the project, folder or organization to check the permissions on: the one in spec.permissionCheck,
+// or else the one that Config Connector defaults to for the resources in the namespace.
+func (p *PermissionChecker) resourceToCheck(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext) (string, error) {
+	if resource := ccc.Spec.PermissionCheck.Resource; resource != "" {
+		return resource, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: ccc.Namespace}, ns); err != nil {
+		return "", fmt.Errorf("error getting namespace %v: %w", ccc.Namespace, err)
+	}
+	annotations := ns.GetAnnotations()
+	switch {
+	case annotations[k8s.ProjectIDAnnotation] != "":
+		return "projects/" + annotations[k8s.ProjectIDAnnotation], nil
+	case annotations[k8s.FolderIDAnnotation] != "":
+		return "folders/" + annotations[k8s.FolderIDAnnotation], nil
+	case annotations[k8s.OrganizationIDAnnotation] != "":
+		return "organizations/" + annotations[k8s.OrganizationIDAnnotation], nil
+	}
+	return "projects/" + ccc.Namespace, nil
+}
+
+// missingPermissions returns the permissions that the Google Service Account does not have on the resource.
+func (p *PermissionChecker) missingPermissions(ctx context.Context, gsa string, resource string, permissions []string) ([]string, error) {
+	operatorTokenSource := p.tokenSource
+	if operatorTokenSource == nil {
+		ts, err := google.DefaultTokenSource(ctx, cloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the credentials of the operator: %w", err)
+		}
+		operatorTokenSource = ts
+	}
+	iamCredentials, err := iamcredentials.NewService(ctx, option.WithHTTPClient(p.httpClient(operatorTokenSource)))
+	if err != nil {
+		return nil, fmt.Errorf("error building the IAM Credentials client: %w", err)
+	}
+	token, err := iamCredentials.Projects.ServiceAccounts.GenerateAccessToken("projects/-/serviceAccounts/"+gsa, &iamcredentials.GenerateAccessTokenRequest{
+		Scope:    []string{cloudPlatformScope},
+		Lifetime: "300s",
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error impersonating %v, check that the operator has the 'iam.serviceAccounts.getAccessToken' permission on it: %w", gsa, err)
+	}
+
+	gsaTokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token.AccessToken})
+	crm, err := cloudresourcemanager.NewService(ctx, option.WithHTTPClient(p.httpClient(gsaTokenSource)))
+	if err != nil {
+		return nil, fmt.Errorf("error building the Resource Manager client: %w", err)
+	}
+
+	granted := make(map[string]bool)
+	for start := 0; start < len(permissions); start += maxPermissionsPerTest {
+		end := min(start+maxPermissionsPerTest, len(permissions))
+		req := &cloudresourcemanager.TestIamPermissionsRequest{Permissions: permissions[start:end]}
+
+		var response *cloudresourcemanager.TestIamPermissionsResponse
+		kind, _, _ := strings.Cut(resource, "/")
+		switch kind {
+		case "projects":
+			response, err = crm.Projects.TestIamPermissions(resource, req).Context(ctx).Do()
+		case "folders":
+			response, err = crm.Folders.TestIamPermissions(resource, req).Context(ctx).Do()
+		case "organizations":
+			response, err = crm.Organizations.TestIamPermissions(resource, req).Context(ctx).Do()
+		default:
+			return nil, fmt.Errorf("unsupported resource %q, must be a project, folder or organization", resource)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error testing the permissions on %v: %w", resource, err)
+		}
+		for _, permission := range response.Permissions {
+			granted[permission] = true
+		}
+	}
+
+	var missing []string
+	for _, permission := range permissions {
+		if !granted[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	return missing, nil
+}
+
+func (p *PermissionChecker) httpClient(ts oauth2.TokenSource) *http.Client {
+	return &http.Client{Transport: &oauth2.Transport{Source: ts, Base: p.transport}}
+}
+
+// updateStatus sets status.permissionCheck. It patches only that field, because the rest of the status is updated
+// by the reconciler after the preflight checks.
+func (p *PermissionChecker) updateStatus(ctx context.Context, ccc *corev1beta1.ConfigConnectorContext, result *corev1beta1.PermissionCheckStatus) error {
+	original := ccc.DeepCopy()
+	ccc.Status.PermissionCheck = result
+	if err := p.client.Status().Patch(ctx, ccc, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("error updating the permission check status of ConfigConnectorContext %v/%v: %w", ccc.Namespace, ccc.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/authz"
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testProject      = "my-project"
+	operatorGSA      = "operator@operator-project.iam.gserviceaccount.com"
+	namespaceGSA     = "kcc-namespace@my-project.iam.gserviceaccount.com"
+	unimpersonateGSA = "other-account@my-project.iam.gserviceaccount.com"
+)
+
+// newMockGCP returns a transport that serves the IAM Credentials and testIamPermissions requests of the permission
+// checker with the mockgcp authorizer, with IAM enforcement on: the operator can impersonate namespaceGSA but not
+// unimpersonateGSA, and namespaceGSA can manage buckets.
+func newMockGCP() http.RoundTripper {
+	authorizer := authz.NewAuthorizer()
+	authorizer.AddPrincipal("operator-token", "serviceAccount:"+operatorGSA)
+	authorizer.AddBinding("projects/"+testProject+"/serviceAccounts/"+namespaceGSA, "roles/iam.serviceAccountTokenCreator", "serviceAccount:"+operatorGSA)
+	authorizer.AddBinding("projects/"+testProject, "roles/storage.admin", "serviceAccount:"+namespaceGSA)
+	authorizer.AddBinding("projects/"+testProject, "roles/storage.admin", "serviceAccount:"+unimpersonateGSA)
+	return &mockGCP{authorizer: authorizer}
+}
+
+type mockGCP struct {
+	authorizer *authz.Authorizer
+}
+
+func (m *mockGCP) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Remove the API version, e.g. /v3/projects/p:testIamPermissions => projects/p:testIamPermissions
+	_, requestPath, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	if name, ok := strings.CutSuffix(requestPath, ":generateAccessToken"); ok && req.URL.Host == "iamcredentials.googleapis.com" {
+		// The name is projects/-/serviceAccounts/<email>; the project is inferred from the email.
+		email := path.Base(name)
+		_, domain, _ := strings.Cut(email, "@")
+		projectID := strings.TrimSuffix(domain, ".iam.gserviceaccount.com")
+		if err := m.authorizer.AuthorizePermissionHTTP(req, "iam.serviceAccounts.getAccessToken", "iam.googleapis.com", "projects/"+projectID+"/serviceAccounts/"+email); err != nil {
+			return errorResponse(err), nil
+		}
+		return jsonResponse(http.StatusOK, map[string]any{
+			"accessToken": m.authorizer.IssueToken("serviceAccount:" + email),
+			"expireTime":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		}), nil
+	}
+	if resource, ok := strings.CutSuffix(requestPath, ":testIamPermissions"); ok {
+		request := struct {
+			Permissions []string `json:"permissions"`
+		}{}
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+		granted, err := m.authorizer.TestPermissionsHTTP(req, resource, request.Permissions)
+		if err != nil {
+			return errorResponse(err), nil
+		}
+		return jsonResponse(http.StatusOK, map[string]any{"permissions": granted}), nil
+	}
+	return jsonResponse(http.StatusNotFound, map[string]any{}), nil
+}
+
+func errorResponse(err error) *http.Response {
+	httpCode := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		httpCode = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		httpCode = http.StatusForbidden
+	}
+	return jsonResponse(httpCode, map[string]any{
+		"error": map[string]any{"code": httpCode, "message": status.Convert(err).Message()},
+	})
+}
+
+func jsonResponse(httpCode int, body any) *http.Response {
+	b, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: httpCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}
+}
+
+func TestPermissionChecker_Preflight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		ns              *corev1.Namespace
+		spec            corev1beta1.ConfigConnectorContextSpec
+		previousStatus  *corev1beta1.PermissionCheckStatus
+		expectedStatus  *corev1beta1.PermissionCheckStatus
+		expectedMessage string
+		expectedErr     string
+	}{
+		{
+			name: "no permission check",
+			spec: corev1beta1.ConfigConnectorContextSpec{
+				GoogleServiceAccount: namespaceGSA,
+			},
+		},
+		{
+			name: "the service account has the permissions on the project of the namespace",
+			ns: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo-ns",
+				Annotations: map[string]string{k8s.ProjectIDAnnotation: testProject},
+			}},
+			spec: corev1beta1.ConfigConnectorContextSpec{
+				GoogleServiceAccount: namespaceGSA,
+				PermissionCheck: &corev1beta1.PermissionCheckSpec{
+					Permissions: []string{"storage.buckets.create", "storage.buckets.delete"},
+				},
+			},
+			expectedStatus: &corev1beta1.PermissionCheckStatus{
+				ServiceAccount: namespaceGSA,
+				Resource:       "projects/" + testProject,
+			},
+		},
+		{
+			name: "the service account is missing permissions",
+			spec: corev1beta1.ConfigConnectorContextSpec{
+				GoogleServiceAccount: namespaceGSA,
+				PermissionCheck: &corev1beta1.PermissionCheckSpec{
+					Resource:    "projects/" + testProject,
+					Permissions: []string{"compute.instances.create", "storage.buckets.create", "pubsub.topics.create"},
+				},
+			},
+			expectedStatus: &corev1beta1.PermissionCheckStatus{
+				ServiceAccount:     namespaceGSA,
+				Resource:           "projects/" + testProject,
+				MissingPermissions: []string{"compute.instances.create", "pubsub.topics.create"},
+			},
+			expectedErr: "is missing permissions on projects/my-project: compute.instances.create, pubsub.topics.create",
+		},
+		{
+			name: "the operator cannot impersonate the service account",
+			spec: corev1beta1.ConfigConnectorContextSpec{
+				GoogleServiceAccount: unimpersonateGSA,
+				PermissionCheck: &corev1beta1.PermissionCheckSpec{
+					Resource:    "projects/" + testProject,
+					Permissions: []string{"storage.buckets.create"},
+				},
+			},
+			expectedStatus: &corev1beta1.PermissionCheckStatus{
+				ServiceAccount: unimpersonateGSA,
+				Resource:       "projects/" + testProject,
+			},
+			expectedMessage: "iam.serviceAccounts.getAccessToken",
+			expectedErr:     "error checking the permissions of " + unimpersonateGSA,
+		},
+		{
+			name: "the status of a removed permission check is cleared",
+			spec: corev1beta1.ConfigConnectorContextSpec{
+				GoogleServiceAccount: namespaceGSA,
+			},
+			previousStatus: &corev1beta1.PermissionCheckStatus{
+				ServiceAccount:     namespaceGSA,
+				Resource:           "projects/" + testProject,
+				MissingPermissions: []string{"compute.instances.create"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ns := tc.ns
+			if ns == nil {
+				ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo-ns"}}
+			}
+			ccc := &corev1beta1.ConfigConnectorContext{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       corev1beta1.ConfigConnectorContextAllowedName,
+					Namespace:  ns.Name,
+					Generation: 1,
+				},
+				Spec: tc.spec,
+				Status: corev1beta1.ConfigConnectorContextStatus{
+					PermissionCheck: tc.previousStatus,
+				},
+			}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(ns, ccc).WithStatusSubresource(ccc).Build()
+
+			checker := NewPermissionChecker(kubeClient)
+			checker.transport = newMockGCP()
+			checker.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "operator-token"})
+
+			err := checker.Preflight(ctx, ccc)
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedErr)) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+			}
+
+			updated := &corev1beta1.ConfigConnectorContext{}
+			if err := kubeClient.Get(ctx, client.ObjectKeyFromObject(ccc), updated); err != nil {
+				t.Fatalf("error getting ConfigConnectorContext: %v", err)
+			}
+			status := updated.Status.PermissionCheck
+			if tc.expectedStatus == nil {
+				if status != nil {
+					t.Fatalf("expected no permission check status, got %+v", status)
+				}
+				return
+			}
+			if status == nil {
+				t.Fatalf("expected permission check status %+v, got none", tc.expectedStatus)
+			}
+			if !strings.Contains(status.Message, tc.expectedMessage) || (tc.expectedMessage == "" && status.Message != "") {
+				t.Errorf("expected status message containing %q, got %q", tc.expectedMessage, status.Message)
+			}
+			if status.LastCheckTime.IsZero() || status.ObservedGeneration != 1 {
+				t.Errorf("expected the check time and generation to be set, got %+v", status)
+			}
+			status.Message, status.LastCheckTime, status.ObservedGeneration = "", metav1.Time{}, 0
+			if !reflect.DeepEqual(status, tc.expectedStatus) {
+				t.Errorf("unexpected permission check status: got %+v, want %+v", status, tc.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestPermissionChecker_SkipsRecentSuccess(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testProject}}
+	ccc := &corev1beta1.ConfigConnectorContext{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       corev1beta1.ConfigConnectorContextAllowedName,
+			Namespace:  ns.Name,
+			Generation: 2,
+		},
+		Spec: corev1beta1.ConfigConnectorContextSpec{
+			GoogleServiceAccount: namespaceGSA,
+			PermissionCheck: &corev1beta1.PermissionCheckSpec{
+				Permissions: []string{"storage.buckets.create"},
+			},
+		},
+		Status: corev1beta1.ConfigConnectorContextStatus{
+			PermissionCheck: &corev1beta1.PermissionCheckStatus{
+				LastCheckTime:      metav1.NewTime(now.Add(-time.Minute)),
+				ObservedGeneration: 2,
+				ServiceAccount:     namespaceGSA,
+				Resource:           "projects/" + testProject,
+			},
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(ns, ccc).WithStatusSubresource(ccc).Build()
+
+	// GCP is not called, so the check succeeds even though the operator has no credentials.
+	checker := NewPermissionChecker(kubeClient)
+	checker.transport = http.NewFileTransport(http.Dir(t.TempDir()))
+	checker.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "unknown-token"})
+	checker.now = func() time.Time { return now }
+	if err := checker.Preflight(ctx, ccc); err != nil {
+		t.Fatalf("expected the recent successful check to be trusted, got %v", err)
+	}
+
+	// Once the period has passed, the permissions are checked again.
+	checker.now = func() time.Time { return now.Add(permissionRecheckPeriod) }
+	if err := checker.Preflight(ctx, ccc); err == nil {
+		t.Fatalf("expected the permissions to be checked again after %v", permissionRecheckPeriod)
+	}
+}
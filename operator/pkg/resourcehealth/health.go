@@ -0,0 +1,296 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcehealth summarizes the health of Config Connector resources from their Ready conditions,
+// for the `status.resourceHealth` of the ConfigConnector and ConfigConnectorContext objects and for metrics.
+package resourcehealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	k8sv1alpha1 "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	corekcck8s "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RefreshPeriod is how often the summaries in status are refreshed.
+	RefreshPeriod = 5 * time.Minute
+
+	maxTopErrors     = 5
+	maxMessageLength = 512
+	listPageSize     = 500
+)
+
+// Summary accumulates the health of resources.
+type Summary struct {
+	kinds  map[string]*corev1beta1.KindHealth
+	errors map[errorKey]*corev1beta1.ResourceErrorSummary
+	oldest *corev1beta1.UnreconciledResource
+}
+
+type errorKey struct {
+	reason  string
+	message string
+}
+
+func NewSummary() *Summary {
+	return &Summary{
+		kinds:  make(map[string]*corev1beta1.KindHealth),
+		errors: make(map[errorKey]*corev1beta1.ResourceErrorSummary),
+	}
+}
+
+// Add records the health of a resource.
+func (s *Summary) Add(u *unstructured.Unstructured) {
+	kind := s.kind(u.GetKind())
+	kind.Total++
+
+	ready := readyCondition(u)
+	reason, _ := ready["reason"].(string)
+	switch reason {
+	case corekcck8s.UpToDate:
+		kind.UpToDate++
+	case corekcck8s.UpdateFailed:
+		kind.UpdateFailed++
+	case corekcck8s.DependencyNotReady:
+		kind.DependencyNotReady++
+	case corekcck8s.DeleteFailed:
+		kind.DeleteFailed++
+	}
+
+	id := fmt.Sprintf("%v/%v/%v", u.GetNamespace(), u.GetKind(), u.GetName())
+	if status, _ := ready["status"].(string); ready != nil && status != string(metav1.ConditionTrue) {
+		message, _ := ready["message"].(string)
+		if len(message) > maxMessageLength {
+			message = message[:maxMessageLength]
+		}
+		s.addError(reason, message, 1, id)
+	}
+
+	if isUnreconciled(u, ready) {
+		since := u.GetCreationTimestamp()
+		if t, ok := ready["lastTransitionTime"].(string); ok {
+			if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+				since = metav1.NewTime(parsed)
+			}
+		}
+		s.addUnreconciled(&corev1beta1.UnreconciledResource{
+			Kind:      u.GetKind(),
+			Namespace: u.GetNamespace(),
+			Name:      u.GetName(),
+			Since:     since,
+		})
+	}
+}
+
+// Merge adds a summary reported in status, e.g. by a ConfigConnectorContext.
+// Only the top errors of the summary are known, so the counts of merged errors are lower bounds.
+func (s *Summary) Merge(h *corev1beta1.ResourceHealth) {
+	if h == nil {
+		return
+	}
+	for _, k := range h.Kinds {
+		kind := s.kind(k.Kind)
+		kind.Total += k.Total
+		kind.UpToDate += k.UpToDate
+		kind.UpdateFailed += k.UpdateFailed
+		kind.DependencyNotReady += k.DependencyNotReady
+		kind.DeleteFailed += k.DeleteFailed
+	}
+	for _, e := range h.TopErrors {
+		s.addError(e.Reason, e.Message, e.Count, e.Example)
+	}
+	if h.OldestUnreconciled != nil {
+		s.addUnreconciled(h.OldestUnreconciled.DeepCopy())
+	}
+}
+
+// UpToDatePercent returns the percentage of resources that are up to date, out of those that are either
+// up to date or failed to update, or 100 if there are none. Resources that are still being reconciled,
+// or that are failing for other reasons (e.g. missing dependencies), are not counted.
+func (s *Summary) UpToDatePercent() int32 {
+	var upToDate, failed int64
+	for _, k := range s.kinds {
+		upToDate += int64(k.UpToDate)
+		failed += int64(k.UpdateFailed)
+	}
+	if upToDate+failed == 0 {
+		return 100
+	}
+	return int32(upToDate * 100 / (upToDate + failed))
+}
+
+// Status returns the summary for the status of a ConfigConnector or ConfigConnectorContext object.
+func (s *Summary) Status(now time.Time) *corev1beta1.ResourceHealth {
+	h := &corev1beta1.ResourceHealth{
+		LastRefreshTime: &metav1.Time{Time: now},
+	}
+	for _, k := range s.kinds {
+		h.Kinds = append(h.Kinds, *k)
+	}
+	sort.Slice(h.Kinds, func(i, j int) bool {
+		return h.Kinds[i].Kind < h.Kinds[j].Kind
+	})
+	for _, e := range s.errors {
+		h.TopErrors = append(h.TopErrors, *e)
+	}
+	sort.Slice(h.TopErrors, func(i, j int) bool {
+		a, b := h.TopErrors[i], h.TopErrors[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		return a.Message < b.Message
+	})
+	if len(h.TopErrors) > maxTopErrors {
+		h.TopErrors = h.TopErrors[:maxTopErrors]
+	}
+	if s.oldest != nil {
+		h.OldestUnreconciled = s.oldest.DeepCopy()
+	}
+	return h
+}
+
+func (s *Summary) kind(name string) *corev1beta1.KindHealth {
+	kind, ok := s.kinds[name]
+	if !ok {
+		kind = &corev1beta1.KindHealth{Kind: name}
+		s.kinds[name] = kind
+	}
+	return kind
+}
+
+func (s *Summary) addError(reason, message string, count int32, example string) {
+	key := errorKey{reason: reason, message: message}
+	e, ok := s.errors[key]
+	if !ok {
+		e = &corev1beta1.ResourceErrorSummary{Reason: reason, Message: message, Example: example}
+		s.errors[key] = e
+	}
+	e.Count += count
+	// Keep a stable example, regardless of the order in which resources are listed.
+	if example < e.Example {
+		e.Example = example
+	}
+}
+
+func (s *Summary) addUnreconciled(r *corev1beta1.UnreconciledResource) {
+	if s.oldest == nil || r.Since.Before(&s.oldest.Since) ||
+		(r.Since.Equal(&s.oldest.Since) && r.Namespace+"/"+r.Name < s.oldest.Namespace+"/"+s.oldest.Name) {
+		s.oldest = r
+	}
+}
+
+// Collect summarizes the health of the Config Connector resources in the namespace, or in all namespaces if
+// it is empty, by namespace.
+//
+// Resources are listed in full because their Ready conditions are in their status, which is not returned by
+// metadata-only lists; lists are paginated to bound the memory used.
+func Collect(ctx context.Context, kubeClient client.Client, namespace string) (map[string]*Summary, error) {
+	summaries := make(map[string]*Summary)
+	pageToken := ""
+	var crds []apiextensions.CustomResourceDefinition
+	var err error
+	for ok := true; ok; ok = pageToken != "" {
+		crds, pageToken, err = k8s.ListCRDs(ctx, kubeClient, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, crd := range crds {
+			if len(crd.Spec.Versions) == 0 || crd.Spec.Scope == apiextensions.ClusterScoped {
+				continue
+			}
+			gvk := schema.GroupVersionKind{
+				Group:   crd.Spec.Group,
+				Version: storageVersion(&crd),
+				Kind:    crd.Spec.Names.Kind,
+			}
+			listOpts := &client.ListOptions{
+				Namespace: namespace,
+				Limit:     listPageSize,
+				Raw:       &metav1.ListOptions{},
+			}
+			for ok := true; ok; ok = listOpts.Continue != "" {
+				resources := unstructured.UnstructuredList{}
+				resources.SetGroupVersionKind(gvk)
+				if err := kubeClient.List(ctx, &resources, listOpts); err != nil {
+					return nil, fmt.Errorf("error listing resources for gvk '%v': %w", gvk, err)
+				}
+				for i := range resources.Items {
+					resource := &resources.Items[i]
+					summary, ok := summaries[resource.GetNamespace()]
+					if !ok {
+						summary = NewSummary()
+						summaries[resource.GetNamespace()] = summary
+					}
+					summary.Add(resource)
+				}
+				listOpts.Continue = resources.GetContinue()
+			}
+		}
+	}
+	return summaries, nil
+}
+
+// Merged returns the summary of all the summaries.
+func Merged(summaries map[string]*Summary) *Summary {
+	merged := NewSummary()
+	for _, s := range summaries {
+		merged.Merge(s.Status(time.Time{}))
+	}
+	return merged
+}
+
+func storageVersion(crd *apiextensions.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return crd.Spec.Versions[0].Name
+}
+
+// readyCondition returns the resource's Ready condition, or nil if it has none.
+func readyCondition(u *unstructured.Unstructured) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == k8sv1alpha1.ReadyConditionType {
+			return condition
+		}
+	}
+	return nil
+}
+
+// isUnreconciled returns true if the controller has not yet reconciled the latest spec of the resource.
+func isUnreconciled(u *unstructured.Unstructured, ready map[string]interface{}) bool {
+	if ready == nil {
+		return true
+	}
+	observedGeneration, found, err := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	return err == nil && found && observedGeneration < u.GetGeneration()
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcehealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 = t0.Add(time.Hour)
+)
+
+func TestCollect(t *testing.T) {
+	ctx := context.TODO()
+	c := newFakeClient(t,
+		newCRD(),
+		newResource("a", "topic-1", "UpToDate", "True", "", t1, 1, 1),
+		newResource("a", "topic-2", "UpdateFailed", "False", "permission denied", t1, 2, 2),
+		newResource("a", "topic-3", "UpdateFailed", "False", "permission denied", t0, 2, 1),
+		newResource("b", "topic-1", "DependencyNotReady", "False", "waiting for project", t1, 1, 1),
+		newResource("b", "topic-2", "", "", "", t0, 1, 0),
+	)
+
+	summaries, err := Collect(ctx, c, "")
+	if err != nil {
+		t.Fatalf("error collecting resource health: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("got summaries for %d namespaces, want 2", len(summaries))
+	}
+
+	now := t1.Add(time.Minute)
+	got := summaries["a"].Status(now)
+	want := &corev1beta1.ResourceHealth{
+		LastRefreshTime: &metav1.Time{Time: now},
+		Kinds: []corev1beta1.KindHealth{
+			{Kind: "PubSubTopic", Total: 3, UpToDate: 1, UpdateFailed: 2},
+		},
+		TopErrors: []corev1beta1.ResourceErrorSummary{
+			{Reason: "UpdateFailed", Message: "permission denied", Count: 2, Example: "a/PubSubTopic/topic-2"},
+		},
+		// topic-3 has not observed its latest generation.
+		OldestUnreconciled: &corev1beta1.UnreconciledResource{
+			Kind: "PubSubTopic", Namespace: "a", Name: "topic-3", Since: metav1.NewTime(t0),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected health of namespace a (-want +got):\n%v", diff)
+	}
+	if got, want := summaries["a"].UpToDatePercent(), int32(33); got != want {
+		t.Errorf("unexpected up-to-date percent %v, want %v", got, want)
+	}
+
+	merged := Merged(summaries).Status(now)
+	if got, want := merged.Kinds, []corev1beta1.KindHealth{
+		{Kind: "PubSubTopic", Total: 5, UpToDate: 1, UpdateFailed: 2, DependencyNotReady: 1},
+	}; !cmp.Equal(got, want) {
+		t.Errorf("unexpected merged kinds %+v, want %+v", got, want)
+	}
+	if got, want := len(merged.TopErrors), 2; got != want {
+		t.Errorf("got %d merged errors, want %d", got, want)
+	}
+	// b/topic-2 has no Ready condition, so it is unreconciled since its creation, at the same time as a/topic-3.
+	if got, want := merged.OldestUnreconciled.Namespace+"/"+merged.OldestUnreconciled.Name, "a/topic-3"; got != want {
+		t.Errorf("unexpected oldest unreconciled resource %v, want %v", got, want)
+	}
+
+	summaries, err = Collect(ctx, c, "b")
+	if err != nil {
+		t.Fatalf("error collecting resource health: %v", err)
+	}
+	if _, ok := summaries["a"]; ok || summaries["b"] == nil {
+		t.Errorf("unexpected namespaces collected for namespace b: %v", summaries)
+	}
+}
+
+func TestTopErrorsAreBounded(t *testing.T) {
+	s := NewSummary()
+	for i := 0; i < maxTopErrors+3; i++ {
+		for j := 0; j <= i; j++ {
+			s.Add(newResource("a", "topic", "UpdateFailed", "False", string(rune('a'+i)), t0, 1, 1))
+		}
+	}
+	errors := s.Status(t1).TopErrors
+	if len(errors) != maxTopErrors {
+		t.Fatalf("got %d top errors, want %d", len(errors), maxTopErrors)
+	}
+	if errors[0].Count != maxTopErrors+3 || errors[0].Message != string(rune('a'+maxTopErrors+2)) {
+		t.Errorf("unexpected most frequent error %+v", errors[0])
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := NewSummary()
+	s.Add(newResource("a", "topic-1", "UpToDate", "True", "", t1, 1, 1))
+	s.Add(newResource("a", "topic-2", "Updating", "False", "", t0, 2, 1))
+	RecordNamespaceMetrics("a", s, t1)
+	RecordNamespaceMetrics("b", s, t1)
+
+	if got := testutil.ToFloat64(resourcesGauge.WithLabelValues("a", "PubSubTopic", "UpToDate")); got != 1 {
+		t.Errorf("got %v up-to-date resources, want 1", got)
+	}
+	if got := testutil.ToFloat64(resourcesGauge.WithLabelValues("a", "PubSubTopic", otherReason)); got != 1 {
+		t.Errorf("got %v resources with other reasons, want 1", got)
+	}
+	if got, want := testutil.ToFloat64(oldestUnreconciledGauge.WithLabelValues("a")), time.Hour.Seconds(); got != want {
+		t.Errorf("got oldest unreconciled resource age %v, want %v", got, want)
+	}
+
+	ForgetMetrics("a")
+	if got, want := testutil.CollectAndCount(resourcesGauge), 5; got != want {
+		t.Errorf("got %d resource series after forgetting namespace a, want %d", got, want)
+	}
+	RecordClusterMetrics(map[string]*Summary{"c": s}, t1)
+	if got := testutil.ToFloat64(oldestUnreconciledGauge.WithLabelValues("c")); got == 0 {
+		t.Errorf("missing oldest unreconciled resource age for namespace c")
+	}
+	if got, want := testutil.CollectAndCount(oldestUnreconciledGauge), 1; got != want {
+		t.Errorf("got %d oldest unreconciled series, want %d", got, want)
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := apiextensions.AddToScheme(scheme); err != nil {
+		t.Fatalf("error building scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newCRD() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pubsubtopics.pubsub.cnrm.cloud.google.com",
+			Labels: map[string]string{k8s.KCCSystemLabelSelectorRaw: "true"},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "pubsub.cnrm.cloud.google.com",
+			Names: apiextensions.CustomResourceDefinitionNames{Kind: "PubSubTopic"},
+			Scope: apiextensions.NamespaceScoped,
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Storage: true},
+			},
+		},
+	}
+}
+
+// newResource returns a PubSubTopic with a Ready condition, unless status is empty.
+func newResource(namespace, name, reason, status, message string, transition time.Time, generation, observedGeneration int64) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("pubsub.cnrm.cloud.google.com/v1beta1")
+	u.SetKind("PubSubTopic")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetGeneration(generation)
+	u.SetCreationTimestamp(metav1.NewTime(transition))
+	conditions := []interface{}{}
+	if status != "" {
+		conditions = append(conditions, map[string]interface{}{
+			"type":               "Ready",
+			"status":             status,
+			"reason":             reason,
+			"message":            message,
+			"lastTransitionTime": transition.Format(time.RFC3339),
+		})
+	}
+	u.Object["status"] = map[string]interface{}{
+		"conditions":         conditions,
+		"observedGeneration": observedGeneration,
+	}
+	return u
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcehealth
+
+import (
+	"time"
+
+	corekcck8s "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// otherReason is the reason label of resources whose Ready condition has none of the reasons counted in KindHealth.
+const otherReason = "Other"
+
+var (
+	resourcesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "configconnector_resources",
+		Help: "The number of Config Connector resources, by namespace, kind and reason of their Ready condition.",
+	}, []string{"namespace", "kind", "reason"})
+
+	oldestUnreconciledGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "configconnector_oldest_unreconciled_resource_age_seconds",
+		Help: "The time since the Config Connector resource that has been waiting the longest to be reconciled, by namespace.",
+	}, []string{"namespace"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(resourcesGauge, oldestUnreconciledGauge)
+}
+
+// RecordNamespaceMetrics replaces the metrics of the namespace with those of the summary.
+func RecordNamespaceMetrics(namespace string, s *Summary, now time.Time) {
+	ForgetMetrics(namespace)
+	recordMetrics(namespace, s, now)
+}
+
+// RecordClusterMetrics replaces the metrics of all namespaces with those of the summaries, by namespace.
+func RecordClusterMetrics(summaries map[string]*Summary, now time.Time) {
+	resourcesGauge.Reset()
+	oldestUnreconciledGauge.Reset()
+	for namespace, s := range summaries {
+		recordMetrics(namespace, s, now)
+	}
+}
+
+// ForgetMetrics removes the metrics of the namespace.
+func ForgetMetrics(namespace string) {
+	resourcesGauge.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	oldestUnreconciledGauge.DeleteLabelValues(namespace)
+}
+
+func recordMetrics(namespace string, s *Summary, now time.Time) {
+	for _, k := range s.kinds {
+		other := k.Total - k.UpToDate - k.UpdateFailed - k.DependencyNotReady - k.DeleteFailed
+		for reason, count := range map[string]int32{
+			corekcck8s.UpToDate:           k.UpToDate,
+			corekcck8s.UpdateFailed:       k.UpdateFailed,
+			corekcck8s.DependencyNotReady: k.DependencyNotReady,
+			corekcck8s.DeleteFailed:       k.DeleteFailed,
+			otherReason:                   other,
+		} {
+			resourcesGauge.WithLabelValues(namespace, k.Kind, reason).Set(float64(count))
+		}
+	}
+	if s.oldest != nil {
+		oldestUnreconciledGauge.WithLabelValues(namespace).Set(now.Sub(s.oldest.Since.Time).Seconds())
+	}
+}
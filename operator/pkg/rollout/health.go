@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/resourcehealth"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// baselineUpToDatePercent returns the percentage of Config Connector resources in the namespaces that are up to date,
+// out of those that are either up to date or failed to update, from the summaries in the status of their
+// ConfigConnectorContexts (see resourcehealth.Summary.UpToDatePercent).
+func baselineUpToDatePercent(cccs []corev1beta1.ConfigConnectorContext, namespaces map[string]labels.Set) int32 {
+	summary := resourcehealth.NewSummary()
+	for i := range cccs {
+		if _, ok := namespaces[cccs[i].Namespace]; ok {
+			summary.Merge(cccs[i].Status.ResourceHealth)
+		}
+	}
+	return summary.UpToDatePercent()
+}
+
+// stageUpToDatePercent is like baselineUpToDatePercent, but only uses the summaries refreshed since the soak of the
+// stage ended. The new controller managers reconcile every resource when they start, so by then the Ready conditions
+// of all the resources in the namespaces, including those that stayed ready, were set by the new version.
+// It returns false if the summary of any of the namespaces has not been refreshed since the soak ended.
+// Namespaces that no longer have a ConfigConnectorContext are ignored.
+func stageUpToDatePercent(cccs []corev1beta1.ConfigConnectorContext, namespaces []string, soakEnd time.Time) (int32, bool) {
+	summary := resourcehealth.NewSummary()
+	for i := range cccs {
+		if !slices.Contains(namespaces, cccs[i].Namespace) {
+			continue
+		}
+		h := cccs[i].Status.ResourceHealth
+		// Times in status have a resolution of seconds.
+		if h == nil || h.LastRefreshTime == nil || h.LastRefreshTime.Time.Before(soakEnd.Truncate(time.Second)) {
+			return 0, false
+		}
+		summary.Merge(h)
+	}
+	return summary.UpToDatePercent(), true
+}
+
+// notReadyManagers returns the namespaces whose controller manager pods are not all Ready and running the version,
+// sorted by name.
+func notReadyManagers(ctx context.Context, kubeClient client.Client, namespaces []string, version string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := kubeClient.List(ctx, pods, client.InNamespace(k8s.CNRMSystemNamespace),
+		client.MatchingLabels{k8s.KCCSystemComponentLabel: k8s.KCCControllerManagerComponent}); err != nil {
+		return nil, fmt.Errorf("error listing controller manager pods: %w", err)
+	}
+
+	ready := make(map[string]bool)
+	for _, pod := range pods.Items {
+		ns := pod.Labels[k8s.NamespacedComponentLabel]
+		if ns == "" {
+			continue
+		}
+		podReady := pod.Annotations[k8s.VersionAnnotation] == version && isPodReady(&pod)
+		if previous, found := ready[ns]; found {
+			podReady = podReady && previous
+		}
+		ready[ns] = podReady
+	}
+
+	var notReady []string
+	for _, ns := range namespaces {
+		if !ready[ns] {
+			notReady = append(notReady, ns)
+		}
+	}
+	sort.Strings(notReady)
+	return notReady, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
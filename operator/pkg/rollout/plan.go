@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"fmt"
+	"sort"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Plan splits the namespaces in the rollout into stages: the canary namespaces, then one stage per batch,
+// then all remaining namespaces. Each namespace is in exactly one stage; stages may be empty.
+// namespaces maps the name of each namespace in the rollout to its labels.
+func Plan(policy *corev1beta1.RolloutPolicy, namespaces map[string]labels.Set) ([][]string, error) {
+	var names []string
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assigned := make(map[string]bool)
+	var stages [][]string
+
+	var canaries []string
+	for _, name := range policy.CanaryNamespaces {
+		if _, found := namespaces[name]; found && !assigned[name] {
+			canaries = append(canaries, name)
+			assigned[name] = true
+		}
+	}
+	stages = append(stages, canaries)
+
+	for i, batch := range policy.Batches {
+		selector := labels.Everything()
+		if batch.Selector != nil {
+			s, err := metav1.LabelSelectorAsSelector(batch.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing the selector of batch %v: %w", i, err)
+			}
+			selector = s
+		}
+
+		var candidates []string
+		upgraded := 0
+		for _, name := range names {
+			if !selector.Matches(namespaces[name]) {
+				continue
+			}
+			candidates = append(candidates, name)
+			if assigned[name] {
+				upgraded++
+			}
+		}
+
+		percentage := int32(100)
+		if batch.Percentage != nil {
+			percentage = *batch.Percentage
+		}
+		// Round up, so that every batch with a non-zero percentage includes at least one namespace.
+		want := (len(candidates)*int(percentage) + 99) / 100
+
+		var stage []string
+		for _, name := range candidates {
+			if upgraded >= want {
+				break
+			}
+			if assigned[name] {
+				continue
+			}
+			stage = append(stage, name)
+			assigned[name] = true
+			upgraded++
+		}
+		stages = append(stages, stage)
+	}
+
+	var remaining []string
+	for _, name := range names {
+		if !assigned[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	stages = append(stages, remaining)
+
+	return stages, nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rollout implements the progressive rollout of Config Connector versions across namespaces,
+// as defined by the ConfigConnector's `spec.rolloutPolicy`.
+//
+// The ConfigConnector controller advances the rollout and records its progress in `status.rollout`;
+// the ConfigConnectorContext controller reads that status to decide which version each namespace runs.
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/controllers"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/manifest"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultMinUpToDatePercent = 90
+	defaultSoakDuration       = 10 * time.Minute
+
+	// stageHealthPollPeriod is how often to check whether the ConfigConnectorContexts of a stage have refreshed
+	// the health of their resources since the soak ended.
+	stageHealthPollPeriod = time.Minute
+)
+
+// Controller advances rollouts.
+type Controller struct {
+	client client.Client
+	repo   manifest.Repository
+
+	// now is the clock, for tests.
+	now func() time.Time
+}
+
+func NewController(client client.Client, repo manifest.Repository) *Controller {
+	return &Controller{
+		client: client,
+		repo:   repo,
+		now:    time.Now,
+	}
+}
+
+// Reconcile advances the rollout defined by the ConfigConnector's rollout policy, updating `cc.Status.Rollout`;
+// the caller is responsible for persisting the status.
+// It returns how long to wait before the rollout should be reconciled again, or 0 if nothing is pending.
+//
+// A rollout starts over when its baseline or target version changes.
+func (c *Controller) Reconcile(ctx context.Context, cc *corev1beta1.ConfigConnector) (time.Duration, error) {
+	policy := cc.Spec.RolloutPolicy
+	if policy == nil || cc.GetMode() != k8s.NamespacedMode {
+		cc.Status.Rollout = nil
+		return 0, nil
+	}
+
+	target, err := c.targetVersion(ctx, policy)
+	if err != nil {
+		return 0, err
+	}
+
+	cccs := &corev1beta1.ConfigConnectorContextList{}
+	if err := c.client.List(ctx, cccs); err != nil {
+		return 0, fmt.Errorf("error listing ConfigConnectorContexts: %w", err)
+	}
+	namespaces, err := c.rolloutNamespaces(ctx, cccs.Items)
+	if err != nil {
+		return 0, err
+	}
+	stages, err := Plan(policy, namespaces)
+	if err != nil {
+		return 0, err
+	}
+
+	status := cc.Status.Rollout
+	if status == nil || status.BaselineVersion != policy.BaselineVersion || status.TargetVersion != target {
+		baseline := baselineUpToDatePercent(cccs.Items, namespaces)
+		status = &corev1beta1.RolloutStatus{
+			BaselineVersion:         policy.BaselineVersion,
+			TargetVersion:           target,
+			Phase:                   corev1beta1.RolloutProgressing,
+			Stage:                   -1,
+			BaselineUpToDatePercent: &baseline,
+		}
+		cc.Status.Rollout = status
+		return c.startNextStage(policy, status, stages), nil
+	}
+
+	if status.Phase != corev1beta1.RolloutProgressing {
+		return 0, nil
+	}
+
+	soak := soakDuration(policy)
+	if status.StageStartTime != nil {
+		if elapsed := c.now().Sub(status.StageStartTime.Time); elapsed < soak {
+			return soak - elapsed, nil
+		}
+	}
+
+	notReady, err := notReadyManagers(ctx, c.client, status.UpdatedNamespaces, status.TargetVersion)
+	if err != nil {
+		return 0, err
+	}
+	if len(notReady) != 0 {
+		status.Phase = corev1beta1.RolloutRolledBack
+		status.Message = fmt.Sprintf("stage %v is unhealthy: the controller managers of namespace(s) %v are not ready with version %v; all namespaces were rolled back to version %v",
+			status.Stage, strings.Join(notReady, ", "), status.TargetVersion, status.BaselineVersion)
+		return 0, nil
+	}
+
+	var soakEnd time.Time
+	if status.StageStartTime != nil {
+		soakEnd = status.StageStartTime.Add(soak)
+	}
+	percent, ok := stageUpToDatePercent(cccs.Items, status.UpdatedNamespaces, soakEnd)
+	if !ok {
+		status.Message = fmt.Sprintf("waiting for the health of the resources in the namespaces upgraded to version %v to be refreshed", status.TargetVersion)
+		return stageHealthPollPeriod, nil
+	}
+	threshold := minUpToDatePercent(policy)
+	if status.BaselineUpToDatePercent != nil && *status.BaselineUpToDatePercent < threshold {
+		threshold = *status.BaselineUpToDatePercent
+	}
+	if percent < threshold {
+		status.Phase = corev1beta1.RolloutRolledBack
+		status.Message = fmt.Sprintf("stage %v is unhealthy: %v%% of resources in the upgraded namespaces are up to date, below the threshold of %v%%; all namespaces were rolled back to version %v",
+			status.Stage, percent, threshold, status.BaselineVersion)
+		return 0, nil
+	}
+
+	return c.startNextStage(policy, status, stages), nil
+}
+
+// startNextStage upgrades the next stage with namespaces that are not yet upgraded, or completes the rollout if there is none.
+func (c *Controller) startNextStage(policy *corev1beta1.RolloutPolicy, status *corev1beta1.RolloutStatus, stages [][]string) time.Duration {
+	for stage := int(status.Stage) + 1; stage < len(stages); stage++ {
+		var pending []string
+		for _, name := range stages[stage] {
+			if !slices.Contains(status.UpdatedNamespaces, name) {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		status.Stage = int32(stage)
+		status.UpdatedNamespaces = append(status.UpdatedNamespaces, pending...)
+		status.StageStartTime = &metav1.Time{Time: c.now()}
+		status.Message = fmt.Sprintf("upgraded %v namespace(s) in stage %v to version %v", len(pending), stage, status.TargetVersion)
+		return soakDuration(policy)
+	}
+
+	status.Stage = int32(len(stages) - 1)
+	status.Phase = corev1beta1.RolloutCompleted
+	status.StageStartTime = nil
+	status.Message = fmt.Sprintf("all namespaces run version %v", status.TargetVersion)
+	return 0
+}
+
+func (c *Controller) targetVersion(ctx context.Context, policy *corev1beta1.RolloutPolicy) (string, error) {
+	if policy.TargetVersion != "" {
+		return policy.TargetVersion, nil
+	}
+	version, err := manifest.ResolveVersion(ctx, c.repo, manifest.ConfigConnectorComponentName, manifest.StableChannel)
+	if err != nil {
+		return "", fmt.Errorf("error resolving the target version of the rollout: %w", err)
+	}
+	return version, nil
+}
+
+// rolloutNamespaces returns the namespaces that are part of the rollout, with their labels:
+// those with a ConfigConnectorContext that does not pin the version.
+func (c *Controller) rolloutNamespaces(ctx context.Context, cccs []corev1beta1.ConfigConnectorContext) (map[string]labels.Set, error) {
+	nsList := &corev1.NamespaceList{}
+	if err := c.client.List(ctx, nsList); err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+	nsLabels := make(map[string]labels.Set)
+	for _, ns := range nsList.Items {
+		nsLabels[ns.Name] = labels.Set(ns.Labels)
+	}
+
+	namespaces := make(map[string]labels.Set)
+	for _, ccc := range cccs {
+		if ccc.Spec.Version != "" || !ccc.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		namespaces[ccc.Namespace] = nsLabels[ccc.Namespace]
+	}
+	return namespaces, nil
+}
+
+func soakDuration(policy *corev1beta1.RolloutPolicy) time.Duration {
+	if policy.HealthGate != nil && policy.HealthGate.SoakDuration != nil {
+		return policy.HealthGate.SoakDuration.Duration
+	}
+	return defaultSoakDuration
+}
+
+func minUpToDatePercent(policy *corev1beta1.RolloutPolicy) int32 {
+	if policy.HealthGate != nil && policy.HealthGate.MinUpToDatePercent != nil {
+		return *policy.HealthGate.MinUpToDatePercent
+	}
+	return defaultMinUpToDatePercent
+}
+
+// StageOfNamespace returns when the current stage of the rollout started and when its soak ends, if the namespace
+// was upgraded by a rollout in progress. ConfigConnectorContexts refresh the health of their resources once the soak
+// ends, for the rollout to decide whether the stage is healthy.
+func StageOfNamespace(cc *corev1beta1.ConfigConnector, namespace string) (stageStart, soakEnd time.Time, ok bool) {
+	policy := cc.Spec.RolloutPolicy
+	status := cc.Status.Rollout
+	if policy == nil || cc.GetMode() != k8s.NamespacedMode || status == nil || status.Phase != corev1beta1.RolloutProgressing ||
+		status.StageStartTime == nil || !slices.Contains(status.UpdatedNamespaces, namespace) {
+		return time.Time{}, time.Time{}, false
+	}
+	return status.StageStartTime.Time, status.StageStartTime.Add(soakDuration(policy)), true
+}
+
+// VersionForNamespace returns the version of Config Connector that the namespace should run according to the rollout,
+// or "" if there is no rollout and the default version should be used.
+func VersionForNamespace(cc *corev1beta1.ConfigConnector, namespace string) string {
+	policy := cc.Spec.RolloutPolicy
+	if policy == nil || cc.GetMode() != k8s.NamespacedMode {
+		return ""
+	}
+	status := cc.Status.Rollout
+	if status == nil || status.BaselineVersion != policy.BaselineVersion ||
+		(policy.TargetVersion != "" && status.TargetVersion != policy.TargetVersion) {
+		// The rollout has not started yet.
+		return policy.BaselineVersion
+	}
+	switch status.Phase {
+	case corev1beta1.RolloutCompleted:
+		return status.TargetVersion
+	case corev1beta1.RolloutRolledBack:
+		return status.BaselineVersion
+	}
+	if slices.Contains(status.UpdatedNamespaces, namespace) {
+		return status.TargetVersion
+	}
+	return status.BaselineVersion
+}
+
+// VersionResolver resolves the version of each namespace from the rollout of the ConfigConnector object.
+type VersionResolver struct {
+	client client.Client
+}
+
+var _ manifest.NamespaceVersionResolver = &VersionResolver{}
+
+func NewVersionResolver(client client.Client) *VersionResolver {
+	return &VersionResolver{client: client}
+}
+
+func (r *VersionResolver) ResolveNamespaceVersion(ctx context.Context, namespace string) (string, error) {
+	cc, err := controllers.GetConfigConnector(ctx, r.client, controllers.ValidConfigConnectorNamespacedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting the ConfigConnector object: %w", err)
+	}
+	return VersionForNamespace(cc, namespace), nil
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/resourcehealth"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlan(t *testing.T) {
+	namespaces := map[string]labels.Set{
+		"a": {"env": "dev"},
+		"b": {"env": "dev"},
+		"c": {"env": "prod"},
+		"d": {"env": "prod"},
+		"e": {"env": "prod"},
+		"f": {"env": "prod"},
+	}
+	tests := []struct {
+		name   string
+		policy *corev1beta1.RolloutPolicy
+		want   [][]string
+	}{
+		{
+			name:   "no canaries or batches",
+			policy: &corev1beta1.RolloutPolicy{},
+			want:   [][]string{nil, {"a", "b", "c", "d", "e", "f"}},
+		},
+		{
+			name: "canaries, then batches by selector and percentage",
+			policy: &corev1beta1.RolloutPolicy{
+				CanaryNamespaces: []string{"c", "not-in-rollout"},
+				Batches: []corev1beta1.RolloutBatch{
+					{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+					{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}, Percentage: ptr.To[int32](50)},
+					{Percentage: ptr.To[int32](80)},
+				},
+			},
+			want: [][]string{{"c"}, {"a", "b"}, {"d"}, {"e"}, {"f"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Plan(tc.policy, namespaces)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("unexpected stages: %v", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.TODO()
+
+	policy := &corev1beta1.RolloutPolicy{
+		BaselineVersion:  "1.130.2",
+		TargetVersion:    "1.139.0",
+		CanaryNamespaces: []string{"canary"},
+		HealthGate: &corev1beta1.RolloutHealthGate{
+			SoakDuration: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	cc := &corev1beta1.ConfigConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1beta1.ConfigConnectorAllowedName},
+		Spec: corev1beta1.ConfigConnectorSpec{
+			Mode:          k8s.NamespacedMode,
+			RolloutPolicy: policy,
+		},
+	}
+
+	kubeClient := newFakeClient(t,
+		newNamespace("canary"), newCCC("canary", ""),
+		newNamespace("other"), newCCC("other", ""),
+		newNamespace("pinned"), newCCC("pinned", "1.126.0"),
+	)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewController(kubeClient, nil)
+	c.now = func() time.Time { return now }
+
+	// The rollout starts with the canary namespace.
+	requeueAfter, err := c.Reconcile(ctx, cc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requeueAfter != time.Minute {
+		t.Errorf("unexpected requeueAfter %v, want 1m", requeueAfter)
+	}
+	if got, want := cc.Status.Rollout.UpdatedNamespaces, []string{"canary"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected updated namespaces %v, want %v", got, want)
+	}
+	for ns, want := range map[string]string{"canary": "1.139.0", "other": "1.130.2"} {
+		if got := VersionForNamespace(cc, ns); got != want {
+			t.Errorf("unexpected version %q for namespace %v, want %q", got, ns, want)
+		}
+	}
+	stageStart, soakEnd, ok := StageOfNamespace(cc, "canary")
+	if !ok || !stageStart.Equal(now) || !soakEnd.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected stage of canary namespace (%v, %v, %v), want (%v, %v, true)", stageStart, soakEnd, ok, now, now.Add(time.Minute))
+	}
+	if _, _, ok := StageOfNamespace(cc, "other"); ok {
+		t.Errorf("unexpected stage of namespace other, which is not upgraded yet")
+	}
+
+	// Nothing happens until the soak duration passes.
+	now = now.Add(30 * time.Second)
+	if requeueAfter, err := c.Reconcile(ctx, cc); err != nil || requeueAfter != 30*time.Second {
+		t.Fatalf("unexpected result (%v, %v), want (30s, nil)", requeueAfter, err)
+	}
+	if got := cc.Status.Rollout.Stage; got != 0 {
+		t.Errorf("unexpected stage %v, want 0", got)
+	}
+	if err := kubeClient.Create(ctx, newManagerPod("canary", "1.139.0", true)); err != nil {
+		t.Fatalf("error creating pod: %v", err)
+	}
+
+	// The rollout waits for the health of the canary namespace to be refreshed after the soak.
+	setHealth(ctx, t, kubeClient, "canary", now, 1, 0)
+	now = now.Add(30 * time.Second)
+	if requeueAfter, err := c.Reconcile(ctx, cc); err != nil || requeueAfter != stageHealthPollPeriod {
+		t.Fatalf("unexpected result (%v, %v), want (%v, nil)", requeueAfter, err, stageHealthPollPeriod)
+	}
+	if got := cc.Status.Rollout.Stage; got != 0 {
+		t.Errorf("unexpected stage %v, want 0", got)
+	}
+
+	// A healthy canary lets the rollout continue.
+	setHealth(ctx, t, kubeClient, "canary", now, 1, 0)
+	if _, err := c.Reconcile(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := cc.Status.Rollout.UpdatedNamespaces, []string{"canary", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected updated namespaces %v, want %v", got, want)
+	}
+
+	// A regression rolls back all namespaces.
+	if err := kubeClient.Create(ctx, newManagerPod("other", "1.139.0", true)); err != nil {
+		t.Fatalf("error creating pod: %v", err)
+	}
+	now = now.Add(time.Minute)
+	setHealth(ctx, t, kubeClient, "canary", now, 1, 0)
+	setHealth(ctx, t, kubeClient, "other", now, 0, 1)
+	if requeueAfter, err := c.Reconcile(ctx, cc); err != nil || requeueAfter != 0 {
+		t.Fatalf("unexpected result (%v, %v), want (0, nil)", requeueAfter, err)
+	}
+	if got, want := cc.Status.Rollout.Phase, corev1beta1.RolloutRolledBack; got != want {
+		t.Errorf("unexpected phase %v, want %v", got, want)
+	}
+	for _, ns := range []string{"canary", "other"} {
+		if got, want := VersionForNamespace(cc, ns), "1.130.2"; got != want {
+			t.Errorf("unexpected version %q for namespace %v, want %q", got, ns, want)
+		}
+	}
+	if _, _, ok := StageOfNamespace(cc, "canary"); ok {
+		t.Errorf("unexpected stage of canary namespace after the rollback")
+	}
+
+	// Changing the target version starts a new rollout, whose baseline is measured from all the resources.
+	policy.TargetVersion = "1.140.0"
+	if got, want := VersionForNamespace(cc, "canary"), "1.130.2"; got != want {
+		t.Errorf("unexpected version %q before the new rollout starts, want %q", got, want)
+	}
+	if _, err := c.Reconcile(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := cc.Status.Rollout.Phase, corev1beta1.RolloutProgressing; got != want {
+		t.Errorf("unexpected phase %v, want %v", got, want)
+	}
+	if got, want := VersionForNamespace(cc, "canary"), "1.140.0"; got != want {
+		t.Errorf("unexpected version %q for canary namespace, want %q", got, want)
+	}
+	if got, want := ptr.Deref(cc.Status.Rollout.BaselineUpToDatePercent, 0), int32(50); got != want {
+		t.Errorf("unexpected baseline up-to-date percent %v, want %v", got, want)
+	}
+}
+
+func TestReconcileManagersNotReady(t *testing.T) {
+	ctx := context.TODO()
+
+	cc := &corev1beta1.ConfigConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1beta1.ConfigConnectorAllowedName},
+		Spec: corev1beta1.ConfigConnectorSpec{
+			Mode: k8s.NamespacedMode,
+			RolloutPolicy: &corev1beta1.RolloutPolicy{
+				BaselineVersion:  "1.130.2",
+				TargetVersion:    "1.139.0",
+				CanaryNamespaces: []string{"canary"},
+				HealthGate: &corev1beta1.RolloutHealthGate{
+					SoakDuration: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+	kubeClient := newFakeClient(t,
+		newNamespace("canary"), newCCC("canary", ""),
+		newNamespace("other"), newCCC("other", ""),
+		// The manager pod of the canary namespace is still running the baseline version.
+		newManagerPod("canary", "1.130.2", true),
+	)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewController(kubeClient, nil)
+	c.now = func() time.Time { return now }
+
+	if _, err := c.Reconcile(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(time.Minute)
+	setHealth(ctx, t, kubeClient, "canary", now, 1, 0)
+	if requeueAfter, err := c.Reconcile(ctx, cc); err != nil || requeueAfter != 0 {
+		t.Fatalf("unexpected result (%v, %v), want (0, nil)", requeueAfter, err)
+	}
+	if got, want := cc.Status.Rollout.Phase, corev1beta1.RolloutRolledBack; got != want {
+		t.Errorf("unexpected phase %v, want %v", got, want)
+	}
+	if got, want := VersionForNamespace(cc, "canary"), "1.130.2"; got != want {
+		t.Errorf("unexpected version %q for canary namespace, want %q", got, want)
+	}
+}
+
+func TestReconcileSteadyResources(t *testing.T) {
+	ctx := context.TODO()
+
+	cc := &corev1beta1.ConfigConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1beta1.ConfigConnectorAllowedName},
+		Spec: corev1beta1.ConfigConnectorSpec{
+			Mode: k8s.NamespacedMode,
+			RolloutPolicy: &corev1beta1.RolloutPolicy{
+				BaselineVersion:  "1.130.2",
+				TargetVersion:    "1.139.0",
+				CanaryNamespaces: []string{"canary"},
+				HealthGate: &corev1beta1.RolloutHealthGate{
+					SoakDuration: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+	kubeClient := newFakeClient(t,
+		newNamespace("canary"), newCCC("canary", ""),
+		newNamespace("other"), newCCC("other", ""),
+		newManagerPod("canary", "1.139.0", true),
+	)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewController(kubeClient, nil)
+	c.now = func() time.Time { return now }
+
+	if _, err := c.Reconcile(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The new version keeps the resource ready, so its Ready condition has not changed since before the stage started.
+	topic := &unstructured.Unstructured{}
+	topic.SetAPIVersion("pubsub.cnrm.cloud.google.com/v1beta1")
+	topic.SetKind("PubSubTopic")
+	topic.SetNamespace("canary")
+	topic.SetName("steady")
+	topic.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{
+			"type":               "Ready",
+			"status":             "True",
+			"reason":             "UpToDate",
+			"lastTransitionTime": now.Add(-24 * time.Hour).Format(time.RFC3339),
+		}},
+	}
+	summary := resourcehealth.NewSummary()
+	summary.Add(topic)
+	now = now.Add(time.Minute)
+	setResourceHealth(ctx, t, kubeClient, "canary", summary.Status(now))
+
+	if _, err := c.Reconcile(ctx, cc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := cc.Status.Rollout.Phase, corev1beta1.RolloutProgressing; got != want {
+		t.Errorf("unexpected phase %v (%v), want %v", got, cc.Status.Rollout.Message, want)
+	}
+	if got, want := cc.Status.Rollout.UpdatedNamespaces, []string{"canary", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected updated namespaces %v, want %v", got, want)
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, corev1beta1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("error building scheme: %v", err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func newCCC(namespace, version string) *corev1beta1.ConfigConnectorContext {
+	return &corev1beta1.ConfigConnectorContext{
+		ObjectMeta: metav1.ObjectMeta{Name: corev1beta1.ConfigConnectorContextAllowedName, Namespace: namespace},
+		Spec:       corev1beta1.ConfigConnectorContextSpec{Version: version},
+	}
+}
+
+// setHealth records in the status of the ConfigConnectorContext of the namespace that, of its PubSubTopics,
+// upToDate are up to date and failed failed to update.
+func setHealth(ctx context.Context, t *testing.T, kubeClient client.Client, namespace string, refreshed time.Time, upToDate, failed int32) {
+	setResourceHealth(ctx, t, kubeClient, namespace, &corev1beta1.ResourceHealth{
+		LastRefreshTime: &metav1.Time{Time: refreshed},
+		Kinds:           []corev1beta1.KindHealth{{Kind: "PubSubTopic", Total: upToDate + failed, UpToDate: upToDate, UpdateFailed: failed}},
+	})
+}
+
+func setResourceHealth(ctx context.Context, t *testing.T, kubeClient client.Client, namespace string, health *corev1beta1.ResourceHealth) {
+	ccc := &corev1beta1.ConfigConnectorContext{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: corev1beta1.ConfigConnectorContextAllowedName}, ccc); err != nil {
+		t.Fatalf("error getting ConfigConnectorContext: %v", err)
+	}
+	ccc.Status.ResourceHealth = health
+	if err := kubeClient.Update(ctx, ccc); err != nil {
+		t.Fatalf("error updating ConfigConnectorContext status: %v", err)
+	}
+}
+
+func newManagerPod(namespace, version string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cnrm-controller-manager-" + namespace + "-0",
+			Namespace: k8s.CNRMSystemNamespace,
+			Labels: map[string]string{
+				k8s.KCCSystemComponentLabel:  k8s.KCCControllerManagerComponent,
+				k8s.NamespacedComponentLabel: namespace,
+			},
+			Annotations: map[string]string{k8s.VersionAnnotation: version},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
@@ -36,8 +36,16 @@ import (
 // folders/folderNumber
 // organizations/orgNumber
 func ForParentToStorageObject(ctx context.Context, httpClient *http.Client, parent, bucketName, objectName string) error {
+	return ForParentToStorageObjectWithContentType(ctx, httpClient, parent, bucketName, objectName, "")
+}
+
+// ForParentToStorageObjectWithContentType is the same as ForParentToStorageObject but allows for the asset inventory
+// content type to be specified, i.e. 'RESOURCE' to include the resource's location and data (such as labels) in the export.
+// An empty contentType results in the default, metadata-only, export.
+func ForParentToStorageObjectWithContentType(ctx context.Context, httpClient *http.Client, parent, bucketName, objectName, contentType string) error {
 	gcsDestination := fmt.Sprintf("gs://%v/%v", bucketName, objectName)
 	exportAssetsRequest := cloudasset.ExportAssetsRequest{
+		ContentType: contentType,
 		OutputConfig: &cloudasset.OutputConfig{
 			GcsDestination: &cloudasset.GcsDestination{
 				Uri: gcsDestination,
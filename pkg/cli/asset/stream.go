@@ -29,9 +29,18 @@ import (
 )
 
 type Asset struct {
-	Name      string
-	AssetType string `json:"asset_type,omitempty"`
-	Ancestors []string
+	Name       string
+	AssetType  string `json:"asset_type,omitempty"`
+	Ancestors  []string
+	UpdateTime string    `json:"update_time,omitempty"`
+	Resource   *Resource `json:"resource,omitempty"`
+}
+
+// Resource is the subset of the asset inventory resource representation that is used for filtering, it is only
+// populated when the export was performed with the 'RESOURCE' content type.
+type Resource struct {
+	Location string                 `json:"location,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
 }
 
 type Stream struct {
@@ -68,6 +68,26 @@ func init() {
 	bulkExportCmd.Flags().IntVar(&bulkExportParams.FolderID, parameters.FolderIDParam, 0, folderUsage)
 	organizationUsage := fmt.Sprintf("an optional organization id for which a cloud asset inventory will be exported to a temporary bucket; use the '%v' parameter to avoid the creation of a temporary bucket", parameters.StorageKeyParam)
 	bulkExportCmd.Flags().IntVar(&bulkExportParams.OrganizationID, parameters.OrganizationIDParam, 0, organizationUsage)
+	includeKindsUsage := "an optional comma-separated list of kinds to export, each value may be a kind or a kind and group, example: 'PubSubTopic,*.iam.cnrm.cloud.google.com'"
+	bulkExportCmd.Flags().StringSliceVar(&bulkExportParams.IncludeKinds, parameters.IncludeKindsParam, nil, includeKindsUsage)
+	excludeKindsUsage := "an optional comma-separated list of kinds to skip, each value may be a kind or a kind and group, example: 'IAMServiceAccountKey'"
+	bulkExportCmd.Flags().StringSliceVar(&bulkExportParams.ExcludeKinds, parameters.ExcludeKindsParam, nil, excludeKindsUsage)
+	labelSelectorUsage := "an optional label selector matched against the GCP labels of each resource, example: 'team=data,env!=dev'; requires an asset inventory export with the 'RESOURCE' content type"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.LabelSelector, parameters.LabelSelectorParam, "", labelSelectorUsage)
+	locationsUsage := "an optional comma-separated list of locations to export, zonal resources match their region, example: 'europe-west1,global'"
+	bulkExportCmd.Flags().StringSliceVar(&bulkExportParams.Locations, parameters.LocationsParam, nil, locationsUsage)
+	namePatternUsage := "an optional regular expression, only resources whose asset name matches are exported"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.NamePattern, parameters.NamePatternParam, "", namePatternUsage)
+	excludeNameUsage := "an optional regular expression, resources whose asset name matches are skipped"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.ExcludeNamePattern, parameters.ExcludeNameParam, "", excludeNameUsage)
+	sinceUsage := "an optional RFC 3339 timestamp or duration, only resources updated after it are exported, example: '2024-01-01T00:00:00Z' or '24h'"
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.Since, parameters.SinceParam, "", sinceUsage)
+	layoutUsage := fmt.Sprintf("control the layout of an output directory, options are '%v' or '%v'; '%v' organizes resources by project or namespace and then by service with a kustomization.yaml at each level", parameters.FlatLayoutOption, parameters.GitOpsLayoutOption, parameters.GitOpsLayoutOption)
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.Layout, parameters.LayoutParam, parameters.FlatLayoutOption, layoutUsage)
+	targetNamespaceUsage := fmt.Sprintf("an optional namespace to set on every resource, resources are organized by namespace instead of project; requires '%v=%v'", parameters.LayoutParam, parameters.GitOpsLayoutOption)
+	bulkExportCmd.Flags().StringVar(&bulkExportParams.TargetNamespace, parameters.TargetNamespaceParam, "", targetNamespaceUsage)
+	stripOutputOnlyUsage := fmt.Sprintf("remove status and server-populated metadata fields from every resource; requires '%v=%v'", parameters.LayoutParam, parameters.GitOpsLayoutOption)
+	bulkExportCmd.Flags().BoolVar(&bulkExportParams.StripOutputOnly, parameters.StripOutputOnlyParam, false, stripOutputOnlyUsage)
 }
 
 func fillRootFlagsOnBulkExportParams(params *parameters.Parameters) {
@@ -63,7 +63,7 @@ func Execute(ctx context.Context, params *parameters.Parameters) error {
 		return err
 	}
 	recoverableStream := stream.NewRecoverableByteStream(yamlStream)
-	outputSink, err := outputsink.New(tfProvider, params.Output, outputsink.ResourceFormat(params.ResourceFormat))
+	outputSink, err := newOutputSink(params, tfProvider)
 	if err != nil {
 		return err
 	}
@@ -81,7 +81,19 @@ func Execute(ctx context.Context, params *parameters.Parameters) error {
 			}
 		}
 	}
-	return nil
+	// the deferred Close only covers the error paths, the sink may still have output to flush or write
+	return outputSink.Close()
+}
+
+func newOutputSink(params *parameters.Parameters, tfProvider *schema.Provider) (outputsink.OutputSink, error) {
+	if params.Layout == parameters.GitOpsLayoutOption {
+		options := outputsink.GitOpsOptions{
+			Namespace:       params.TargetNamespace,
+			StripOutputOnly: params.StripOutputOnly,
+		}
+		return outputsink.NewGitOpsDirectory(params.Output, options)
+	}
+	return outputsink.New(tfProvider, params.Output, outputsink.ResourceFormat(params.ResourceFormat))
 }
 
 func newFilteredAssetStream(ctx context.Context, params *parameters.Parameters, tfProvider *schema.Provider) (stream.AssetStream, error) {
@@ -93,5 +105,5 @@ func newFilteredAssetStream(ctx context.Context, params *parameters.Parameters,
 	if err != nil {
 		return nil, err
 	}
-	return filteredinputstream.NewFilteredAssetStream(ctx, assetStream, tfProvider, config)
+	return filteredinputstream.NewFilteredAssetStream(ctx, params, assetStream, tfProvider, config)
 }
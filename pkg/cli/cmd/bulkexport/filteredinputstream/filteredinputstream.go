@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/log"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/stream"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/config"
@@ -71,12 +73,20 @@ func isDefaultNetworkingAsset(a *asset.Asset) bool {
 	return false
 }
 
-func NewFilteredAssetStream(ctx context.Context, assetStream *asset.Stream, tfProvider *schema.Provider, config *config.ControllerConfig) (stream.AssetStream, error) {
+func NewFilteredAssetStream(ctx context.Context, params *parameters.Parameters, assetStream *asset.Stream, tfProvider *schema.Provider, config *config.ControllerConfig) (stream.AssetStream, error) {
 	smLoader, err := servicemappingloader.New()
 	if err != nil {
 		return nil, fmt.Errorf("error loading service mappings: %w", err)
 	}
+	userFilters, err := newAssetFilters(params, time.Now())
+	if err != nil {
+		return nil, err
+	}
 	filter := func(a *asset.Asset) bool {
+		if !userFilters.matchesMetadata(ctx, a) {
+			log.Verbose("skipping asset excluded by filters: %v/%v", a.AssetType, a.Name)
+			return false
+		}
 		if !isAssetSupported(ctx, smLoader, tfProvider, config, a) {
 			log.Verbose("skipping unsupported asset: %v", a.AssetType)
 			return false
@@ -85,6 +95,17 @@ func NewFilteredAssetStream(ctx context.Context, assetStream *asset.Stream, tfPr
 			log.Verbose("skipping default asset, as it cannot be normally acquired or imported: %v/%v", a.AssetType, a.Name)
 			return false
 		}
+		if userFilters.hasKindFilters() {
+			gk, err := getAssetGroupKind(ctx, smLoader, a)
+			if err != nil {
+				log.Verbose("skipping asset with unknown kind: %v/%v: %v", a.AssetType, a.Name, err)
+				return false
+			}
+			if !userFilters.matchesKind(gk) {
+				log.Verbose("skipping asset of kind %v excluded by filters: %v", gk, a.Name)
+				return false
+			}
+		}
 		return true
 	}
 	return stream.NewFilteredAssetStream(assetStream, filter), nil
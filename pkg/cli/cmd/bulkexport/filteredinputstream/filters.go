@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filteredinputstream
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+
+	"k8s.io/apimachinery/pkg/labels"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+const (
+	globalLocation = "global"
+	anyKind        = "*"
+)
+
+var (
+	locationInNameRegex = regexp.MustCompile("/(?:locations|regions|zones)/([^/]+)")
+	zoneRegex           = regexp.MustCompile("^([a-z]+-[a-z]+[0-9]+)-[a-z]$")
+)
+
+// assetFilters is the set of user supplied filters that restrict which assets are exported. The filters only
+// rely on the contents of the asset inventory so they are applied before any resource is fetched from GCP.
+type assetFilters struct {
+	includeKinds  []k8sschema.GroupKind
+	excludeKinds  []k8sschema.GroupKind
+	labelSelector labels.Selector
+	locations     []string
+	namePattern   *regexp.Regexp
+	excludeName   *regexp.Regexp
+	since         time.Time
+}
+
+func newAssetFilters(params *parameters.Parameters, now time.Time) (*assetFilters, error) {
+	f := assetFilters{
+		includeKinds: parseGroupKinds(params.IncludeKinds),
+		excludeKinds: parseGroupKinds(params.ExcludeKinds),
+		locations:    params.Locations,
+	}
+	var err error
+	if f.labelSelector, err = labels.Parse(params.LabelSelector); err != nil {
+		return nil, fmt.Errorf("error parsing '%v' value of '%v': %w", parameters.LabelSelectorParam, params.LabelSelector, err)
+	}
+	if params.NamePattern != "" {
+		if f.namePattern, err = regexp.Compile(params.NamePattern); err != nil {
+			return nil, fmt.Errorf("error compiling '%v' value of '%v': %w", parameters.NamePatternParam, params.NamePattern, err)
+		}
+	}
+	if params.ExcludeNamePattern != "" {
+		if f.excludeName, err = regexp.Compile(params.ExcludeNamePattern); err != nil {
+			return nil, fmt.Errorf("error compiling '%v' value of '%v': %w", parameters.ExcludeNameParam, params.ExcludeNamePattern, err)
+		}
+	}
+	if f.since, err = parameters.ParseSince(params.Since, now); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// parseGroupKinds converts values of the form 'Kind' or 'Kind.group' to GroupKinds, a kind of '*' matches every kind
+// in the group, i.e. '*.pubsub.cnrm.cloud.google.com'.
+func parseGroupKinds(values []string) []k8sschema.GroupKind {
+	var results []k8sschema.GroupKind
+	for _, v := range values {
+		results = append(results, k8sschema.ParseGroupKind(strings.TrimSpace(v)))
+	}
+	return results
+}
+
+func (f *assetFilters) hasKindFilters() bool {
+	return len(f.includeKinds) > 0 || len(f.excludeKinds) > 0
+}
+
+// matchesMetadata returns true if the asset matches all the filters that can be evaluated without resolving the asset's
+// kind.
+func (f *assetFilters) matchesMetadata(ctx context.Context, a *asset.Asset) bool {
+	log := klog.FromContext(ctx)
+
+	if f.namePattern != nil && !f.namePattern.MatchString(a.Name) {
+		return false
+	}
+	if f.excludeName != nil && f.excludeName.MatchString(a.Name) {
+		return false
+	}
+	if len(f.locations) > 0 && !matchesLocation(getAssetLocation(a), f.locations) {
+		return false
+	}
+	if !f.labelSelector.Empty() && !f.labelSelector.Matches(getAssetLabels(a)) {
+		return false
+	}
+	if !f.since.IsZero() {
+		if a.UpdateTime == "" {
+			log.V(2).Info("skipping asset without an update time", "name", a.Name)
+			return false
+		}
+		updateTime, err := time.Parse(time.RFC3339Nano, a.UpdateTime)
+		if err != nil {
+			log.Error(err, "unable to parse asset update time", "name", a.Name, "updateTime", a.UpdateTime)
+			return false
+		}
+		if updateTime.Before(f.since) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesKind returns true if the asset's GroupKind passes the include and exclude kind filters.
+func (f *assetFilters) matchesKind(gk k8sschema.GroupKind) bool {
+	if len(f.includeKinds) > 0 && !groupKindInList(gk, f.includeKinds) {
+		return false
+	}
+	return !groupKindInList(gk, f.excludeKinds)
+}
+
+func groupKindInList(gk k8sschema.GroupKind, list []k8sschema.GroupKind) bool {
+	for _, candidate := range list {
+		if candidate.Group != "" && !strings.EqualFold(candidate.Group, gk.Group) {
+			continue
+		}
+		if candidate.Kind == anyKind || strings.EqualFold(candidate.Kind, gk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAssetGroupKind returns the Config Connector GroupKind that the asset will be exported as.
+func getAssetGroupKind(ctx context.Context, smLoader *servicemappingloader.ServiceMappingLoader, a *asset.Asset) (k8sschema.GroupKind, error) {
+	gk, found, err := direct.ExportGroupKind(ctx, a.Name)
+	if err != nil {
+		return k8sschema.GroupKind{}, fmt.Errorf("error checking if resource is direct-implemented: %w", err)
+	}
+	if found {
+		return gk, nil
+	}
+	sm, rc, err := asset.GetServiceMappingAndResourceConfig(smLoader, a)
+	if err != nil {
+		return k8sschema.GroupKind{}, err
+	}
+	return k8sschema.GroupKind{Group: sm.Name, Kind: rc.Kind}, nil
+}
+
+// getAssetLocation returns the asset's location, preferring the location in the resource content and falling back to
+// the location embedded in the asset's name.
+func getAssetLocation(a *asset.Asset) string {
+	if a.Resource != nil && a.Resource.Location != "" {
+		return a.Resource.Location
+	}
+	if matches := locationInNameRegex.FindStringSubmatch(a.Name); matches != nil {
+		return matches[1]
+	}
+	return globalLocation
+}
+
+// matchesLocation returns true if the location is one of the filter locations, a zonal location also matches its region.
+func matchesLocation(location string, filterLocations []string) bool {
+	region := location
+	if matches := zoneRegex.FindStringSubmatch(location); matches != nil {
+		region = matches[1]
+	}
+	for _, l := range filterLocations {
+		if strings.EqualFold(l, location) || strings.EqualFold(l, region) {
+			return true
+		}
+	}
+	return false
+}
+
+func getAssetLabels(a *asset.Asset) labels.Set {
+	result := labels.Set{}
+	if a.Resource == nil {
+		return result
+	}
+	rawLabels, ok := a.Resource.Data["labels"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+	for k, v := range rawLabels {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		}
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filteredinputstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/bulkexport/parameters"
+
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAssetFiltersMatchesMetadata(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	topic := &asset.Asset{
+		Name:       "//pubsub.googleapis.com/projects/my-project/topics/orders",
+		AssetType:  "pubsub.googleapis.com/Topic",
+		UpdateTime: "2024-05-31T12:00:00.123Z",
+		Resource: &asset.Resource{
+			Data: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"team": "data",
+				},
+			},
+		},
+	}
+	disk := &asset.Asset{
+		Name:       "//compute.googleapis.com/projects/my-project/zones/europe-west1-b/disks/my-disk",
+		AssetType:  "compute.googleapis.com/Disk",
+		UpdateTime: "2024-01-01T00:00:00Z",
+	}
+	subnet := &asset.Asset{
+		Name:      "//compute.googleapis.com/projects/my-project/regions/us-central1/subnetworks/my-subnet",
+		AssetType: "compute.googleapis.com/Subnetwork",
+	}
+	testCases := []struct {
+		Name     string
+		Params   parameters.Parameters
+		Asset    *asset.Asset
+		Expected bool
+	}{
+		{
+			Name:     "no filters",
+			Asset:    subnet,
+			Expected: true,
+		},
+		{
+			Name:     "matching name pattern",
+			Params:   parameters.Parameters{NamePattern: "/topics/ord.*"},
+			Asset:    topic,
+			Expected: true,
+		},
+		{
+			Name:     "non-matching name pattern",
+			Params:   parameters.Parameters{NamePattern: "/topics/payments$"},
+			Asset:    topic,
+			Expected: false,
+		},
+		{
+			Name:     "matching exclude name pattern",
+			Params:   parameters.Parameters{ExcludeNamePattern: "my-subnet"},
+			Asset:    subnet,
+			Expected: false,
+		},
+		{
+			Name:     "zonal resource matches region",
+			Params:   parameters.Parameters{Locations: []string{"europe-west1"}},
+			Asset:    disk,
+			Expected: true,
+		},
+		{
+			Name:     "regional resource in other location",
+			Params:   parameters.Parameters{Locations: []string{"europe-west1"}},
+			Asset:    subnet,
+			Expected: false,
+		},
+		{
+			Name:     "resource without location in name is global",
+			Params:   parameters.Parameters{Locations: []string{"global"}},
+			Asset:    topic,
+			Expected: true,
+		},
+		{
+			Name:     "matching label selector",
+			Params:   parameters.Parameters{LabelSelector: "team=data"},
+			Asset:    topic,
+			Expected: true,
+		},
+		{
+			Name:     "asset without labels does not match selector",
+			Params:   parameters.Parameters{LabelSelector: "team=data"},
+			Asset:    disk,
+			Expected: false,
+		},
+		{
+			Name:     "updated after since",
+			Params:   parameters.Parameters{Since: "24h"},
+			Asset:    topic,
+			Expected: true,
+		},
+		{
+			Name:     "updated before since",
+			Params:   parameters.Parameters{Since: "2024-03-01T00:00:00Z"},
+			Asset:    disk,
+			Expected: false,
+		},
+		{
+			Name:     "missing update time with since",
+			Params:   parameters.Parameters{Since: "24h"},
+			Asset:    subnet,
+			Expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filters, err := newAssetFilters(&tc.Params, now)
+			if err != nil {
+				t.Fatalf("error creating filters: %v", err)
+			}
+			if got := filters.matchesMetadata(context.TODO(), tc.Asset); got != tc.Expected {
+				t.Errorf("matchesMetadata(%v) = %v, want %v", tc.Asset.Name, got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestAssetFiltersMatchesKind(t *testing.T) {
+	topic := k8sschema.GroupKind{Group: "pubsub.cnrm.cloud.google.com", Kind: "PubSubTopic"}
+	serviceAccount := k8sschema.GroupKind{Group: "iam.cnrm.cloud.google.com", Kind: "IAMServiceAccount"}
+	testCases := []struct {
+		Name         string
+		IncludeKinds []string
+		ExcludeKinds []string
+		GroupKind    k8sschema.GroupKind
+		Expected     bool
+	}{
+		{
+			Name:      "no kind filters",
+			GroupKind: topic,
+			Expected:  true,
+		},
+		{
+			Name:         "included by kind",
+			IncludeKinds: []string{"PubSubTopic"},
+			GroupKind:    topic,
+			Expected:     true,
+		},
+		{
+			Name:         "included by group kind",
+			IncludeKinds: []string{"PubSubTopic.pubsub.cnrm.cloud.google.com"},
+			GroupKind:    topic,
+			Expected:     true,
+		},
+		{
+			Name:         "included by group wildcard",
+			IncludeKinds: []string{"PubSubTopic", "*.iam.cnrm.cloud.google.com"},
+			GroupKind:    serviceAccount,
+			Expected:     true,
+		},
+		{
+			Name:         "not in include list",
+			IncludeKinds: []string{"PubSubTopic"},
+			GroupKind:    serviceAccount,
+			Expected:     false,
+		},
+		{
+			Name:         "included but excluded",
+			IncludeKinds: []string{"*.iam.cnrm.cloud.google.com"},
+			ExcludeKinds: []string{"IAMServiceAccount"},
+			GroupKind:    serviceAccount,
+			Expected:     false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			params := parameters.Parameters{IncludeKinds: tc.IncludeKinds, ExcludeKinds: tc.ExcludeKinds}
+			filters, err := newAssetFilters(&params, time.Now())
+			if err != nil {
+				t.Fatalf("error creating filters: %v", err)
+			}
+			if got := filters.matchesKind(tc.GroupKind); got != tc.Expected {
+				t.Errorf("matchesKind(%v) = %v, want %v", tc.GroupKind, got, tc.Expected)
+			}
+		})
+	}
+}
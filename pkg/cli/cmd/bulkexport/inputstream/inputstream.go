@@ -29,6 +29,8 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/storage"
 )
 
+const resourceContentType = "RESOURCE"
+
 var (
 	requestTimeout = 10 * time.Second
 	exportTimeout  = 2 * time.Minute
@@ -82,7 +84,11 @@ func newStorageObjectStream(params *parameters.Parameters) (*asset.Stream, error
 	exportCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
 	defer cancel()
 	log.Verbose("Creating asset inventory export at %v", storage.GetFullURI(bucketName, objectName))
-	if err := export.ForParentToStorageObject(exportCtx, httpClient, parent, bucketName, objectName); err != nil {
+	contentType := ""
+	if params.NeedsResourceContent() {
+		contentType = resourceContentType
+	}
+	if err := export.ForParentToStorageObjectWithContentType(exportCtx, httpClient, parent, bucketName, objectName, contentType); err != nil {
 		return nil, fmt.Errorf("error exporting asset inventory: %w", err)
 	}
 	defer deleteExport(httpClient, bucketName, objectName)
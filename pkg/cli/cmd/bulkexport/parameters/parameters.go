@@ -20,24 +20,41 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/commonparams"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/storage"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/config"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/util/valutil"
 	"golang.org/x/oauth2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type OnErrorOption string
 type IAMFormatOption string
 
 const (
-	InputParam          = "input"
-	OnErrorParam        = "on-error"
-	StorageKeyParam     = "storage-key"
-	ProjectIDParam      = "project"
-	FolderIDParam       = "folder"
-	OrganizationIDParam = "organization"
+	InputParam           = "input"
+	OnErrorParam         = "on-error"
+	StorageKeyParam      = "storage-key"
+	ProjectIDParam       = "project"
+	FolderIDParam        = "folder"
+	OrganizationIDParam  = "organization"
+	IncludeKindsParam    = "include-kinds"
+	ExcludeKindsParam    = "exclude-kinds"
+	LabelSelectorParam   = "label-selector"
+	LocationsParam       = "locations"
+	NamePatternParam     = "name-pattern"
+	ExcludeNameParam     = "exclude-name-pattern"
+	SinceParam           = "since"
+	LayoutParam          = "layout"
+	TargetNamespaceParam = "target-namespace"
+	StripOutputOnlyParam = "strip-output-only"
+
+	FlatLayoutOption   = "flat"
+	GitOpsLayoutOption = "gitops"
 
 	ContinueOnErrorOption = "continue"
 	HaltOnErrorOption     = "halt"
@@ -65,6 +82,26 @@ type Parameters struct {
 	OAuth2Token             string
 	ResourceFormat          string
 	Verbose                 bool
+
+	// Filters applied to the asset inventory before any resources are fetched.
+	IncludeKinds       []string
+	ExcludeKinds       []string
+	LabelSelector      string
+	Locations          []string
+	NamePattern        string
+	ExcludeNamePattern string
+	Since              string
+
+	// Options for the layout of the output directory.
+	Layout          string
+	TargetNamespace string
+	StripOutputOnly bool
+}
+
+// NeedsResourceContent returns true if the supplied filters require the asset inventory export to contain the
+// resource data, i.e. labels and locations, which are only present in exports with the 'RESOURCE' content type.
+func (p *Parameters) NeedsResourceContent() bool {
+	return p.LabelSelector != "" || len(p.Locations) > 0
 }
 
 func (p *Parameters) NewControllerConfig(ctx context.Context) (*config.ControllerConfig, error) {
@@ -118,6 +155,15 @@ func Validate(p *Parameters, stdin *os.File) error {
 	if err := commonparams.ValidateResourceFormat(p.ResourceFormat, p.IAMFormat); err != nil {
 		return err
 	}
+	if err := validateFilters(p); err != nil {
+		return err
+	}
+	if err := validateLabelSelectorInput(p, stdin); err != nil {
+		return err
+	}
+	if err := validateLayout(p); err != nil {
+		return err
+	}
 
 	return validateOneInput(p, stdin)
 }
@@ -191,6 +237,108 @@ func validateStorageKey(p *Parameters) error {
 	return validateCanExport(p)
 }
 
+func validateFilters(p *Parameters) error {
+	for _, kindParam := range []struct {
+		Name  string
+		Kinds []string
+	}{
+		{Name: IncludeKindsParam, Kinds: p.IncludeKinds},
+		{Name: ExcludeKindsParam, Kinds: p.ExcludeKinds},
+	} {
+		for _, k := range kindParam.Kinds {
+			if strings.TrimSpace(k) == "" {
+				return fmt.Errorf("invalid %v value: kinds must not be empty", kindParam.Name)
+			}
+		}
+	}
+	if _, err := labels.Parse(p.LabelSelector); err != nil {
+		return fmt.Errorf("invalid %v value of '%v': %w", LabelSelectorParam, p.LabelSelector, err)
+	}
+	if _, err := regexp.Compile(p.NamePattern); err != nil {
+		return fmt.Errorf("invalid %v value of '%v': %w", NamePatternParam, p.NamePattern, err)
+	}
+	if _, err := regexp.Compile(p.ExcludeNamePattern); err != nil {
+		return fmt.Errorf("invalid %v value of '%v': %w", ExcludeNameParam, p.ExcludeNamePattern, err)
+	}
+	if _, err := ParseSince(p.Since, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateLabelSelectorInput ensures that the label selector is only used when the asset inventory is exported by
+// this command, as an existing inventory may not contain the resource data that the labels are read from.
+func validateLabelSelectorInput(p *Parameters, stdin *os.File) error {
+	if p.LabelSelector == "" {
+		return nil
+	}
+	piped, err := IsInputPiped(stdin)
+	if err != nil {
+		return err
+	}
+	if piped {
+		return fmt.Errorf("the '%v' parameter cannot be used with an asset inventory on 'stdin'", LabelSelectorParam)
+	}
+	if !valutil.IsDefaultValue(p.Input) {
+		return fmt.Errorf("the '%v' parameter cannot be used with an asset inventory read from '%v'", LabelSelectorParam, InputParam)
+	}
+	if !valutil.IsDefaultValue(p.StorageKey) {
+		_, objectName, err := storage.GetBucketAndPrefix(p.StorageKey)
+		if err != nil {
+			return fmt.Errorf("error parsing '%v' value of '%v': %w", StorageKeyParam, p.StorageKey, err)
+		}
+		if objectName != "" {
+			return fmt.Errorf("the '%v' parameter cannot be used with an asset inventory read from '%v'", LabelSelectorParam, StorageKeyParam)
+		}
+	}
+	return nil
+}
+
+func validateLayout(p *Parameters) error {
+	switch p.Layout {
+	case "", FlatLayoutOption:
+		if p.TargetNamespace != "" || p.StripOutputOnly {
+			return fmt.Errorf("the '%v' and '%v' parameters can only be used with '%v=%v'",
+				TargetNamespaceParam, StripOutputOnlyParam, LayoutParam, GitOpsLayoutOption)
+		}
+		return nil
+	case GitOpsLayoutOption:
+		if p.Output == "" {
+			return fmt.Errorf("the '%v' parameter must be set to a directory when using '%v=%v'", commonparams.OutputParamName, LayoutParam, GitOpsLayoutOption)
+		}
+		if p.ResourceFormat != "" && p.ResourceFormat != commonparams.KRMResourceFormatOption {
+			return fmt.Errorf("'%v=%v' is only supported with the '%v' resource format", LayoutParam, GitOpsLayoutOption, commonparams.KRMResourceFormatOption)
+		}
+		if p.TargetNamespace != "" {
+			if errs := validation.IsDNS1123Label(p.TargetNamespace); len(errs) > 0 {
+				return fmt.Errorf("invalid %v value of '%v': %v", TargetNamespaceParam, p.TargetNamespace, strings.Join(errs, ", "))
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid %v value of '%v': must be one of {%v, %v}", LayoutParam, p.Layout, FlatLayoutOption, GitOpsLayoutOption)
+	}
+}
+
+// ParseSince converts the value of the 'since' parameter to a time, the value may either be an RFC 3339 timestamp or
+// a duration, i.e. '24h', which is relative to 'now'. An empty value results in the zero time.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %v value of '%v': must be an RFC 3339 timestamp or a duration such as '24h'", SinceParam, value)
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("invalid %v value of '%v': duration must not be negative", SinceParam, value)
+	}
+	return now.Add(-d), nil
+}
+
 func validateCanExport(p *Parameters) error {
 	if p.ProjectID == "" && p.FolderID == 0 && p.OrganizationID == 0 {
 		return fmt.Errorf("one of the '%v', '%v', or '%v' parameters must be defined to perform an export",
@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"os"
+	"testing"
+)
+
+func TestValidateLabelSelectorInput(t *testing.T) {
+	// a character device is not treated as piped input
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("error opening %v: %v", os.DevNull, err)
+	}
+	defer stdin.Close()
+
+	tests := []struct {
+		name      string
+		params    Parameters
+		expectErr bool
+	}{
+		{
+			name:   "no label selector",
+			params: Parameters{Input: "inventory.json"},
+		},
+		{
+			name:   "label selector with export",
+			params: Parameters{LabelSelector: "team=data", ProjectID: "my-project"},
+		},
+		{
+			name:   "label selector with export to storage prefix",
+			params: Parameters{LabelSelector: "team=data", ProjectID: "my-project", StorageKey: "gs://my-bucket"},
+		},
+		{
+			name:      "label selector with inventory from storage object",
+			params:    Parameters{LabelSelector: "team=data", StorageKey: "gs://my-bucket/inventory.json"},
+			expectErr: true,
+		},
+		{
+			name:      "label selector with inventory from input file",
+			params:    Parameters{LabelSelector: "team=data", Input: "inventory.json"},
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLabelSelectorInput(&tc.params, stdin)
+			if tc.expectErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/commonparams"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/preview"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/preview/parameters"
+	previewlib "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/preview"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	previewCommandName = "preview"
+)
+
+var (
+	previewParams = parameters.Parameters{}
+	previewLong   = fmt.Sprintf(`Preview the changes Config Connector would make to GCP for the resources in the cluster.
+
+All write requests to GCP and Kubernetes are blocked and recorded. The command exits with %d if no changes are planned,
+%d if changes are planned and %d if any resource failed to reconcile or was not reconciled before the timeout.
+
+With '--%v', the preview runs offline: an in-process cluster and mock GCP are seeded with exported resources,
+such as the output of bulk-export or a kompanion export tarball, and the resources in '--%v' are previewed against them.
+Only resources with a direct controller can be seeded, other resources are reported as unsupported.
+The Config Connector CRDs to install must be supplied with '--%v', no cluster is used.`,
+		previewlib.ExitCodeNoChanges, previewlib.ExitCodeChanges, previewlib.ExitCodeErrors, parameters.SeedParam, parameters.FilenameParam, parameters.CRDsParam)
+	previewCmd = &cobra.Command{
+		Use:   previewCommandName,
+		Short: "Preview the changes Config Connector would make to GCP",
+		Long:  previewLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parameters.Validate(&previewParams); err != nil {
+				return err
+			}
+			rootCmd.SilenceUsage = true
+			report, err := preview.Execute(cmd.Context(), &previewParams, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			switch code := report.ExitCode(); code {
+			case previewlib.ExitCodeNoChanges:
+				return nil
+			case previewlib.ExitCodeErrors:
+				return &ExitCodeError{Code: code, Err: fmt.Errorf("%d resources failed to reconcile and %d resources were not reconciled", report.Summary.Errors, report.Summary.NotReconciled)}
+			default:
+				return &ExitCodeError{Code: code}
+			}
+		},
+		Args: cobra.NoArgs,
+	}
+)
+
+func init() {
+	commonparams.AddOAuth2TokenParam(previewCmd, &previewParams.OAuth2Token)
+	previewCmd.Flags().StringVar(&previewParams.Kubeconfig, parameters.KubeconfigParam, "", "an optional path to the kubeconfig file, defaults to the standard kubeconfig loading rules")
+	previewCmd.Flags().DurationVar(&previewParams.Timeout, parameters.TimeoutParam, parameters.DefaultTimeout, "the maximum time to wait for all resources to be reconciled")
+	jsonReportUsage := fmt.Sprintf("an optional file path where the JSON report will be written, use '%v' for stdout", parameters.StdoutReportPath)
+	previewCmd.Flags().StringVar(&previewParams.JSONReport, parameters.JSONReportParam, "", jsonReportUsage)
+	markdownReportUsage := fmt.Sprintf("an optional file path where the Markdown report will be written, use '%v' for stdout (default: stdout if no report is specified)", parameters.StdoutReportPath)
+	previewCmd.Flags().StringVar(&previewParams.MarkdownReport, parameters.MarkdownReportParam, "", markdownReportUsage)
+	seedUsage := "an optional file, directory or .tar.gz of exported resources; if set, the preview runs offline against a mock GCP seeded with these resources"
+	previewCmd.Flags().StringVar(&previewParams.Seed, parameters.SeedParam, "", seedUsage)
+	filenameUsage := fmt.Sprintf("an optional file, directory or .tar.gz of the desired resources to preview against the '%v'", parameters.SeedParam)
+	previewCmd.Flags().StringVarP(&previewParams.Filename, parameters.FilenameParam, "f", "", filenameUsage)
+	crdsUsage := fmt.Sprintf("a file, directory or .tar.gz with the Config Connector CRDs to install, required with '%v'", parameters.SeedParam)
+	previewCmd.Flags().StringVar(&previewParams.CRDs, parameters.CRDsParam, "", crdsUsage)
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/cmd/preview/parameters"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/preview"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/preview/offline"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Execute runs KCC against the cluster, or against a seeded offline cluster and mock GCP, with all writes to GCP and kube blocked, and writes the resulting reports.
+// The returned report is nil if the preview could not be run.
+func Execute(ctx context.Context, params *parameters.Parameters, stdout io.Writer) (*preview.Report, error) {
+	log.SetLogger(klogr.New())
+
+	var options preview.PreviewInstanceOptions
+	var world *offline.World
+	if params.IsOffline() {
+		var err error
+		world, err = newOfflineWorld(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		defer world.Close()
+		options = preview.PreviewInstanceOptions{
+			UpstreamRESTConfig:       world.RESTConfig,
+			UpstreamGCPAuthorization: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: offline.AccessToken}),
+			UpstreamGCPHTTPClient:    world.GCPHTTPClient,
+		}
+	} else {
+		upstreamRESTConfig, err := getRESTConfig(params)
+		if err != nil {
+			return nil, fmt.Errorf("error building kubeconfig: %w", err)
+		}
+		authorization, err := getGCPAuthorization(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("error building GCP authorization: %w", err)
+		}
+		options = preview.PreviewInstanceOptions{
+			UpstreamRESTConfig:       upstreamRESTConfig,
+			UpstreamGCPAuthorization: authorization,
+		}
+	}
+
+	recorder := preview.NewRecorder()
+	if err := recorder.PreloadGKNN(ctx, options.UpstreamRESTConfig); err != nil {
+		return nil, fmt.Errorf("error preloading the list of resources to reconcile: %w", err)
+	}
+	instance, err := preview.NewPreviewInstance(recorder, options)
+	if err != nil {
+		return nil, fmt.Errorf("error building preview instance: %w", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, params.Timeout)
+	defer cancel()
+	if err := instance.Start(runCtx); err != nil {
+		return nil, fmt.Errorf("error running preview: %w", err)
+	}
+	if runCtx.Err() != nil {
+		// objects that were not reconciled before the timeout are reported as such
+		klog.Warningf("preview timed out after %v", params.Timeout)
+	}
+
+	report := recorder.BuildReport()
+	if world != nil {
+		// the GCP state of these objects was not seeded, so their planned actions would be misleading
+		report.MarkUnsupported(world.Unsupported())
+	}
+	if err := writeReport(params.JSONReport, stdout, report.WriteJSON); err != nil {
+		return nil, err
+	}
+	if err := writeReport(params.MarkdownReport, stdout, report.WriteMarkdown); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// newOfflineWorld starts an in-process cluster and mock GCP seeded with the exported resources,
+// and applies the desired resources on top of them.
+func newOfflineWorld(ctx context.Context, params *parameters.Parameters) (*offline.World, error) {
+	seed, err := offline.LoadObjects(params.Seed)
+	if err != nil {
+		return nil, fmt.Errorf("error loading seed: %w", err)
+	}
+	var desired []*unstructured.Unstructured
+	if params.Filename != "" {
+		desired, err = offline.LoadObjects(params.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("error loading desired resources: %w", err)
+		}
+	}
+	var opts offline.WorldOptions
+	opts.CRDs, err = offline.LoadCRDs(params.CRDs)
+	if err != nil {
+		return nil, fmt.Errorf("error loading CRDs: %w", err)
+	}
+
+	world, err := offline.NewWorld(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error starting offline preview: %w", err)
+	}
+	klog.Infof("seeding %d resources from '%v'", len(seed), params.Seed)
+	if err := world.Seed(ctx, seed); err != nil {
+		world.Close()
+		return nil, fmt.Errorf("error seeding offline preview: %w", err)
+	}
+	if err := world.Apply(ctx, desired); err != nil {
+		world.Close()
+		return nil, fmt.Errorf("error applying desired resources: %w", err)
+	}
+	return world, nil
+}
+
+func writeReport(path string, stdout io.Writer, write func(io.Writer) error) error {
+	switch path {
+	case "":
+		return nil
+	case parameters.StdoutReportPath:
+		return write(stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file '%v': %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing report to '%v': %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error closing '%v': %w", path, err)
+	}
+	return nil
+}
+
+func getRESTConfig(params *parameters.Parameters) (*rest.Config, error) {
+	var loadingRules clientcmd.ClientConfigLoader
+	if params.Kubeconfig != "" {
+		loadingRules = &clientcmd.ClientConfigLoadingRules{ExplicitPath: params.Kubeconfig}
+	} else {
+		loadingRules = clientcmd.NewDefaultClientConfigLoadingRules()
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+func getGCPAuthorization(ctx context.Context, params *parameters.Parameters) (oauth2.TokenSource, error) {
+	if params.OAuth2Token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: params.OAuth2Token}), nil
+	}
+	return google.DefaultTokenSource(ctx, cloudPlatformScope)
+}
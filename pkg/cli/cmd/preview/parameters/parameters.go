@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	KubeconfigParam     = "kubeconfig"
+	TimeoutParam        = "timeout"
+	JSONReportParam     = "json-report"
+	MarkdownReportParam = "markdown-report"
+	SeedParam           = "seed"
+	FilenameParam       = "filename"
+	CRDsParam           = "crds"
+
+	// StdoutReportPath can be used as the path of a report to write it to stdout
+	StdoutReportPath = "-"
+
+	DefaultTimeout = 15 * time.Minute
+)
+
+type Parameters struct {
+	Kubeconfig     string
+	Timeout        time.Duration
+	JSONReport     string
+	MarkdownReport string
+	OAuth2Token    string
+
+	// Seed is a file, directory or tarball of exported resources; if set, the preview runs offline
+	// against a mock GCP seeded with these resources instead of against a cluster and GCP.
+	Seed string
+	// Filename is a file, directory or tarball of the desired resources, previewed against the seed.
+	Filename string
+	// CRDs is a file, directory or tarball with the CRDs to install in the offline cluster.
+	// It is required with the seed.
+	CRDs string
+}
+
+// IsOffline returns true if the preview runs against a seeded mock GCP.
+func (p *Parameters) IsOffline() bool {
+	return p.Seed != ""
+}
+
+func Validate(p *Parameters) error {
+	if p.Timeout <= 0 {
+		return fmt.Errorf("invalid %v value of '%v': must be greater than zero", TimeoutParam, p.Timeout)
+	}
+	if p.JSONReport == StdoutReportPath && p.MarkdownReport == StdoutReportPath {
+		return fmt.Errorf("cannot write both the '%v' and '%v' to stdout", JSONReportParam, MarkdownReportParam)
+	}
+	if err := validateOffline(p); err != nil {
+		return err
+	}
+	// default to a human-readable report on stdout
+	if p.JSONReport == "" && p.MarkdownReport == "" {
+		p.MarkdownReport = StdoutReportPath
+	}
+	return nil
+}
+
+func validateOffline(p *Parameters) error {
+	if !p.IsOffline() {
+		if p.Filename != "" {
+			return fmt.Errorf("'%v' can only be used with '%v'", FilenameParam, SeedParam)
+		}
+		if p.CRDs != "" {
+			return fmt.Errorf("'%v' can only be used with '%v'", CRDsParam, SeedParam)
+		}
+		return nil
+	}
+	// the offline preview never talks to a cluster, so the CRDs must be supplied
+	if p.CRDs == "" {
+		return fmt.Errorf("'%v' is required with '%v'", CRDsParam, SeedParam)
+	}
+	if p.Kubeconfig != "" {
+		return fmt.Errorf("'%v' cannot be used with '%v', the offline preview does not use a cluster", KubeconfigParam, SeedParam)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	golog "log"
@@ -62,6 +63,7 @@ func init() {
 	AddVersionCommand(rootCmd)
 	AddLicensesCommand(rootCmd)
 	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(previewCmd)
 
 	powertools.AddCommands(rootCmd)
 
@@ -75,8 +77,33 @@ func rootPreRunE(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// ExitCodeError is returned by commands that need the CLI to exit with a specific code, Err is optional and is
+// only logged if set.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
 func Execute() {
 	if err := recoverExecute(); err != nil {
+		var exitCodeErr *ExitCodeError
+		if errors.As(err, &exitCodeErr) {
+			if exitCodeErr.Err != nil {
+				log.Error("error in '%v' version '%v': %v", commandName, version, exitCodeErr.Err)
+			}
+			os.Exit(exitCodeErr.Code)
+		}
 		log.Error("error in '%v' version '%v': %v", commandName, version, err)
 		os.Exit(2)
 	}
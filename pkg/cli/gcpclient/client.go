@@ -42,18 +42,24 @@ type Client interface {
 }
 
 type gcpClient struct {
-	erroringK8sClient client.Client
-	smLoader          *servicemappingloader.ServiceMappingLoader
-	tfProvider        *schema.Provider
-	supportedKinds    map[string]bool
+	k8sClient      client.Client
+	smLoader       *servicemappingloader.ServiceMappingLoader
+	tfProvider     *schema.Provider
+	supportedKinds map[string]bool
 }
 
 func New(provider *schema.Provider, smLoader *servicemappingloader.ServiceMappingLoader) Client {
+	return NewWithKubeClient(provider, smLoader, k8s.NewErroringClient())
+}
+
+// NewWithKubeClient returns a Client which resolves the references of a resource using kubeClient,
+// so that resources read from a cluster can be fetched without externalizing their references first.
+func NewWithKubeClient(provider *schema.Provider, smLoader *servicemappingloader.ServiceMappingLoader, kubeClient client.Client) Client {
 	client := gcpClient{
-		erroringK8sClient: k8s.NewErroringClient(),
-		smLoader:          smLoader,
-		tfProvider:        provider,
-		supportedKinds:    buildSupportedKindSet(smLoader),
+		k8sClient:      kubeClient,
+		smLoader:       smLoader,
+		tfProvider:     provider,
+		supportedKinds: buildSupportedKindSet(smLoader),
 	}
 	return &client
 }
@@ -72,7 +78,7 @@ func (c *gcpClient) Get(ctx context.Context, u *unstructured.Unstructured) (*uns
 	if err != nil {
 		return nil, fmt.Errorf("could not parse resource %s: %w", u.GetName(), err)
 	}
-	state, err := krmtotf.FetchLiveState(ctx, resource, c.tfProvider, c.erroringK8sClient, c.smLoader)
+	state, err := krmtotf.FetchLiveState(ctx, resource, c.tfProvider, c.k8sClient, c.smLoader)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching live state: %w", err)
 	}
@@ -92,11 +98,11 @@ func (c *gcpClient) Apply(u *unstructured.Unstructured) (*unstructured.Unstructu
 	if err != nil {
 		return nil, fmt.Errorf("could not parse resource %s: %w", u.GetName(), err)
 	}
-	liveState, err := krmtotf.FetchLiveState(ctx, krmResource, c.tfProvider, c.erroringK8sClient, c.smLoader)
+	liveState, err := krmtotf.FetchLiveState(ctx, krmResource, c.tfProvider, c.k8sClient, c.smLoader)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching live state: %w", err)
 	}
-	config, _, err := krmtotf.KRMResourceToTFResourceConfig(krmResource, c.erroringK8sClient, c.smLoader)
+	config, _, err := krmtotf.KRMResourceToTFResourceConfig(krmResource, c.k8sClient, c.smLoader)
 	if err != nil {
 		return nil, fmt.Errorf("error expanding resource configuration: %w", err)
 	}
@@ -131,7 +137,7 @@ func (c *gcpClient) Delete(u *unstructured.Unstructured) error {
 	if err != nil {
 		return fmt.Errorf("could not parse resource %s: %w", u.GetName(), err)
 	}
-	liveState, err := krmtotf.FetchLiveState(ctx, krmResource, c.tfProvider, c.erroringK8sClient, c.smLoader)
+	liveState, err := krmtotf.FetchLiveState(ctx, krmResource, c.tfProvider, c.k8sClient, c.smLoader)
 	if err != nil {
 		return fmt.Errorf("error fetching live state: %w", err)
 	}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputsink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/outputsink/filename"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kustomizationFileName = "kustomization.yaml"
+	// unscopedDirName is used for resources that are not parented by a project, folder or organization
+	unscopedDirName = "unscoped"
+)
+
+// serverPopulatedMetadataFields are the metadata fields that are set by the API server and must not be committed
+var serverPopulatedMetadataFields = []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"}
+
+// GitOpsOptions configures the layout produced by the GitOpsDirectorySink
+type GitOpsOptions struct {
+	// Namespace, if set, is applied to every resource and is used as the top-level directory instead of the project
+	Namespace string
+	// StripOutputOnly removes the status and server-populated metadata fields from every resource
+	StripOutputOnly bool
+}
+
+// GitOpsDirectorySink writes KRM resources to a directory layout that is ready to be committed to a Config Sync
+// repository:
+//
+//	<dir>/kustomization.yaml
+//	<dir>/<project-or-namespace>/kustomization.yaml
+//	<dir>/<project-or-namespace>/<service>/kustomization.yaml
+//	<dir>/<project-or-namespace>/<service>/<kind>-<name>.yaml
+//
+// The kustomization.yaml files are written when the sink is closed.
+type GitOpsDirectorySink struct {
+	dir     string
+	options GitOpsOptions
+	// directory relative to dir -> set of entries (files or sub-directories) in that directory
+	entries map[string]map[string]bool
+}
+
+// NewGitOpsDirectory returns a GitOpsDirectorySink which writes to the given directory
+func NewGitOpsDirectory(dir string, options GitOpsOptions) (*GitOpsDirectorySink, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating directory '%v': %w", dir, err)
+	}
+	sink := GitOpsDirectorySink{
+		dir:     dir,
+		options: options,
+		entries: make(map[string]map[string]bool),
+	}
+	return &sink, nil
+}
+
+func (gs *GitOpsDirectorySink) Receive(_ context.Context, bytes []byte, u *unstructured.Unstructured) error {
+	if isYAMLTerminator(bytes) || u == nil {
+		return nil
+	}
+	u = u.DeepCopy()
+	projectID := getProjectID(u)
+	topDir := gs.options.Namespace
+	if topDir == "" {
+		topDir = getScopeDirName(u, projectID)
+	}
+	if gs.options.Namespace != "" {
+		u.SetNamespace(gs.options.Namespace)
+	}
+	if projectID != "" {
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[k8s.ProjectIDAnnotation] = projectID
+		u.SetAnnotations(annotations)
+	}
+	if gs.options.StripOutputOnly {
+		stripOutputOnlyFields(u)
+	}
+	out, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return fmt.Errorf("error marshalling %v '%v' to YAML: %w", u.GetKind(), u.GetName(), err)
+	}
+
+	serviceDir := getServiceName(u)
+	fileName := filename.MakeSafeFilename(fmt.Sprintf("%v-%v", strings.ToLower(u.GetKind()), u.GetName())) + ".yaml"
+	relDir := filepath.Join(topDir, serviceDir)
+	if gs.entries[relDir][fileName] {
+		return fmt.Errorf("cannot write %v '%v': a resource with the same kind and name was already written to '%v'",
+			u.GetKind(), u.GetName(), filepath.Join(relDir, fileName))
+	}
+	absDir := filepath.Join(gs.dir, relDir)
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return fmt.Errorf("error ensuring parent path '%v' exists: %w", absDir, err)
+	}
+	filePath := filepath.Join(absDir, fileName)
+	if err := os.WriteFile(filePath, out, 0644); err != nil {
+		return fmt.Errorf("error writing bytes to '%v': %w", filePath, err)
+	}
+	gs.addEntry(".", topDir)
+	gs.addEntry(topDir, serviceDir)
+	gs.addEntry(relDir, fileName)
+	return nil
+}
+
+func (gs *GitOpsDirectorySink) addEntry(dir, entry string) {
+	if gs.entries[dir] == nil {
+		gs.entries[dir] = make(map[string]bool)
+	}
+	gs.entries[dir][entry] = true
+}
+
+// Close writes a kustomization.yaml to every directory that was populated by the sink
+func (gs *GitOpsDirectorySink) Close() error {
+	for dir, entries := range gs.entries {
+		resources := make([]string, 0, len(entries))
+		for e := range entries {
+			resources = append(resources, e)
+		}
+		sort.Strings(resources)
+		kustomization := map[string]interface{}{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  resources,
+		}
+		out, err := yaml.Marshal(kustomization)
+		if err != nil {
+			return fmt.Errorf("error marshalling kustomization for '%v': %w", dir, err)
+		}
+		filePath := filepath.Join(gs.dir, dir, kustomizationFileName)
+		if err := os.WriteFile(filePath, out, 0644); err != nil {
+			return fmt.Errorf("error writing bytes to '%v': %w", filePath, err)
+		}
+	}
+	gs.entries = make(map[string]map[string]bool)
+	return nil
+}
+
+// getProjectID returns the id of the project the resource belongs to, or "" if it is not a project-scoped resource
+func getProjectID(u *unstructured.Unstructured) string {
+	if projectID, ok := k8s.GetAnnotation(k8s.ProjectIDAnnotation, u); ok {
+		return projectID
+	}
+	external, _, _ := unstructured.NestedString(u.Object, "spec", "projectRef", "external")
+	return strings.TrimPrefix(external, "projects/")
+}
+
+func getScopeDirName(u *unstructured.Unstructured, projectID string) string {
+	if projectID != "" {
+		return filename.MakeSafeFilename(projectID)
+	}
+	if folderID, ok := k8s.GetAnnotation(k8s.FolderIDAnnotation, u); ok {
+		return filename.MakeSafeFilename("folders-" + folderID)
+	}
+	if orgID, ok := k8s.GetAnnotation(k8s.OrgIDAnnotation, u); ok {
+		return filename.MakeSafeFilename("organizations-" + orgID)
+	}
+	for _, ref := range []string{"folderRef", "organizationRef"} {
+		external, _, _ := unstructured.NestedString(u.Object, "spec", ref, "external")
+		if external != "" {
+			return filename.MakeSafeFilename(strings.ReplaceAll(external, "/", "-"))
+		}
+	}
+	return unscopedDirName
+}
+
+// getServiceName returns the service portion of the resource's group, i.e. 'pubsub' for 'pubsub.cnrm.cloud.google.com'
+func getServiceName(u *unstructured.Unstructured) string {
+	group := u.GroupVersionKind().Group
+	return filename.MakeSafeFilename(strings.Split(group, ".")[0])
+}
+
+func stripOutputOnlyFields(u *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range serverPopulatedMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputsink_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/outputsink"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGitOpsDirectorySinkByProject(t *testing.T) {
+	tmpDir, cleanup := newTmpDir(t)
+	defer cleanup()
+	sink, err := outputsink.NewGitOpsDirectory(tmpDir, outputsink.GitOpsOptions{})
+	if err != nil {
+		t.Fatalf("error creating sink: %v", err)
+	}
+	receiveTestFiles(t, sink, "pubsubtopic-project1.yaml", "pubsubtopic-project2.yaml", "storagebucket.yaml")
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing sink: %v", err)
+	}
+	expectedFiles := []string{
+		"kustomization.yaml",
+		"my-project-id/kustomization.yaml",
+		"my-project-id/storage/kustomization.yaml",
+		"my-project-id/storage/storagebucket-deleteoutofband-0ba21344-d250-11e8-bf9c-dc4a3e7de811.yaml",
+		"project1/kustomization.yaml",
+		"project1/pubsub/kustomization.yaml",
+		"project1/pubsub/pubsubtopic-pubsubtopic.yaml",
+		"project2/kustomization.yaml",
+		"project2/pubsub/kustomization.yaml",
+		"project2/pubsub/pubsubtopic-pubsubtopic.yaml",
+	}
+	assertRelativeFiles(t, tmpDir, expectedFiles)
+	assertKustomizationResources(t, filepath.Join(tmpDir, "kustomization.yaml"), []string{"my-project-id", "project1", "project2"})
+	assertKustomizationResources(t, filepath.Join(tmpDir, "project1", "pubsub", "kustomization.yaml"), []string{"pubsubtopic-pubsubtopic.yaml"})
+}
+
+func TestGitOpsDirectorySinkByNamespace(t *testing.T) {
+	tmpDir, cleanup := newTmpDir(t)
+	defer cleanup()
+	sink, err := outputsink.NewGitOpsDirectory(tmpDir, outputsink.GitOpsOptions{Namespace: "team-a", StripOutputOnly: true})
+	if err != nil {
+		t.Fatalf("error creating sink: %v", err)
+	}
+	u := unstructuredFromYamlFile(t, "pubsubtopic-project1.yaml")
+	u.SetUID("0123")
+	u.SetResourceVersion("42")
+	if err := unstructured.SetNestedField(u.Object, "abc", "status", "observedState"); err != nil {
+		t.Fatalf("error setting status: %v", err)
+	}
+	if err := sink.Receive(context.TODO(), []byte("unused"), u); err != nil {
+		t.Fatalf("error receiving resource: %v", err)
+	}
+	if err := sink.Receive(context.TODO(), []byte("unused"), unstructuredFromYamlFile(t, "pubsubtopic-project2.yaml")); err == nil {
+		t.Fatalf("expected an error when writing two resources with the same kind and name to the same namespace")
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("error closing sink: %v", err)
+	}
+	assertRelativeFiles(t, tmpDir, []string{
+		"kustomization.yaml",
+		"team-a/kustomization.yaml",
+		"team-a/pubsub/kustomization.yaml",
+		"team-a/pubsub/pubsubtopic-pubsubtopic.yaml",
+	})
+	written := unstructuredFromFile(t, filepath.Join(tmpDir, "team-a", "pubsub", "pubsubtopic-pubsubtopic.yaml"))
+	if got, want := written.GetNamespace(), "team-a"; got != want {
+		t.Errorf("namespace: got '%v', want '%v'", got, want)
+	}
+	if got, want := written.GetAnnotations()["cnrm.cloud.google.com/project-id"], "project1"; got != want {
+		t.Errorf("project-id annotation: got '%v', want '%v'", got, want)
+	}
+	if _, found := written.Object["status"]; found {
+		t.Errorf("expected status to be stripped")
+	}
+	if written.GetUID() != "" || written.GetResourceVersion() != "" {
+		t.Errorf("expected server-populated metadata to be stripped, got uid '%v' and resourceVersion '%v'", written.GetUID(), written.GetResourceVersion())
+	}
+}
+
+func receiveTestFiles(t *testing.T, sink outputsink.OutputSink, fileNames ...string) {
+	for _, f := range fileNames {
+		if err := sink.Receive(context.TODO(), testFileToBytes(t, f), unstructuredFromYamlFile(t, f)); err != nil {
+			t.Fatalf("error receiving '%v': %v", f, err)
+		}
+	}
+}
+
+func assertRelativeFiles(t *testing.T, dir string, expected []string) {
+	var actual []string
+	for _, f := range findFilesRecursive(t, dir) {
+		rel, err := filepath.Rel(dir, f)
+		if err != nil {
+			t.Fatalf("error getting relative path for '%v': %v", f, err)
+		}
+		actual = append(actual, filepath.ToSlash(rel))
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("unexpected files in '%v' (-want +got):\n%v", dir, diff)
+	}
+}
+
+func assertKustomizationResources(t *testing.T, filePath string, expected []string) {
+	u := unstructuredFromFile(t, filePath)
+	resources, _, err := unstructured.NestedStringSlice(u.Object, "resources")
+	if err != nil {
+		t.Fatalf("error reading resources from '%v': %v", filePath, err)
+	}
+	if diff := cmp.Diff(expected, resources); diff != "" {
+		t.Errorf("unexpected resources in '%v' (-want +got):\n%v", filePath, diff)
+	}
+}
+
+func unstructuredFromFile(t *testing.T, filePath string) *unstructured.Unstructured {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("error reading '%v': %v", filePath, err)
+	}
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(string(b))), &u.Object); err != nil {
+		t.Fatalf("error unmarshalling '%v': %v", filePath, err)
+	}
+	return u
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abandon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/diffs"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options configures the behaviour of the Abandon operation.
+type Options struct {
+	kubecli.ClusterOptions
+	kubecli.ObjectOptions
+
+	// FieldOwner is the field-manager owner value to use when making changes
+	FieldOwner string
+
+	// Timeout is how long we wait for the object to be deleted
+	Timeout time.Duration
+
+	// DryRun is true if we should not actually make changes, just print the changes we would make
+	DryRun bool
+
+	// Yes is true if we should not ask for confirmation before making changes
+	Yes bool
+}
+
+func (o *Options) PopulateDefaults() {
+	o.ClusterOptions.PopulateDefaults()
+	o.ObjectOptions.PopulateDefaults()
+
+	o.FieldOwner = "abandon"
+	o.Timeout = 5 * time.Minute
+	o.DryRun = false
+	o.Yes = false
+}
+
+func AddCommand(parent *cobra.Command) {
+	var options Options
+	options.PopulateDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "abandon",
+		Short: "Delete a KCC object while keeping the GCP resource (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return Run(ctx, cmd.InOrStdin(), cmd.OutOrStdout(), options)
+		},
+		Long: `
+abandon deletes a KCC object without deleting the GCP resource, by setting
+the cnrm.cloud.google.com/deletion-policy annotation to abandon before
+deleting the object.  It waits until the finalizers have run and the
+object is gone.
+
+Examples
+
+Abandon the PubSubTopic "my-topic" in the namespace "my-namespace":
+
+  config-connector powertools abandon \
+    --namespace=my-namespace --name=my-topic \
+    --kind=PubSubTopic
+
+`,
+		Args: cobra.NoArgs,
+	}
+
+	options.ObjectOptions.AddFlags(cmd)
+	options.ClusterOptions.AddFlags(cmd)
+
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "how long to wait for the object to be deleted")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "dry-run mode will not make changes, but only print the changes it would make")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "abandon the object without asking for confirmation")
+
+	parent.AddCommand(cmd)
+}
+
+func Run(ctx context.Context, stdin io.Reader, stdout io.Writer, options Options) error {
+	// Impersonate the KCC service account, which is allowed to make changes
+	options.ClusterOptions.ImpersonateControllerManager(options.Namespace)
+	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	u, err := kubeClient.GetObject(ctx, options.ObjectOptions)
+	if err != nil {
+		return fmt.Errorf("getting object: %w", err)
+	}
+
+	diff, err := diffs.BuildObjectDiff(u, WithAbandonPolicy(u))
+	if err != nil {
+		return fmt.Errorf("building object diff: %w", err)
+	}
+	fmt.Fprintf(stdout, "\n\n")
+	printOpts := diffs.PrettyPrintOptions{PrintObjectInfo: true, Indent: "    "}
+	diff.PrettyPrintTo(printOpts, stdout)
+	fmt.Fprintf(stdout, "\n\n")
+
+	if options.DryRun {
+		fmt.Fprintf(stdout, "dry-run mode, not making changes\n")
+		return nil
+	}
+
+	if !options.Yes {
+		confirmed, err := kubecli.Confirm(stdin, stdout, fmt.Sprintf("Delete %s %s/%s, keeping the GCP resource?", u.GetKind(), u.GetNamespace(), u.GetName()))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	if err := Abandon(ctx, stdout, kubeClient, u, options.FieldOwner, options.Timeout); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s %s/%s abandoned\n", u.GetKind(), u.GetNamespace(), u.GetName())
+	return nil
+}
+
+// WithAbandonPolicy returns a copy of u with the deletion-policy annotation set to abandon.
+func WithAbandonPolicy(u *unstructured.Unstructured) *unstructured.Unstructured {
+	abandoned := u.DeepCopy()
+	annotations := abandoned.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[k8s.DeletionPolicyAnnotation] = k8s.DeletionPolicyAbandon
+	abandoned.SetAnnotations(annotations)
+	return abandoned
+}
+
+// Abandon sets the abandon deletion-policy on u, deletes it, and waits for the finalizers to complete.
+// The GCP resource is left in place.
+func Abandon(ctx context.Context, stdout io.Writer, kubeClient *kubecli.Client, u *unstructured.Unstructured, fieldOwner string, timeout time.Duration) error {
+	if !k8s.HasAbandonAnnotation(u) {
+		fmt.Fprintf(stdout, "setting %s=%s on %s %s/%s\n", k8s.DeletionPolicyAnnotation, k8s.DeletionPolicyAbandon, u.GetKind(), u.GetNamespace(), u.GetName())
+		if err := kubeClient.Update(ctx, WithAbandonPolicy(u), client.FieldOwner(fieldOwner)); err != nil {
+			return fmt.Errorf("setting deletion policy: %w", err)
+		}
+	}
+
+	fmt.Fprintf(stdout, "deleting %s %s/%s\n", u.GetKind(), u.GetNamespace(), u.GetName())
+	if err := kubeClient.Delete(ctx, u); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting object: %w", err)
+	}
+	if err := kubeClient.WaitForDeletion(ctx, u, timeout); err != nil {
+		return err
+	}
+	return nil
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/stateintospec"
 	"github.com/spf13/cobra"
-	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 )
@@ -128,18 +127,8 @@ func Run(ctx context.Context, stdout io.Writer, stderr io.Writer, options Option
 		return err
 	}
 
-	if options.ImpersonateUser == "" {
-		// Impersonate the KCC service account, which is allowed to make changes
-		options.ClusterOptions.Impersonate = &rest.ImpersonationConfig{
-			UserName: "system:serviceaccount:cnrm-system:cnrm-controller-manager-" + options.Namespace,
-			Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:cnrm-system"},
-		}
-	} else {
-		options.ClusterOptions.Impersonate = &rest.ImpersonationConfig{
-			UserName: options.ImpersonateUser,
-			Groups:   options.ImpersonateGroups,
-		}
-	}
+	// Impersonate the KCC service account, which is allowed to make changes
+	options.ClusterOptions.ImpersonateControllerManager(options.Namespace)
 	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
 	if err != nil {
 		return fmt.Errorf("creating client: %w", err)
@@ -15,8 +15,13 @@
 package powertools
 
 import (
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/abandon"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/changestateintospec"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/forcesetfield"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/migratereconciler"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/move"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/reconcile"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/wait"
 	"github.com/spf13/cobra"
 )
 
@@ -30,4 +35,9 @@ func AddCommands(parent *cobra.Command) {
 
 	forcesetfield.AddCommand(powertoolsCmd)
 	changestateintospec.AddCommand(powertoolsCmd)
+	migratereconciler.AddCommand(powertoolsCmd)
+	abandon.AddCommand(powertoolsCmd)
+	move.AddCommand(powertoolsCmd)
+	reconcile.AddCommand(powertoolsCmd)
+	wait.AddCommand(powertoolsCmd)
 }
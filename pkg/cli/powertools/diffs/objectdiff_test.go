@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildObjectDiff(t *testing.T) {
+	oldObj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"location": "us-central1",
+			"tags":     []any{"a", "b", "c"},
+		},
+	}}
+	newObj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"location": "us-central1",
+			"tags":     []any{"a", "x"},
+		},
+	}}
+
+	diff, err := BuildObjectDiff(oldObj, newObj)
+	if err != nil {
+		t.Fatalf("error building diff: %v", err)
+	}
+	if !diff.HasChanges() {
+		t.Fatalf("expected changes")
+	}
+	var out bytes.Buffer
+	diff.PrintStructuredTo(&out)
+	want := "spec.tags.[1]: b -> x\nspec.tags.[2]: c -> <nil>\n"
+	if d := cmp.Diff(want, out.String()); d != "" {
+		t.Errorf("unexpected diff (-want +got):\n%v", d)
+	}
+
+	same, err := BuildObjectDiff(oldObj, oldObj.DeepCopy())
+	if err != nil {
+		t.Fatalf("error building diff: %v", err)
+	}
+	if same.HasChanges() {
+		t.Errorf("expected no changes between identical objects")
+	}
+}
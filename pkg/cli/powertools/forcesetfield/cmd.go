@@ -24,7 +24,6 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -85,18 +84,8 @@ func AddCommand(parent *cobra.Command) {
 func Run(ctx context.Context, out io.Writer, options Options, setFields map[string]string) error {
 	// log := klog.FromContext(ctx)
 
-	if options.ImpersonateUser == "" {
-		// Impersonate the KCC service account, which is allowed to make changes
-		options.ClusterOptions.Impersonate = &rest.ImpersonationConfig{
-			UserName: "system:serviceaccount:cnrm-system:cnrm-controller-manager-" + options.Namespace,
-			Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:cnrm-system"},
-		}
-	} else {
-		options.ClusterOptions.Impersonate = &rest.ImpersonationConfig{
-			UserName: options.ImpersonateUser,
-			Groups:   options.ImpersonateGroups,
-		}
-	}
+	// Impersonate the KCC service account, which is allowed to make changes
+	options.ClusterOptions.ImpersonateControllerManager(options.Namespace)
 	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
 	if err != nil {
 		return fmt.Errorf("creating client: %w", err)
@@ -39,6 +39,7 @@ import (
 type Client struct {
 	client.Client
 	DiscoveryClient discovery.DiscoveryInterface
+	RESTConfig      *rest.Config
 }
 
 func NewClient(ctx context.Context, options ClusterOptions) (*Client, error) {
@@ -82,6 +83,7 @@ func NewClient(ctx context.Context, options ClusterOptions) (*Client, error) {
 	return &Client{
 		DiscoveryClient: discoveryClient,
 		Client:          kubeClient,
+		RESTConfig:      restConfig,
 	}, nil
 }
 
@@ -145,33 +147,54 @@ func (c *Client) GetObject(ctx context.Context, options ObjectOptions) (*unstruc
 		return nil, fmt.Errorf("must specify object namespace to target (use --namespace flag)")
 	}
 
+	gvk, err := c.findKind(options.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.NamespacedName{
+		Name:      options.Name,
+		Namespace: options.Namespace,
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+
+	if err := c.Client.Get(ctx, key, u); err != nil {
+		return nil, fmt.Errorf("getting object %v: %w", key, err)
+	}
+	return u, nil
+}
+
+// findKind resolves kind (matched against kind, resource-name, aliases etc) to a GroupVersionKind.
+func (c *Client) findKind(kind string) (schema.GroupVersionKind, error) {
 	resources, err := c.DiscoveryClient.ServerPreferredResources()
 	if err != nil {
-		return nil, fmt.Errorf("discovering server resources: %w", err)
+		return schema.GroupVersionKind{}, fmt.Errorf("discovering server resources: %w", err)
 	}
 
 	var matches []metav1.APIResource
 	for _, group := range resources {
 		for _, resource := range group.APIResources {
 			match := false
-			if strings.EqualFold(resource.Kind, options.Kind) {
+			if strings.EqualFold(resource.Kind, kind) {
 				match = true
 			}
-			if strings.EqualFold(resource.Name, options.Kind) {
+			if strings.EqualFold(resource.Name, kind) {
 				match = true
 			}
-			if strings.EqualFold(resource.SingularName, options.Kind) {
+			if strings.EqualFold(resource.SingularName, kind) {
 				match = true
 			}
 			for _, shortName := range resource.ShortNames {
-				if strings.EqualFold(shortName, options.Kind) {
+				if strings.EqualFold(shortName, kind) {
 					match = true
 				}
 			}
 			if match {
 				gv, err := schema.ParseGroupVersion(group.GroupVersion)
 				if err != nil {
-					return nil, fmt.Errorf("parsing group version %q: %w", group.GroupVersion, err)
+					return schema.GroupVersionKind{}, fmt.Errorf("parsing group version %q: %w", group.GroupVersion, err)
 				}
 
 				// populate the group and version
@@ -184,26 +207,13 @@ func (c *Client) GetObject(ctx context.Context, options ObjectOptions) (*unstruc
 		}
 	}
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("did not find any kubernetes kinds for %q", options.Kind)
+		return schema.GroupVersionKind{}, fmt.Errorf("did not find any kubernetes kinds for %q", kind)
 	}
 	if len(matches) > 1 {
 		// TODO: Print fully-qualified names
-		return nil, fmt.Errorf("found multiple kubernetes kind for %q", options.Kind)
+		return schema.GroupVersionKind{}, fmt.Errorf("found multiple kubernetes kind for %q", kind)
 	}
 	resource := matches[0]
 
-	gvk := schema.GroupVersionKind{Group: resource.Group, Version: resource.Version, Kind: resource.Kind}
-
-	key := types.NamespacedName{
-		Name:      options.Name,
-		Namespace: options.Namespace,
-	}
-
-	u := &unstructured.Unstructured{}
-	u.SetGroupVersionKind(gvk)
-
-	if err := c.Client.Get(ctx, key, u); err != nil {
-		return nil, fmt.Errorf("getting object %v: %w", key, err)
-	}
-	return u, nil
+	return schema.GroupVersionKind{Group: resource.Group, Version: resource.Version, Kind: resource.Kind}, nil
 }
@@ -37,6 +37,25 @@ func (o *ClusterOptions) PopulateDefaults() {
 
 }
 
+// ImpersonateControllerManager configures the client to impersonate the KCC controller manager for the namespace,
+// which is allowed to make changes to the objects in it.  The user and groups passed with --as and --as-group take precedence.
+func (o *ClusterOptions) ImpersonateControllerManager(namespace string) {
+	if o.ImpersonateUser != "" {
+		o.Impersonate = &rest.ImpersonationConfig{
+			UserName: o.ImpersonateUser,
+			Groups:   o.ImpersonateGroups,
+		}
+		return
+	}
+	if namespace == "" {
+		return
+	}
+	o.Impersonate = &rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:cnrm-system:cnrm-controller-manager-" + namespace,
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:cnrm-system"},
+	}
+}
+
 func (o *ClusterOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file to use for CLI requests.")
 	cmd.Flags().StringVar(&o.ImpersonateUser, "as", o.ImpersonateUser, "Username to impersonate for the operation. User could be a regular user or a service account in a namespace.")
@@ -61,3 +80,21 @@ func (o *ObjectOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.Name, "name", o.Name, "Name of the object to change")
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "Namespace of the object")
 }
+
+type SelectorOptions struct {
+	// Kinds restricts the selection to these kinds, matched as for ObjectOptions.  All KCC kinds are selected if empty.
+	Kinds []string
+	// Namespace restricts the selection to a single namespace
+	Namespace string
+	// AllNamespaces selects objects in all namespaces
+	AllNamespaces bool
+	// LabelSelector restricts the selection to objects matching the label selector
+	LabelSelector string
+}
+
+func (o *SelectorOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&o.Kinds, "kind", o.Kinds, "Kinds of the objects to select, this flag can be repeated.  All KCC kinds are selected if not specified.")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "Namespace of the objects to select")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "Select objects in all namespaces")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Label selector for the objects to select, for example env=prod")
+}
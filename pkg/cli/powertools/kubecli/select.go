@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubecli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListObjects returns the objects matching the selector, sorted by kind, namespace and name.
+func (c *Client) ListObjects(ctx context.Context, options SelectorOptions) ([]*unstructured.Unstructured, error) {
+	if options.Namespace == "" && !options.AllNamespaces {
+		return nil, fmt.Errorf("must specify namespace to select from (use --namespace or --all-namespaces flag)")
+	}
+	if options.Namespace != "" && options.AllNamespaces {
+		return nil, fmt.Errorf("cannot specify both --namespace and --all-namespaces")
+	}
+
+	var listOptions []client.ListOption
+	if options.Namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(options.Namespace))
+	}
+	if options.LabelSelector != "" {
+		selector, err := labels.Parse(options.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing label selector %q: %w", options.LabelSelector, err)
+		}
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	var gvks []schema.GroupVersionKind
+	if len(options.Kinds) != 0 {
+		for _, kind := range options.Kinds {
+			gvk, err := c.findKind(kind)
+			if err != nil {
+				return nil, err
+			}
+			gvks = append(gvks, gvk)
+		}
+	} else {
+		kccKinds, err := c.kccKinds()
+		if err != nil {
+			return nil, err
+		}
+		gvks = kccKinds
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, gvk := range gvks {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.Client.List(ctx, list, listOptions...); err != nil {
+			return nil, fmt.Errorf("listing %v: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].GetKind() != objects[j].GetKind() {
+			return objects[i].GetKind() < objects[j].GetKind()
+		}
+		if objects[i].GetNamespace() != objects[j].GetNamespace() {
+			return objects[i].GetNamespace() < objects[j].GetNamespace()
+		}
+		return objects[i].GetName() < objects[j].GetName()
+	})
+	return objects, nil
+}
+
+// kccKinds returns the namespaced KCC resource kinds served by the cluster.
+// The core group (ConfigConnectorContext etc) is not included.
+func (c *Client) kccKinds() ([]schema.GroupVersionKind, error) {
+	resources, err := c.DiscoveryClient.ServerPreferredNamespacedResources()
+	if err != nil {
+		return nil, fmt.Errorf("discovering server resources: %w", err)
+	}
+
+	var gvks []schema.GroupVersionKind
+	for _, group := range resources {
+		gv, err := schema.ParseGroupVersion(group.GroupVersion)
+		if err != nil {
+			return nil, fmt.Errorf("parsing group version %q: %w", group.GroupVersion, err)
+		}
+		if !strings.HasSuffix(gv.Group, k8s.APIDomainSuffix) || gv.Group == "core"+k8s.APIDomainSuffix {
+			continue
+		}
+		for _, resource := range group.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				// subresource
+				continue
+			}
+			if !sets.New[string](resource.Verbs...).Has("list") {
+				continue
+			}
+			gvks = append(gvks, gv.WithKind(resource.Kind))
+		}
+	}
+	return gvks, nil
+}
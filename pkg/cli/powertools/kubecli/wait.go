@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubecli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pollInterval is how often we check the object when waiting
+var pollInterval = 2 * time.Second
+
+// ReadyCondition is the Ready condition of a KCC object.
+type ReadyCondition struct {
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime string
+}
+
+// ReconcileRequestedAt returns the time a reconcile was last requested by setting the reconcile-cookie annotation, if any.
+func ReconcileRequestedAt(u *unstructured.Unstructured) (time.Time, bool) {
+	cookie, found := u.GetAnnotations()[k8s.InternalForceReconcileAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+	requested, err := time.Parse(time.RFC3339Nano, cookie)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return requested, true
+}
+
+// GetReadyCondition returns the Ready condition of the object, and whether it has been reconciled at its current generation.
+// A requested reconcile does not change the generation, so it is only observed once the Ready condition has transitioned since.
+func GetReadyCondition(u *unstructured.Unstructured) (condition ReadyCondition, observed bool) {
+	observedGeneration, found, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	observed = found && observedGeneration >= u.GetGeneration()
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		m, ok := c.(map[string]any)
+		if !ok || m["type"] != v1alpha1.ReadyConditionType {
+			continue
+		}
+		condition.Status, _ = m["status"].(string)
+		condition.Reason, _ = m["reason"].(string)
+		condition.Message, _ = m["message"].(string)
+		condition.LastTransitionTime, _ = m["lastTransitionTime"].(string)
+	}
+
+	if requested, ok := ReconcileRequestedAt(u); ok && observed {
+		// lastTransitionTime only has a precision of seconds
+		transitioned, err := time.Parse(time.RFC3339, condition.LastTransitionTime)
+		observed = err == nil && !transitioned.Before(requested.Truncate(time.Second))
+	}
+	return condition, observed
+}
+
+// IsUpToDate returns true if the object has been reconciled at its current generation and is UpToDate.
+func IsUpToDate(u *unstructured.Unstructured) bool {
+	condition, observed := GetReadyCondition(u)
+	return observed && condition.Status == "True" && condition.Reason == k8s.UpToDate
+}
+
+// failureReasons are the Ready condition reasons which indicate that reconciliation failed,
+// and will not succeed without a change to the object or to GCP.
+var failureReasons = map[string]bool{
+	k8s.CreateFailed:                 true,
+	k8s.UpdateFailed:                 true,
+	k8s.DeleteFailed:                 true,
+	k8s.DependencyInvalid:            true,
+	k8s.ManagementConflict:           true,
+	k8s.PreActuationTransformFailed:  true,
+	k8s.PostActuationTransformFailed: true,
+	k8s.Unmanaged:                    true,
+}
+
+// IsFailed returns true if the object has been reconciled at its current generation, and reconciliation failed.
+func IsFailed(u *unstructured.Unstructured) bool {
+	condition, observed := GetReadyCondition(u)
+	return observed && condition.Status == "False" && failureReasons[condition.Reason]
+}
+
+// WaitForUpToDate polls the object until it has been reconciled and is UpToDate, and returns the latest object.
+func (c *Client) WaitForUpToDate(ctx context.Context, u *unstructured.Unstructured, timeout time.Duration) (*unstructured.Unstructured, error) {
+	latest := &unstructured.Unstructured{}
+	latest.SetGroupVersionKind(u.GroupVersionKind())
+	key := client.ObjectKeyFromObject(u)
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Client.Get(ctx, key, latest); err != nil {
+			return false, fmt.Errorf("getting object %v: %w", key, err)
+		}
+		return IsUpToDate(latest), nil
+	})
+	if err != nil {
+		condition, _ := GetReadyCondition(latest)
+		return latest, fmt.Errorf("waiting for %v %v to be %v (last condition reason=%q, message=%q): %w", u.GetKind(), key, k8s.UpToDate, condition.Reason, condition.Message, err)
+	}
+	return latest, nil
+}
+
+// WaitForDeletion polls until the object no longer exists, which means any finalizers have completed.
+func (c *Client) WaitForDeletion(ctx context.Context, u *unstructured.Unstructured, timeout time.Duration) error {
+	latest := &unstructured.Unstructured{}
+	latest.SetGroupVersionKind(u.GroupVersionKind())
+	key := client.ObjectKeyFromObject(u)
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Client.Get(ctx, key, latest); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("getting object %v: %w", key, err)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for %v %v to be deleted (finalizers %v): %w", u.GetKind(), key, latest.GetFinalizers(), err)
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migratereconciler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/gcpclient"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/diffs"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/tf"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/config"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/registry"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/parent"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceconfig"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl"
+	dclclientconfig "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/clientconfig"
+	dclconversion "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/kcclite"
+	dcllivestate "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/livestate"
+	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/schema/dclschemaloader"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/servicemapping/servicemappingloader"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options configures the behaviour of the MigrateReconciler operation.
+type Options struct {
+	kubecli.ClusterOptions
+	kubecli.ObjectOptions
+
+	// To is the reconciler type we want to switch the object to
+	To string
+
+	// FieldOwner is the field-manager owner value to use when making changes
+	FieldOwner string
+
+	// DryRun is true if we should not actually make changes, just print the changes we would make
+	DryRun bool
+
+	// Yes is true if we should not ask for confirmation before making changes
+	Yes bool
+}
+
+func (o *Options) PopulateDefaults() {
+	o.ClusterOptions.PopulateDefaults()
+	o.ObjectOptions.PopulateDefaults()
+
+	o.FieldOwner = "migrate-reconciler"
+	o.DryRun = false
+	o.Yes = false
+}
+
+func (o *Options) Validate() error {
+	switch k8s.ReconcilerType(o.To) {
+	case k8s.ReconcilerTypeDirect, k8s.ReconcilerTypeTerraform, k8s.ReconcilerTypeDCL:
+		return nil
+	case "":
+		return fmt.Errorf("must specify the reconciler to switch to (use --to flag)")
+	default:
+		return fmt.Errorf("unsupported reconciler %q, should be %q, %q or %q", o.To, k8s.ReconcilerTypeDirect, k8s.ReconcilerTypeTerraform, k8s.ReconcilerTypeDCL)
+	}
+}
+
+func AddCommand(parent *cobra.Command) {
+	var options Options
+	options.PopulateDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "migrate-reconciler",
+		Short: "Switch an existing object to a different reconciler, if the switch would not change GCP (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return Run(ctx, cmd.InOrStdin(), cmd.OutOrStdout(), options)
+		},
+		Long: `
+migrate-reconciler switches an existing object between the terraform, DCL
+and direct reconcilers, by setting or removing the
+alpha.cnrm.cloud.google.com/reconciler annotation.
+
+The GCP resource is read through both the current and the new reconciler,
+and the differences are printed.  If the new reconciler would change any
+field of the GCP resource that is set in the spec, the switch is refused.
+References are resolved differently by each reconciler and are not compared.
+
+Examples
+
+Print the differences for the PubSubTopic "my-topic" in the namespace
+"my-namespace" when switching to the direct reconciler, without making
+changes:
+
+  config-connector powertools migrate-reconciler \
+    --namespace=my-namespace --name=my-topic \
+    --kind=PubSubTopic \
+    --to=direct \
+    --dry-run=true
+
+As before, but switch the reconciler after confirmation.
+
+  config-connector powertools migrate-reconciler \
+    --namespace=my-namespace --name=my-topic \
+    --kind=PubSubTopic \
+    --to=direct
+
+`,
+		Args: cobra.NoArgs,
+	}
+
+	options.ObjectOptions.AddFlags(cmd)
+	options.ClusterOptions.AddFlags(cmd)
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "Reconciler to switch to, one of direct, tf or dcl")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "dry-run mode will not make changes, but only print the changes it would make")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "switch the reconciler without asking for confirmation")
+
+	parent.AddCommand(cmd)
+}
+
+func Run(ctx context.Context, stdin io.Reader, stdout io.Writer, options Options) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	to := k8s.ReconcilerType(options.To)
+
+	// Impersonate the KCC service account, which is allowed to make changes
+	options.ClusterOptions.ImpersonateControllerManager(options.Namespace)
+	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	u, err := kubeClient.GetObject(ctx, options.ObjectOptions)
+	if err != nil {
+		return fmt.Errorf("getting object: %w", err)
+	}
+	gvk := u.GroupVersionKind()
+
+	from, err := parent.DetermineControllerType(ctx, kubeClient, gvk, u)
+	if err != nil {
+		return fmt.Errorf("determining current reconciler: %w", err)
+	}
+	if from == to {
+		fmt.Fprintf(stdout, "%s %s/%s already uses the %s reconciler\n", gvk.Kind, u.GetNamespace(), u.GetName(), to)
+		return nil
+	}
+	if !resourceconfig.IsControllerSupported(gvk, to) {
+		return fmt.Errorf("the %s reconciler is not supported for %s", to, gvk.GroupKind())
+	}
+
+	migrated := u.DeepCopy()
+	annotations := migrated.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if to == k8s.ReconcilerTypeDirect {
+		annotations[k8s.AlphaReconcilerAnnotation] = string(k8s.ReconcilerTypeDirect)
+	} else {
+		delete(annotations, k8s.AlphaReconcilerAnnotation)
+	}
+	migrated.SetAnnotations(annotations)
+	if got, err := parent.DetermineControllerType(ctx, kubeClient, gvk, migrated); err != nil {
+		return fmt.Errorf("determining new reconciler: %w", err)
+	} else if got != to {
+		return fmt.Errorf("cannot switch to the %s reconciler with the %s annotation, %s would still use the %s reconciler; use controllerOverrides in the ConfigConnectorContext instead", to, k8s.AlphaReconcilerAnnotation, gvk.Kind, got)
+	}
+
+	fromExport, err := exportObject(ctx, kubeClient, u, from)
+	if err != nil {
+		return fmt.Errorf("reading GCP resource through the %s reconciler: %w", from, err)
+	}
+	toExport, err := exportObject(ctx, kubeClient, u, to)
+	if err != nil {
+		return fmt.Errorf("reading GCP resource through the %s reconciler: %w", to, err)
+	}
+
+	printOpts := diffs.PrettyPrintOptions{PrintObjectInfo: true, Indent: "    "}
+
+	exportDiff, err := diffs.BuildObjectDiff(specOf(u, fromExport), specOf(u, toExport))
+	if err != nil {
+		return fmt.Errorf("building object diff: %w", err)
+	}
+	fmt.Fprintf(stdout, "\nGCP resource as read by the %s reconciler -> as read by the %s reconciler:\n\n", from, to)
+	exportDiff.PrettyPrintTo(printOpts, stdout)
+
+	updateDiff, err := buildUpdateDiff(u, toExport)
+	if err != nil {
+		return fmt.Errorf("building object diff: %w", err)
+	}
+	fmt.Fprintf(stdout, "\nChanges the %s reconciler would make to GCP:\n\n", to)
+	updateDiff.PrettyPrintTo(printOpts, stdout)
+	fmt.Fprintf(stdout, "\n")
+	if updateDiff.HasChanges() {
+		return fmt.Errorf("refusing to switch to the %s reconciler, it would change the GCP resource", to)
+	}
+
+	objectDiff, err := diffs.BuildObjectDiff(u, migrated)
+	if err != nil {
+		return fmt.Errorf("building object diff: %w", err)
+	}
+	fmt.Fprintf(stdout, "Changes to the object:\n\n")
+	objectDiff.PrettyPrintTo(printOpts, stdout)
+	fmt.Fprintf(stdout, "\n")
+
+	if options.DryRun {
+		fmt.Fprintf(stdout, "dry-run mode, not making changes\n")
+		return nil
+	}
+
+	if !options.Yes {
+		confirmed, err := kubecli.Confirm(stdin, stdout, fmt.Sprintf("Switch %s %s/%s from the %s reconciler to the %s reconciler?", gvk.Kind, u.GetNamespace(), u.GetName(), from, to))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	fmt.Fprintf(stdout, "applying changes\n")
+	if err := kubeClient.Update(ctx, migrated, client.FieldOwner(options.FieldOwner)); err != nil {
+		return fmt.Errorf("updating object: %w", err)
+	}
+
+	return nil
+}
+
+// exportObject reads the GCP resource of u through the given reconciler, and returns it as KRM.
+func exportObject(ctx context.Context, kubeClient client.Client, u *unstructured.Unstructured, reconcilerType k8s.ReconcilerType) (*unstructured.Unstructured, error) {
+	switch reconcilerType {
+	case k8s.ReconcilerTypeDirect:
+		controllerConfig := &config.ControllerConfig{
+			UserAgent: gcp.KCCUserAgent(),
+		}
+		if err := controllerConfig.Init(ctx); err != nil {
+			return nil, err
+		}
+		if err := registry.Init(ctx, controllerConfig); err != nil {
+			return nil, err
+		}
+		model, err := registry.GetModel(u.GroupVersionKind().GroupKind())
+		if err != nil {
+			return nil, err
+		}
+		adapter, err := model.AdapterForObject(ctx, kubeClient, u.DeepCopy())
+		if err != nil {
+			return nil, err
+		}
+		found, err := adapter.Find(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("GCP resource not found")
+		}
+		return adapter.Export(ctx)
+
+	case k8s.ReconcilerTypeTerraform:
+		tfProvider, err := tf.NewProvider(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		smLoader, err := servicemappingloader.New()
+		if err != nil {
+			return nil, fmt.Errorf("error creating service mapping loader: %w", err)
+		}
+		return gcpclient.NewWithKubeClient(tfProvider, smLoader, kubeClient).Get(ctx, u.DeepCopy())
+
+	case k8s.ReconcilerTypeDCL:
+		smLoader, err := servicemappingloader.New()
+		if err != nil {
+			return nil, fmt.Errorf("error creating service mapping loader: %w", err)
+		}
+		schemaLoader, err := dclschemaloader.New()
+		if err != nil {
+			return nil, fmt.Errorf("error creating DCL schema loader: %w", err)
+		}
+		converter := dclconversion.New(schemaLoader, dclmetadata.New())
+		dclConfig, err := dclclientconfig.New(ctx, dclclientconfig.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating DCL client config: %w", err)
+		}
+		schema, err := dclschemaloader.GetDCLSchemaForGVK(u.GroupVersionKind(), converter.MetadataLoader, converter.SchemaLoader)
+		if err != nil {
+			return nil, err
+		}
+		resource, err := dcl.NewResource(u.DeepCopy(), schema)
+		if err != nil {
+			return nil, err
+		}
+		liveLite, err := dcllivestate.FetchLiveState(ctx, resource, dclConfig, converter, smLoader, kubeClient)
+		if err != nil {
+			return nil, err
+		}
+		if liveLite == nil {
+			return nil, fmt.Errorf("GCP resource not found")
+		}
+		spec, status, err := kcclite.ResolveSpecAndStatus(liveLite, resource, converter.MetadataLoader)
+		if err != nil {
+			return nil, err
+		}
+		resource.Spec = spec
+		resource.Status = status
+		resource.Labels = liveLite.GetLabels()
+		return resource.MarshalAsUnstructured()
+
+	default:
+		return nil, fmt.Errorf("reading GCP resources through the %s reconciler is not supported", reconcilerType)
+	}
+}
+
+// buildUpdateDiff returns the changes from the GCP resource (as read by a reconciler) to the spec of the object,
+// only considering the fields that are set in the spec.
+func buildUpdateDiff(u *unstructured.Unstructured, export *unstructured.Unstructured) (*diffs.ObjectDiff, error) {
+	desired := specOf(u, u)
+	actual := specOf(u, export)
+	actual.Object["spec"] = pruneToFields(desired.Object["spec"], actual.Object["spec"])
+	return diffs.BuildObjectDiff(actual, desired)
+}
+
+// specOf returns an object identified as u, holding a copy of the spec of source without references.
+func specOf(u *unstructured.Unstructured, source *unstructured.Unstructured) *unstructured.Unstructured {
+	spec, _ := source.Object["spec"].(map[string]any)
+	out := &unstructured.Unstructured{Object: map[string]any{
+		"spec": withoutReferences(spec),
+	}}
+	out.SetGroupVersionKind(u.GroupVersionKind())
+	out.SetNamespace(u.GetNamespace())
+	out.SetName(u.GetName())
+	return out
+}
+
+// withoutReferences returns a copy of m, without the reference fields (fooRef and fooRefs).
+func withoutReferences(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if strings.HasSuffix(k, "Ref") || strings.HasSuffix(k, "Refs") {
+			continue
+		}
+		if child, ok := v.(map[string]any); ok {
+			v = withoutReferences(child)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// pruneToFields returns actual, keeping only the fields of objects that are set in desired.
+func pruneToFields(desired, actual any) any {
+	desiredMap, ok := desired.(map[string]any)
+	if !ok {
+		return actual
+	}
+	actualMap, ok := actual.(map[string]any)
+	if !ok {
+		return actual
+	}
+	out := make(map[string]any)
+	for k, desiredValue := range desiredMap {
+		actualValue, found := actualMap[k]
+		if !found {
+			continue
+		}
+		out[k] = pruneToFields(desiredValue, actualValue)
+	}
+	return out
+}
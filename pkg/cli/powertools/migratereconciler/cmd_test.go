@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migratereconciler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildUpdateDiff(t *testing.T) {
+	u := mustParse(t, `
+apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubTopic
+metadata:
+  name: my-topic
+  namespace: my-namespace
+spec:
+  messageRetentionDuration: 86400s
+  kmsKeyRef:
+    name: my-key
+  messageStoragePolicy:
+    allowedPersistenceRegions:
+    - us-central1
+`)
+
+	inSync := mustParse(t, `
+apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubTopic
+metadata:
+  name: my-topic
+spec:
+  resourceID: my-topic
+  messageRetentionDuration: 86400s
+  kmsKeyRef:
+    external: projects/p/locations/l/keyRings/r/cryptoKeys/my-key
+  messageStoragePolicy:
+    allowedPersistenceRegions:
+    - us-central1
+`)
+	diff, err := buildUpdateDiff(u, inSync)
+	if err != nil {
+		t.Fatalf("error building diff: %v", err)
+	}
+	if diff.HasChanges() {
+		var out bytes.Buffer
+		diff.PrintStructuredTo(&out)
+		t.Errorf("expected no changes, got:\n%v", out.String())
+	}
+
+	mismatched := mustParse(t, `
+apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubTopic
+metadata:
+  name: my-topic
+spec:
+  resourceID: my-topic
+  messageStoragePolicy:
+    allowedPersistenceRegions:
+    - us-east1
+`)
+	diff, err = buildUpdateDiff(u, mismatched)
+	if err != nil {
+		t.Fatalf("error building diff: %v", err)
+	}
+	var out bytes.Buffer
+	diff.PrintStructuredTo(&out)
+	want := "spec.messageRetentionDuration: <nil> -> 86400s\nspec.messageStoragePolicy.allowedPersistenceRegions.[0]: us-east1 -> us-central1\n"
+	if d := cmp.Diff(want, out.String()); d != "" {
+		t.Errorf("unexpected diff (-want +got):\n%v", d)
+	}
+}
+
+func mustParse(t *testing.T, s string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(s), &u.Object); err != nil {
+		t.Fatalf("error parsing yaml: %v", err)
+	}
+	return u
+}
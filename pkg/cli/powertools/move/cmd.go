@@ -0,0 +1,401 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package move
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/abandon"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/diffs"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// lastAppliedAnnotation is written by kubectl apply, and should not be carried over to the new object.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Options configures the behaviour of the Move operation.
+type Options struct {
+	kubecli.ClusterOptions
+	kubecli.ObjectOptions
+
+	// ToNamespace is the namespace we are moving the object to
+	ToNamespace string
+
+	// ToName is the name of the object in the new namespace; defaults to the current name
+	ToName string
+
+	// FieldOwner is the field-manager owner value to use when making changes
+	FieldOwner string
+
+	// Timeout is how long we wait for each step (deletion, adoption) to complete
+	Timeout time.Duration
+
+	// DryRun is true if we should not actually make changes, just print the changes we would make
+	DryRun bool
+
+	// Yes is true if we should not ask for confirmation before making changes
+	Yes bool
+}
+
+func (o *Options) PopulateDefaults() {
+	o.ClusterOptions.PopulateDefaults()
+	o.ObjectOptions.PopulateDefaults()
+
+	o.FieldOwner = "move"
+	o.Timeout = 5 * time.Minute
+	o.DryRun = false
+	o.Yes = false
+}
+
+func (o *Options) Validate() error {
+	if o.ToNamespace == "" {
+		return fmt.Errorf("must specify the namespace to move the object to (use --to-namespace flag)")
+	}
+	if o.ToNamespace == o.Namespace && (o.ToName == "" || o.ToName == o.Name) {
+		return fmt.Errorf("object is already named %s/%s", o.Namespace, o.Name)
+	}
+	return nil
+}
+
+func AddCommand(parent *cobra.Command) {
+	var options Options
+	options.PopulateDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move a KCC object to a different namespace, keeping the GCP resource (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return Run(ctx, cmd.InOrStdin(), cmd.OutOrStdout(), options)
+		},
+		Long: `
+move moves a KCC object to a different namespace (and optionally renames it)
+without deleting or recreating the GCP resource.
+
+The object is abandoned in its current namespace, by setting the
+cnrm.cloud.google.com/deletion-policy annotation to abandon and deleting it.
+It is then recreated in the target namespace with the same spec.resourceID,
+so that KCC acquires the existing GCP resource, and we wait for it to become
+UpToDate.  If the new object does not become UpToDate, it is abandoned and
+the original object is recreated in its original namespace.
+
+References without a namespace are qualified with the original namespace, so
+they keep pointing to the same objects.  If the object has no project
+reference or annotation and the namespaces map to different projects, the
+cnrm.cloud.google.com/project-id annotation is set to the original project.
+
+Examples
+
+Move the PubSubTopic "my-topic" from the namespace "team-a" to "team-b":
+
+  config-connector powertools move \
+    --namespace=team-a --name=my-topic \
+    --kind=PubSubTopic \
+    --to-namespace=team-b
+
+`,
+		Args: cobra.NoArgs,
+	}
+
+	options.ObjectOptions.AddFlags(cmd)
+	options.ClusterOptions.AddFlags(cmd)
+
+	cmd.Flags().StringVar(&options.ToNamespace, "to-namespace", options.ToNamespace, "namespace to move the object to")
+	cmd.Flags().StringVar(&options.ToName, "to-name", options.ToName, "name of the object in the new namespace, defaults to the current name")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "how long to wait for each step (deletion, adoption) to complete")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "dry-run mode will not make changes, but only print the changes it would make")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "move the object without asking for confirmation")
+
+	parent.AddCommand(cmd)
+}
+
+// newClient builds a client for making changes in the given namespace.
+func newClient(ctx context.Context, options Options, namespace string) (*kubecli.Client, error) {
+	clusterOptions := options.ClusterOptions
+	// Impersonate the KCC service account, which is allowed to make changes
+	clusterOptions.ImpersonateControllerManager(namespace)
+	return kubecli.NewClient(ctx, clusterOptions)
+}
+
+func Run(ctx context.Context, stdin io.Reader, stdout io.Writer, options Options) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if options.ToName == "" {
+		options.ToName = options.Name
+	}
+
+	sourceClient, err := newClient(ctx, options, options.Namespace)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	targetClient, err := newClient(ctx, options, options.ToNamespace)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	u, err := sourceClient.GetObject(ctx, options.ObjectOptions)
+	if err != nil {
+		return fmt.Errorf("getting object: %w", err)
+	}
+	if !kubecli.IsUpToDate(u) {
+		condition, _ := kubecli.GetReadyCondition(u)
+		return fmt.Errorf("refusing to move %s %s/%s, it is not %s (reason=%q, message=%q)", u.GetKind(), u.GetNamespace(), u.GetName(), k8s.UpToDate, condition.Reason, condition.Message)
+	}
+
+	hasResourceID, err := hasSpecField(ctx, sourceClient, u.GroupVersionKind(), "resourceID")
+	if err != nil {
+		return err
+	}
+	sourceProjectID, err := k8s.GetProjectIDForNamespace(ctx, sourceClient, options.Namespace)
+	if err != nil {
+		return err
+	}
+	targetProjectID, err := k8s.GetProjectIDForNamespace(ctx, targetClient, options.ToNamespace)
+	if err != nil {
+		return err
+	}
+	projectID := ""
+	if sourceProjectID != targetProjectID {
+		projectID = sourceProjectID
+	}
+
+	moved, err := buildMovedObject(u, options.ToNamespace, options.ToName, projectID, hasResourceID)
+	if err != nil {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(moved.GroupVersionKind())
+	if err := targetClient.Get(ctx, client.ObjectKeyFromObject(moved), existing); err == nil {
+		return fmt.Errorf("%s %s/%s already exists", moved.GetKind(), moved.GetNamespace(), moved.GetName())
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("checking for existing object: %w", err)
+	}
+
+	diff, err := diffs.BuildObjectDiff(cleanObject(u), moved)
+	if err != nil {
+		return fmt.Errorf("building object diff: %w", err)
+	}
+	fmt.Fprintf(stdout, "\n\n")
+	printOpts := diffs.PrettyPrintOptions{PrintObjectInfo: true, Indent: "    "}
+	diff.PrettyPrintTo(printOpts, stdout)
+	fmt.Fprintf(stdout, "\n\n")
+
+	if options.DryRun {
+		fmt.Fprintf(stdout, "dry-run mode, not making changes\n")
+		return nil
+	}
+
+	if !options.Yes {
+		confirmed, err := kubecli.Confirm(stdin, stdout, fmt.Sprintf("Move %s %s/%s to %s/%s?", u.GetKind(), u.GetNamespace(), u.GetName(), moved.GetNamespace(), moved.GetName()))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	if err := abandon.Abandon(ctx, stdout, sourceClient, u, options.FieldOwner, options.Timeout); err != nil {
+		return fmt.Errorf("abandoning object: %w", err)
+	}
+
+	if err := adopt(ctx, stdout, targetClient, moved, options); err != nil {
+		fmt.Fprintf(stdout, "adoption failed: %v\nrolling back\n", err)
+		if rollbackErr := rollback(ctx, stdout, sourceClient, targetClient, u, moved, options); rollbackErr != nil {
+			return fmt.Errorf("adopting object in namespace %q: %w (rolling back also failed: %v)", options.ToNamespace, err, rollbackErr)
+		}
+		return fmt.Errorf("adopting object in namespace %q (rolled back to namespace %q): %w", options.ToNamespace, options.Namespace, err)
+	}
+
+	fmt.Fprintf(stdout, "%s %s/%s moved to %s/%s\n", u.GetKind(), u.GetNamespace(), u.GetName(), moved.GetNamespace(), moved.GetName())
+	return nil
+}
+
+// adopt creates the object and waits for KCC to acquire the GCP resource.
+func adopt(ctx context.Context, stdout io.Writer, kubeClient *kubecli.Client, u *unstructured.Unstructured, options Options) error {
+	fmt.Fprintf(stdout, "creating %s %s/%s\n", u.GetKind(), u.GetNamespace(), u.GetName())
+	if err := kubeClient.Create(ctx, u.DeepCopy(), client.FieldOwner(options.FieldOwner)); err != nil {
+		return fmt.Errorf("creating object: %w", err)
+	}
+	fmt.Fprintf(stdout, "waiting for %s %s/%s to be %s\n", u.GetKind(), u.GetNamespace(), u.GetName(), k8s.UpToDate)
+	if _, err := kubeClient.WaitForUpToDate(ctx, u, options.Timeout); err != nil {
+		return err
+	}
+	return nil
+}
+
+// rollback abandons the moved object (if it was created) and recreates the original object.
+func rollback(ctx context.Context, stdout io.Writer, sourceClient, targetClient *kubecli.Client, original, moved *unstructured.Unstructured, options Options) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(moved.GroupVersionKind())
+	if err := targetClient.Get(ctx, client.ObjectKeyFromObject(moved), current); err == nil {
+		if err := abandon.Abandon(ctx, stdout, targetClient, current, options.FieldOwner, options.Timeout); err != nil {
+			return fmt.Errorf("abandoning moved object: %w", err)
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("getting moved object: %w", err)
+	}
+
+	restored := cleanObject(original)
+	restored.SetOwnerReferences(original.GetOwnerReferences())
+	if err := adopt(ctx, stdout, sourceClient, restored, options); err != nil {
+		return fmt.Errorf("restoring original object: %w", err)
+	}
+	return nil
+}
+
+// cleanObject returns a copy of u with only the user-specified fields, suitable for creating a new object.
+func cleanObject(u *unstructured.Unstructured) *unstructured.Unstructured {
+	out := &unstructured.Unstructured{Object: map[string]any{}}
+	out.SetAPIVersion(u.GetAPIVersion())
+	out.SetKind(u.GetKind())
+	out.SetNamespace(u.GetNamespace())
+	out.SetName(u.GetName())
+	if labels := u.GetLabels(); len(labels) != 0 {
+		out.SetLabels(labels)
+	}
+	annotations := u.GetAnnotations()
+	delete(annotations, lastAppliedAnnotation)
+	if len(annotations) != 0 {
+		out.SetAnnotations(annotations)
+	}
+	if spec, found, _ := unstructured.NestedMap(u.Object, "spec"); found {
+		out.Object["spec"] = spec
+	}
+	return out
+}
+
+// buildMovedObject builds the object to create in the target namespace, pointing at the same GCP resource as u.
+// If projectID is set, and u does not otherwise specify its project, the project-id annotation is set.
+func buildMovedObject(u *unstructured.Unstructured, toNamespace, toName string, projectID string, hasResourceID bool) (*unstructured.Unstructured, error) {
+	moved := cleanObject(u)
+	moved.SetNamespace(toNamespace)
+	moved.SetName(toName)
+
+	if _, found, _ := unstructured.NestedString(moved.Object, "spec", "resourceID"); !found {
+		if hasResourceID {
+			if err := unstructured.SetNestedField(moved.Object, u.GetName(), "spec", "resourceID"); err != nil {
+				return nil, fmt.Errorf("setting spec.resourceID: %w", err)
+			}
+		} else if toName != u.GetName() {
+			return nil, fmt.Errorf("%s does not support spec.resourceID, so it cannot be renamed", u.GetKind())
+		}
+	}
+
+	if spec, ok := moved.Object["spec"].(map[string]any); ok {
+		qualifyReferences(spec, u.GetNamespace())
+	}
+
+	if projectID != "" && !hasContainer(moved) {
+		annotations := moved.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[k8s.ProjectIDAnnotation] = projectID
+		moved.SetAnnotations(annotations)
+	}
+
+	return moved, nil
+}
+
+// hasContainer returns true if u specifies its project, folder or organization.
+func hasContainer(u *unstructured.Unstructured) bool {
+	for _, annotation := range []string{k8s.ProjectIDAnnotation, k8s.FolderIDAnnotation, k8s.OrgIDAnnotation} {
+		if _, found := k8s.GetAnnotation(annotation, u); found {
+			return true
+		}
+	}
+	for _, field := range []string{"projectRef", "folderRef", "organizationRef"} {
+		if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", field); found {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyReferences sets the namespace on references that rely on the namespace of the referencing object.
+func qualifyReferences(obj map[string]any, namespace string) {
+	for k, v := range obj {
+		isRef := strings.HasSuffix(k, "Ref") || strings.HasSuffix(k, "Refs")
+		switch v := v.(type) {
+		case map[string]any:
+			if isRef {
+				qualifyReference(v, namespace)
+			}
+			qualifyReferences(v, namespace)
+		case []any:
+			for _, item := range v {
+				m, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				if isRef {
+					qualifyReference(m, namespace)
+				}
+				qualifyReferences(m, namespace)
+			}
+		}
+	}
+}
+
+func qualifyReference(ref map[string]any, namespace string) {
+	if _, found := ref["name"]; !found {
+		return
+	}
+	if _, found := ref["namespace"]; found {
+		return
+	}
+	ref["namespace"] = namespace
+}
+
+// hasSpecField returns true if the CRD for gvk declares the given top-level spec field.
+func hasSpecField(ctx context.Context, kubeClient *kubecli.Client, gvk schema.GroupVersionKind, field string) (bool, error) {
+	mapping, err := kubeClient.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, fmt.Errorf("getting resource for %v: %w", gvk, err)
+	}
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+	key := types.NamespacedName{Name: mapping.Resource.Resource + "." + gvk.Group}
+	if err := kubeClient.Get(ctx, key, crd); err != nil {
+		return false, fmt.Errorf("getting CRD %q: %w", key.Name, err)
+	}
+
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]any)
+		if !ok || version["name"] != gvk.Version {
+			continue
+		}
+		_, found, _ := unstructured.NestedFieldNoCopy(version, "schema", "openAPIV3Schema", "properties", "spec", "properties", field)
+		return found, nil
+	}
+	return false, fmt.Errorf("version %q not found in CRD %q", gvk.Version, key.Name)
+}
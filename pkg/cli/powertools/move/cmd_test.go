@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package move
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildMovedObject(t *testing.T) {
+	u := parseObject(t, `
+apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubSubscription
+metadata:
+  name: sub1
+  namespace: team-a
+  resourceVersion: "123"
+  annotations:
+    cnrm.cloud.google.com/deletion-policy: abandon
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+  labels:
+    env: prod
+spec:
+  topicRef:
+    name: topic1
+  deadLetterPolicy:
+    deadLetterTopicRef:
+      external: projects/p/topics/dlq
+status:
+  observedGeneration: 1
+`)
+
+	moved, err := buildMovedObject(u, "team-b", "sub1", "project-a", true)
+	if err != nil {
+		t.Fatalf("error building moved object: %v", err)
+	}
+	want := parseObject(t, `
+apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubSubscription
+metadata:
+  name: sub1
+  namespace: team-b
+  annotations:
+    cnrm.cloud.google.com/deletion-policy: abandon
+    cnrm.cloud.google.com/project-id: project-a
+  labels:
+    env: prod
+spec:
+  resourceID: sub1
+  topicRef:
+    name: topic1
+    namespace: team-a
+  deadLetterPolicy:
+    deadLetterTopicRef:
+      external: projects/p/topics/dlq
+`)
+	if diff := cmp.Diff(want.Object, moved.Object); diff != "" {
+		t.Errorf("unexpected moved object (-want +got):\n%v", diff)
+	}
+
+	if _, err := buildMovedObject(u, "team-b", "sub2", "", false); err == nil {
+		t.Errorf("expected error renaming object without spec.resourceID")
+	}
+}
+
+func parseObject(t *testing.T, s string) *unstructured.Unstructured {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(s), &u.Object); err != nil {
+		t.Fatalf("error parsing object: %v", err)
+	}
+	return u
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/apis/k8s/v1alpha1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options configures the behaviour of the Reconcile operation.
+type Options struct {
+	kubecli.ClusterOptions
+	kubecli.SelectorOptions
+
+	// FieldOwner is the field-manager owner value to use when making changes
+	FieldOwner string
+
+	// DryRun is true if we should not actually make changes, just print the objects we would reconcile
+	DryRun bool
+}
+
+func (o *Options) PopulateDefaults() {
+	o.ClusterOptions.PopulateDefaults()
+
+	o.FieldOwner = "reconcile"
+	o.DryRun = false
+}
+
+func AddCommand(parent *cobra.Command) {
+	var options Options
+	options.PopulateDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Trigger an immediate reconcile of KCC objects (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return Run(ctx, cmd.OutOrStdout(), options)
+		},
+		Long: `
+reconcile triggers an immediate reconcile of the selected objects, by setting
+the ` + k8s.InternalForceReconcileAnnotation + ` annotation to the current time.
+The Ready condition of the objects is set to ` + k8s.Updating + ` until they are reconciled.
+
+Objects are selected by namespace (or all namespaces), kind and label.
+Use "powertools wait" to wait for a changed object to become UpToDate.
+
+Examples
+
+Reconcile all KCC objects in the namespace "my-namespace" with the label
+env=prod:
+
+  config-connector powertools reconcile \
+    --namespace=my-namespace --selector=env=prod
+
+Reconcile all PubSubTopics in all namespaces:
+
+  config-connector powertools reconcile \
+    --all-namespaces --kind=PubSubTopic
+
+`,
+		Args: cobra.NoArgs,
+	}
+
+	options.SelectorOptions.AddFlags(cmd)
+	options.ClusterOptions.AddFlags(cmd)
+
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", options.DryRun, "dry-run mode will not make changes, but only print the objects it would reconcile")
+
+	parent.AddCommand(cmd)
+}
+
+func Run(ctx context.Context, out io.Writer, options Options) error {
+	// Impersonate the KCC service account, which is allowed to make changes
+	options.ClusterOptions.ImpersonateControllerManager(options.Namespace)
+	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	objects, err := kubeClient.ListObjects(ctx, options.SelectorOptions)
+	if err != nil {
+		return fmt.Errorf("selecting objects: %w", err)
+	}
+	if len(objects) == 0 {
+		fmt.Fprintf(out, "no objects selected\n")
+		return nil
+	}
+
+	now := time.Now().UTC()
+	cookie := now.Format(time.RFC3339Nano)
+	for _, u := range objects {
+		if options.DryRun {
+			fmt.Fprintf(out, "%s %s/%s: would reconcile (dry-run)\n", u.GetKind(), u.GetNamespace(), u.GetName())
+			continue
+		}
+
+		// Requesting a reconcile does not change the generation, so we mark the object as updating, which
+		// makes the Ready condition transition when the reconcile completes, for "powertools wait".
+		original := u.DeepCopy()
+		if err := setUpdatingCondition(u, now); err != nil {
+			return fmt.Errorf("setting Ready condition of %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		if err := kubeClient.Status().Patch(ctx, u, client.MergeFrom(original), client.FieldOwner(options.FieldOwner)); err != nil {
+			return fmt.Errorf("updating status of %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+
+		original = u.DeepCopy()
+		k8s.SetAnnotation(k8s.InternalForceReconcileAnnotation, cookie, u)
+		if err := kubeClient.Patch(ctx, u, client.MergeFrom(original), client.FieldOwner(options.FieldOwner)); err != nil {
+			return fmt.Errorf("requesting reconcile of %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		fmt.Fprintf(out, "%s %s/%s: reconcile requested\n", u.GetKind(), u.GetNamespace(), u.GetName())
+	}
+	return nil
+}
+
+// setUpdatingCondition replaces the Ready condition of the object with an Updating condition, which transitioned at the given time.
+func setUpdatingCondition(u *unstructured.Unstructured, now time.Time) error {
+	updating := map[string]any{
+		"type":               v1alpha1.ReadyConditionType,
+		"status":             "False",
+		"reason":             k8s.Updating,
+		"message":            k8s.UpdatingMessage,
+		"lastTransitionTime": now.Format(time.RFC3339),
+	}
+
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return err
+	}
+	var newConditions []any
+	for _, c := range conditions {
+		if m, ok := c.(map[string]any); ok && m["type"] == v1alpha1.ReadyConditionType {
+			continue
+		}
+		newConditions = append(newConditions, c)
+	}
+	newConditions = append(newConditions, updating)
+	return unstructured.SetNestedSlice(u.Object, newConditions, "status", "conditions")
+}
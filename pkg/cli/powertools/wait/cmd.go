@@ -0,0 +1,256 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/powertools/kubecli"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourcewatcher"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// Options configures the behaviour of the Wait operation.
+type Options struct {
+	kubecli.ClusterOptions
+	kubecli.SelectorOptions
+
+	// Timeout is how long we wait for all the objects to be UpToDate or failed
+	Timeout time.Duration
+}
+
+func (o *Options) PopulateDefaults() {
+	o.ClusterOptions.PopulateDefaults()
+
+	o.Timeout = 10 * time.Minute
+}
+
+func AddCommand(parent *cobra.Command) {
+	var options Options
+	options.PopulateDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for KCC objects to be UpToDate or to fail (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return Run(ctx, cmd.OutOrStdout(), options)
+		},
+		Long: `
+wait waits until each of the selected objects has been reconciled at its
+current generation, and is either UpToDate or has failed.  Changes to the
+Ready condition are printed as they happen, and the error messages of the
+failed objects are printed at the end.  Objects for which a reconcile was
+requested with "powertools reconcile" must also have been reconciled since.
+
+The command fails if any object failed, was deleted, or did not finish
+before the timeout.
+
+Examples
+
+Wait for all KCC objects in the namespace "my-namespace" with the label
+env=prod:
+
+  config-connector powertools wait \
+    --namespace=my-namespace --selector=env=prod --timeout=20m
+
+`,
+		Args: cobra.NoArgs,
+	}
+
+	options.SelectorOptions.AddFlags(cmd)
+	options.ClusterOptions.AddFlags(cmd)
+
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "how long to wait for the objects")
+
+	parent.AddCommand(cmd)
+}
+
+func Run(ctx context.Context, out io.Writer, options Options) error {
+	if options.ImpersonateUser != "" {
+		options.ClusterOptions.Impersonate = &rest.ImpersonationConfig{
+			UserName: options.ImpersonateUser,
+			Groups:   options.ImpersonateGroups,
+		}
+	}
+	kubeClient, err := kubecli.NewClient(ctx, options.ClusterOptions)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	objects, err := kubeClient.ListObjects(ctx, options.SelectorOptions)
+	if err != nil {
+		return fmt.Errorf("selecting objects: %w", err)
+	}
+	if len(objects) == 0 {
+		fmt.Fprintf(out, "no objects selected\n")
+		return nil
+	}
+
+	watcher, err := resourcewatcher.New(kubeClient.RESTConfig, klog.FromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("creating resource watcher: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	watchObjects := func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) (watch.Interface, error) {
+		return watcher.WatchResources(ctx, namespace, gvk)
+	}
+	return waitForObjects(ctx, out, objects, watchObjects)
+}
+
+// watchFunc starts a watch on all the objects of a kind in a namespace.
+type watchFunc func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) (watch.Interface, error)
+
+// watchKey identifies the objects which share a watch.
+type watchKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+}
+
+// result is the final state of an object we waited for.
+type result struct {
+	u         *unstructured.Unstructured
+	condition kubecli.ReadyCondition
+	upToDate  bool
+	err       error
+}
+
+// waitForObjects watches the objects until each is UpToDate or failed, printing changes to the Ready condition.
+// We start one watch per kind and namespace, rather than one per object.
+func waitForObjects(ctx context.Context, out io.Writer, objects []*unstructured.Unstructured, watchObjects watchFunc) error {
+	var mutex sync.Mutex
+	printf := func(format string, args ...any) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fmt.Fprintf(out, format, args...)
+	}
+
+	results := make([]result, len(objects))
+	var keys []watchKey
+	groups := make(map[watchKey][]*result)
+	for i, u := range objects {
+		results[i].u = u
+		results[i].condition, _ = kubecli.GetReadyCondition(u)
+
+		key := watchKey{gvk: u.GroupVersionKind(), namespace: u.GetNamespace()}
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], &results[i])
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			waitForGroup(ctx, key, groups[key], watchObjects, printf)
+		}()
+	}
+	wg.Wait()
+
+	upToDate := 0
+	var failed []result
+	for _, r := range results {
+		if r.upToDate {
+			upToDate++
+		} else {
+			failed = append(failed, r)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d of %d objects are %s\n", upToDate, len(results), k8s.UpToDate)
+	if len(failed) == 0 {
+		return nil
+	}
+	fmt.Fprintf(out, "\nFailed objects:\n\n")
+	for _, r := range failed {
+		fmt.Fprintf(out, "    %s %s/%s:\n", r.u.GetKind(), r.u.GetNamespace(), r.u.GetName())
+		if r.err != nil {
+			fmt.Fprintf(out, "        error: %v\n", r.err)
+		}
+		fmt.Fprintf(out, "        reason: %s\n", r.condition.Reason)
+		fmt.Fprintf(out, "        message: %s\n", r.condition.Message)
+	}
+	fmt.Fprintf(out, "\n")
+	return fmt.Errorf("%d of %d objects are not %s", len(failed), len(results), k8s.UpToDate)
+}
+
+// waitForGroup watches the objects of one kind in one namespace, until each is UpToDate, failed or deleted.
+func waitForGroup(ctx context.Context, key watchKey, group []*result, watchObjects watchFunc, printf func(format string, args ...any)) {
+	pending := make(map[string]*result, len(group))
+	for _, r := range group {
+		pending[r.u.GetName()] = r
+	}
+
+	w, err := watchObjects(ctx, key.gvk, key.namespace)
+	if err != nil {
+		for _, r := range group {
+			r.err = err
+		}
+		return
+	}
+	defer w.Stop()
+
+	printed := make(map[string]bool)
+	err = resourcewatcher.WatchResourceUntil(ctx, w, func(eventType watch.EventType, latest *unstructured.Unstructured) (bool, error) {
+		name := latest.GetName()
+		r, found := pending[name]
+		if !found {
+			// not selected, or already done
+			return false, nil
+		}
+
+		if eventType == watch.Deleted {
+			r.err = fmt.Errorf("object was deleted")
+			delete(pending, name)
+			return len(pending) == 0, nil
+		}
+
+		condition, _ := kubecli.GetReadyCondition(latest)
+		if !printed[name] || condition != r.condition {
+			printf("%s %s/%s: Ready=%s %s: %s\n", latest.GetKind(), latest.GetNamespace(), name, condition.Status, condition.Reason, condition.Message)
+		}
+		printed[name] = true
+		r.condition = condition
+
+		if kubecli.IsUpToDate(latest) {
+			r.upToDate = true
+			delete(pending, name)
+		} else if kubecli.IsFailed(latest) {
+			delete(pending, name)
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		for _, r := range pending {
+			r.err = err
+		}
+	}
+}
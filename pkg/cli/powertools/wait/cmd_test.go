@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestWaitForObjects(t *testing.T) {
+	topic := newObject("topic1", 2, 1, "False", "Updating", "Update in progress")
+	bucket := newObject("bucket1", 1, 1, "False", "Updating", "Update in progress")
+
+	// Both objects share a single watch, which also sees objects that were not selected.
+	watcher := watch.NewFakeWithChanSize(10, false)
+	watcher.Add(topic)
+	watcher.Add(bucket)
+	watcher.Add(newObject("other", 1, 1, "False", "UpdateFailed", "not selected"))
+	watcher.Modify(newObject("topic1", 2, 2, "True", "UpToDate", "The resource is up to date"))
+	watcher.Modify(newObject("bucket1", 1, 1, "False", "UpdateFailed", "permission denied"))
+
+	watches := 0
+	watchObjects := func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) (watch.Interface, error) {
+		watches++
+		return watcher, nil
+	}
+
+	var out bytes.Buffer
+	err := waitForObjects(context.Background(), &out, []*unstructured.Unstructured{topic, bucket}, watchObjects)
+	if err == nil {
+		t.Fatalf("expected error when an object failed")
+	}
+	if watches != 1 {
+		t.Errorf("expected a single watch, got %d", watches)
+	}
+
+	for _, want := range []string{
+		"PubSubTopic ns1/topic1: Ready=True UpToDate: The resource is up to date\n",
+		"PubSubTopic ns1/bucket1: Ready=False UpdateFailed: permission denied\n",
+		"1 of 2 objects are UpToDate\n",
+		"        message: permission denied\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "not selected") {
+		t.Errorf("expected output to only contain the selected objects, got:\n%v", out.String())
+	}
+}
+
+func TestWaitForRequestedReconcile(t *testing.T) {
+	// A requested reconcile does not change the generation, so the object is only done
+	// once the Ready condition has transitioned after the reconcile-cookie.
+	requested := newObject("topic1", 1, 1, "True", "UpToDate", "The resource is up to date")
+	setReconcileRequested(requested, "2026-01-02T03:04:05.678Z", "2026-01-01T00:00:00Z")
+	reconciled := newObject("topic1", 1, 1, "True", "UpToDate", "The resource is up to date")
+	setReconcileRequested(reconciled, "2026-01-02T03:04:05.678Z", "2026-01-02T03:04:05Z")
+
+	watcher := watch.NewFakeWithChanSize(10, false)
+	watcher.Add(requested)
+	watchObjects := func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) (watch.Interface, error) {
+		return watcher, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var out bytes.Buffer
+	if err := waitForObjects(ctx, &out, []*unstructured.Unstructured{requested}, watchObjects); err == nil {
+		t.Errorf("expected error when the object has not been reconciled since the request, got:\n%v", out.String())
+	}
+
+	watcher = watch.NewFakeWithChanSize(10, false)
+	watcher.Add(requested)
+	watcher.Modify(reconciled)
+	out.Reset()
+	if err := waitForObjects(context.Background(), &out, []*unstructured.Unstructured{requested}, watchObjects); err != nil {
+		t.Errorf("unexpected error: %v\n%v", err, out.String())
+	}
+}
+
+func setReconcileRequested(u *unstructured.Unstructured, cookie string, lastTransitionTime string) {
+	u.SetAnnotations(map[string]string{k8s.InternalForceReconcileAnnotation: cookie})
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	conditions[0].(map[string]any)["lastTransitionTime"] = lastTransitionTime
+	_ = unstructured.SetNestedSlice(u.Object, conditions, "status", "conditions")
+}
+
+func newObject(name string, generation, observedGeneration int64, status, reason, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+		"kind":       "PubSubTopic",
+		"metadata": map[string]any{
+			"name":       name,
+			"namespace":  "ns1",
+			"generation": generation,
+		},
+		"status": map[string]any{
+			"observedGeneration": observedGeneration,
+			"conditions": []any{
+				map[string]any{
+					"type":    "Ready",
+					"status":  status,
+					"reason":  reason,
+					"message": message,
+				},
+			},
+		},
+	}}
+	return u
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadObjects reads the Config Connector resources from a YAML file, a directory of YAML files
+// (as written by bulk-export) or a .tar.gz archive (as written by kompanion export).
+// Objects which are not Config Connector resources, such as kustomization files, are skipped.
+func LoadObjects(path string) ([]*unstructured.Unstructured, error) {
+	return loadDocuments(path, isConfigConnectorResource)
+}
+
+// LoadCRDs reads the CustomResourceDefinitions from a YAML file, a directory of YAML files or a .tar.gz archive,
+// such as the crds.yaml of a Config Connector release bundle.
+func LoadCRDs(path string) ([]*unstructured.Unstructured, error) {
+	return loadDocuments(path, isCRD)
+}
+
+func loadDocuments(path string, keep func(u *unstructured.Unstructured) bool) ([]*unstructured.Unstructured, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading '%v': %w", path, err)
+	}
+	if fi.IsDir() {
+		return loadDirectory(path, keep)
+	}
+	if isTarball(path) {
+		return loadTarball(path, keep)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading '%v': %w", path, err)
+	}
+	return parseDocuments(path, b, keep)
+}
+
+func isTarball(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+func isYAMLFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func loadDirectory(dir string, keep func(u *unstructured.Unstructured) bool) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading '%v': %w", path, err)
+		}
+		fileObjects, err := parseDocuments(path, b, keep)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, fileObjects...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking '%v': %w", dir, err)
+	}
+	return objects, nil
+}
+
+func loadTarball(path string, keep func(u *unstructured.Unstructured) bool) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening '%v': %w", path, err)
+	}
+	defer f.Close()
+	gzReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gzip stream of '%v': %w", path, err)
+	}
+	defer gzReader.Close()
+
+	// sort the entries so that the result does not depend on the order of the archive
+	entries := make(map[string][]byte)
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading tar entry of '%v': %w", path, err)
+		}
+		if header.Typeflag != tar.TypeReg || !isYAMLFile(header.Name) {
+			continue
+		}
+		b, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%v' from '%v': %w", header.Name, path, err)
+		}
+		entries[header.Name] = b
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var objects []*unstructured.Unstructured
+	for _, name := range names {
+		fileObjects, err := parseDocuments(name, entries[name], keep)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+func parseDocuments(source string, b []byte, keep func(u *unstructured.Unstructured) bool) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("error parsing '%v': %w", source, err)
+		}
+		if len(u.Object) == 0 || !keep(u) {
+			continue
+		}
+		objects = append(objects, u)
+	}
+	return objects, nil
+}
+
+func isConfigConnectorResource(u *unstructured.Unstructured) bool {
+	group := u.GroupVersionKind().Group
+	return strings.HasSuffix(group, ".cnrm.cloud.google.com") && !strings.HasPrefix(group, "core.")
+}
+
+func isCRD(u *unstructured.Unstructured) bool {
+	return u.GroupVersionKind().GroupKind() == schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var testFiles = map[string]string{
+	"kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- project1
+`,
+	"project1/pubsub/pubsubtopic-topic1.yaml": `apiVersion: pubsub.cnrm.cloud.google.com/v1beta1
+kind: PubSubTopic
+metadata:
+  name: topic1
+  annotations:
+    cnrm.cloud.google.com/project-id: project1
+`,
+	"ns1/StorageBucket_bucket1.yaml": `apiVersion: storage.cnrm.cloud.google.com/v1beta1
+kind: StorageBucket
+metadata:
+  name: bucket1
+  namespace: ns1
+---
+apiVersion: core.cnrm.cloud.google.com/v1beta1
+kind: ConfigConnectorContext
+metadata:
+  name: configconnectorcontext.core.cnrm.cloud.google.com
+  namespace: ns1
+`,
+	"README.md": "not a manifest",
+}
+
+func TestLoadObjectsFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for name, contents := range testFiles {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("error creating directory: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0o644); err != nil {
+			t.Fatalf("error writing '%v': %v", p, err)
+		}
+	}
+	objects, err := LoadObjects(dir)
+	if err != nil {
+		t.Fatalf("error loading objects: %v", err)
+	}
+	want := []string{"StorageBucket/ns1/bucket1", "PubSubTopic//topic1"}
+	if diff := cmp.Diff(want, objectIDs(objects)); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%v", diff)
+	}
+}
+
+func TestLoadObjectsFromTarball(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "export.tar.gz")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatalf("error creating '%v': %v", p, err)
+	}
+	gzWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzWriter)
+	for name, contents := range testFiles {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(contents)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("error writing tar header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(contents)); err != nil {
+			t.Fatalf("error writing tar entry: %v", err)
+		}
+	}
+	for _, c := range []interface{ Close() error }{tarWriter, gzWriter, f} {
+		if err := c.Close(); err != nil {
+			t.Fatalf("error closing tarball: %v", err)
+		}
+	}
+
+	objects, err := LoadObjects(p)
+	if err != nil {
+		t.Fatalf("error loading objects: %v", err)
+	}
+	want := []string{"StorageBucket/ns1/bucket1", "PubSubTopic//topic1"}
+	if diff := cmp.Diff(want, objectIDs(objects)); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%v", diff)
+	}
+}
+
+func objectIDs(objects []*unstructured.Unstructured) []string {
+	var ids []string
+	for _, u := range objects {
+		ids = append(ids, u.GetKind()+"/"+u.GetNamespace()+"/"+u.GetName())
+	}
+	return ids
+}
@@ -0,0 +1,406 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/preview"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/config"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/directbase"
+	_ "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/register"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/registry"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/lifecyclehandler"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/gcp"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	resourcemanagerpb "cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kubebuilder-declarative-pattern/mockkubeapiserver"
+)
+
+const (
+	// AccessToken is the token accepted by the mock GCP.
+	AccessToken = "dummytoken"
+
+	fakeGoogleServiceAccount = "offline-preview@offline-preview.iam.gserviceaccount.com"
+
+	configConnectorCRD        = "configconnectors.core.cnrm.cloud.google.com"
+	configConnectorContextCRD = "configconnectorcontexts.core.cnrm.cloud.google.com"
+)
+
+// WorldOptions configures a World.
+type WorldOptions struct {
+	// CRDs are the CustomResourceDefinitions to install, such as the ones returned by LoadCRDs.
+	// The ConfigConnector and ConfigConnectorContext objects are only created if their CRDs are included.
+	CRDs []*unstructured.Unstructured
+}
+
+// World is an in-process kube-apiserver and mock GCP, standing in for a real cluster and real GCP
+// so that the preview can run without credentials.
+type World struct {
+	// RESTConfig connects to the in-process kube-apiserver.
+	RESTConfig *rest.Config
+	// GCPHTTPClient sends requests to the mock GCP.
+	GCPHTTPClient *http.Client
+
+	kubeAPIServer *mockkubeapiserver.MockKubeAPIServer
+	kubeClient    client.Client
+	cancel        context.CancelFunc
+
+	namespaces map[string]bool
+	projects   map[string]bool
+	// hasCCCs is true if the ConfigConnectorContext CRD is installed.
+	hasCCCs bool
+	// unsupported are the objects whose GCP resources cannot be seeded.
+	unsupported map[preview.GKNN]bool
+}
+
+// NewWorld starts the in-process kube-apiserver and mock GCP, with the Config Connector CRDs installed.
+// Close must be called to stop them.
+func NewWorld(ctx context.Context, opts WorldOptions) (*World, error) {
+	w := &World{
+		namespaces:  make(map[string]bool),
+		projects:    make(map[string]bool),
+		unsupported: make(map[preview.GKNN]bool),
+	}
+	if err := w.start(ctx, opts); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *World) start(ctx context.Context, opts WorldOptions) error {
+	kubeAPIServer, err := mockkubeapiserver.NewMockKubeAPIServer(":0")
+	if err != nil {
+		return fmt.Errorf("error building mock kube-apiserver: %w", err)
+	}
+	addr, err := kubeAPIServer.StartServing()
+	if err != nil {
+		return fmt.Errorf("error starting mock kube-apiserver: %w", err)
+	}
+	w.kubeAPIServer = kubeAPIServer
+	w.RESTConfig = &rest.Config{
+		Host: addr.String(),
+		ContentConfig: rest.ContentConfig{
+			ContentType: "application/json",
+		},
+		// the apiserver is in-process, there is no need to throttle requests
+		QPS:   1000.0,
+		Burst: 2000.0,
+	}
+	kubeClient, err := client.New(w.RESTConfig, client.Options{})
+	if err != nil {
+		return fmt.Errorf("error building kube client: %w", err)
+	}
+	w.kubeClient = kubeClient
+
+	if len(opts.CRDs) == 0 {
+		return fmt.Errorf("no CRDs to install")
+	}
+	hasCCs := false
+	for _, crd := range opts.CRDs {
+		if err := w.kubeClient.Create(ctx, crd.DeepCopy()); err != nil {
+			return fmt.Errorf("error creating CRD '%v': %w", crd.GetName(), err)
+		}
+		switch crd.GetName() {
+		case configConnectorCRD:
+			hasCCs = true
+		case configConnectorContextCRD:
+			w.hasCCCs = true
+		}
+	}
+
+	mockCtx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	mockCloud, err := mockgcp.NewMockRoundTripper(mockCtx, w.kubeClient, storage.NewInMemoryStorage())
+	if err != nil {
+		return fmt.Errorf("error building mock GCP: %w", err)
+	}
+	go func() {
+		if err := mockCloud.Run(mockCtx); err != nil {
+			klog.Errorf("error from mock GCP grpc server: %v", err)
+		}
+	}()
+	w.GCPHTTPClient = &http.Client{Transport: mockCloud}
+
+	if !hasCCs {
+		return nil
+	}
+	cc := &unstructured.Unstructured{}
+	cc.SetGroupVersionKind(schema.GroupVersionKind{Group: "core.cnrm.cloud.google.com", Version: "v1beta1", Kind: "ConfigConnector"})
+	cc.SetName("configconnector.core.cnrm.cloud.google.com")
+	if err := unstructured.SetNestedField(cc.Object, "namespaced", "spec", "mode"); err != nil {
+		return fmt.Errorf("error setting spec.mode: %w", err)
+	}
+	if err := w.kubeClient.Create(ctx, cc); err != nil {
+		return fmt.Errorf("error creating ConfigConnector: %w", err)
+	}
+	return nil
+}
+
+// Close stops the in-process kube-apiserver and mock GCP.
+func (w *World) Close() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.kubeAPIServer != nil {
+		return w.kubeAPIServer.Stop()
+	}
+	return nil
+}
+
+// Seed creates the objects in the kube-apiserver and creates the corresponding GCP resources in the mock GCP,
+// so that the objects are up to date when the preview starts.
+// The GCP resources are created by the direct controllers. Objects without a direct controller are only
+// created in the kube-apiserver, so that references to them can be resolved, and are returned by Unsupported.
+func (w *World) Seed(ctx context.Context, objects []*unstructured.Unstructured) error {
+	var seeded []*unstructured.Unstructured
+	for _, obj := range objects {
+		u, err := w.prepare(ctx, obj)
+		if err != nil {
+			return err
+		}
+		// status is written by the direct controller when the GCP resource is created
+		delete(u.Object, "status")
+		if err := w.kubeClient.Create(ctx, u); err != nil {
+			return fmt.Errorf("error creating %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		seeded = append(seeded, u)
+	}
+
+	kccConfig := &config.ControllerConfig{
+		HTTPClient:     w.GCPHTTPClient,
+		GCPTokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: AccessToken}),
+		UserAgent:      gcp.KCCUserAgent(),
+	}
+	if err := registry.Init(ctx, kccConfig); err != nil {
+		return fmt.Errorf("error initializing direct controllers: %w", err)
+	}
+
+	var pending []*unstructured.Unstructured
+	for _, u := range seeded {
+		if !w.isSupported(u) {
+			klog.Warningf("%v %v/%v does not have a direct controller and will not be seeded in the mock GCP", u.GetKind(), u.GetNamespace(), u.GetName())
+			continue
+		}
+		pending = append(pending, u)
+	}
+
+	// Resources may depend on each other, so we keep seeding until no more progress can be made.
+	for len(pending) != 0 {
+		var failed []*unstructured.Unstructured
+		var errs []error
+		for _, u := range pending {
+			if err := w.seedGCP(ctx, u); err != nil {
+				failed = append(failed, u)
+				errs = append(errs, fmt.Errorf("error seeding %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err))
+			}
+		}
+		if len(failed) == len(pending) {
+			return errors.Join(errs...)
+		}
+		pending = failed
+	}
+	return nil
+}
+
+func (w *World) seedGCP(ctx context.Context, u *unstructured.Unstructured) error {
+	// refresh the object, the status may have been updated by a previous attempt
+	if err := w.kubeClient.Get(ctx, client.ObjectKeyFromObject(u), u); err != nil {
+		return err
+	}
+	model, err := registry.GetModel(u.GroupVersionKind().GroupKind())
+	if err != nil {
+		return err
+	}
+	adapter, err := model.AdapterForObject(ctx, w.kubeClient, u)
+	if err != nil {
+		return err
+	}
+	found, err := adapter.Find(ctx)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	// events are not needed when seeding, a FakeRecorder without a channel drops them
+	lifecycleHandler := lifecyclehandler.NewLifecycleHandler(w.kubeClient, &record.FakeRecorder{})
+	return adapter.Create(ctx, directbase.NewCreateOperation(lifecycleHandler, w.kubeClient, u))
+}
+
+// isSupported returns true if the GCP resource of the object can be seeded, and records the object otherwise.
+func (w *World) isSupported(u *unstructured.Unstructured) bool {
+	if registry.IsDirectByGK(u.GroupVersionKind().GroupKind()) {
+		return true
+	}
+	w.unsupported[preview.GKNN{
+		Group:     u.GroupVersionKind().Group,
+		Kind:      u.GetKind(),
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+	}] = true
+	return false
+}
+
+// Unsupported returns the seeded or applied objects whose GCP resources cannot be seeded, because they do not have
+// a direct controller. The preview cannot tell whether they would change, so they should be reported as unsupported.
+func (w *World) Unsupported() []preview.GKNN {
+	var result []preview.GKNN
+	for gknn := range w.unsupported {
+		result = append(result, gknn)
+	}
+	return result
+}
+
+// Apply creates or updates the objects in the kube-apiserver, without changing the mock GCP.
+// It is used to apply the desired state that is previewed against the seeded state.
+func (w *World) Apply(ctx context.Context, objects []*unstructured.Unstructured) error {
+	for _, obj := range objects {
+		u, err := w.prepare(ctx, obj)
+		if err != nil {
+			return err
+		}
+		w.isSupported(u)
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(u.GroupVersionKind())
+		err = w.kubeClient.Get(ctx, client.ObjectKeyFromObject(u), existing)
+		if apierrors.IsNotFound(err) {
+			delete(u.Object, "status")
+			if err := w.kubeClient.Create(ctx, u); err != nil {
+				return fmt.Errorf("error creating %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error getting %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		u.SetResourceVersion(existing.GetResourceVersion())
+		// keep the status written when seeding, it holds the externalRef of the GCP resource
+		if status, found := existing.Object["status"]; found {
+			u.Object["status"] = status
+		}
+		if err := w.kubeClient.Update(ctx, u); err != nil {
+			return fmt.Errorf("error updating %v %v/%v: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// prepare returns a copy of obj that can be written to the kube-apiserver,
+// creating its namespace and GCP project if needed.
+// Exported objects do not always have a namespace, those are placed in a namespace named after their project.
+func (w *World) prepare(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	u := obj.DeepCopy()
+	u.SetResourceVersion("")
+	u.SetUID("")
+	u.SetGeneration(0)
+	u.SetCreationTimestamp(metav1.Time{})
+	u.SetManagedFields(nil)
+
+	projectID := getProjectID(u)
+	if projectID == "" {
+		return nil, fmt.Errorf("cannot determine the project of %v %v/%v, set the '%v' annotation", u.GetKind(), u.GetNamespace(), u.GetName(), k8s.ProjectIDAnnotation)
+	}
+	if u.GetNamespace() == "" {
+		u.SetNamespace(projectID)
+	}
+	if err := w.ensureProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := w.ensureNamespace(ctx, u.GetNamespace(), projectID); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func getProjectID(u *unstructured.Unstructured) string {
+	if projectID := u.GetAnnotations()[k8s.ProjectIDAnnotation]; projectID != "" {
+		return projectID
+	}
+	if external, _, _ := unstructured.NestedString(u.Object, "spec", "projectRef", "external"); external != "" {
+		return strings.TrimPrefix(external, "projects/")
+	}
+	return u.GetNamespace()
+}
+
+func (w *World) ensureNamespace(ctx context.Context, namespace, projectID string) error {
+	if w.namespaces[namespace] {
+		return nil
+	}
+	ns := &unstructured.Unstructured{}
+	ns.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"})
+	ns.SetName(namespace)
+	ns.SetAnnotations(map[string]string{k8s.ProjectIDAnnotation: projectID})
+	if err := w.kubeClient.Create(ctx, ns); err != nil {
+		return fmt.Errorf("error creating namespace '%v': %w", namespace, err)
+	}
+	w.namespaces[namespace] = true
+	if !w.hasCCCs {
+		return nil
+	}
+
+	ccc := &unstructured.Unstructured{}
+	ccc.SetGroupVersionKind(schema.GroupVersionKind{Group: "core.cnrm.cloud.google.com", Version: "v1beta1", Kind: "ConfigConnectorContext"})
+	ccc.SetName("configconnectorcontext.core.cnrm.cloud.google.com")
+	ccc.SetNamespace(namespace)
+	if err := unstructured.SetNestedField(ccc.Object, fakeGoogleServiceAccount, "spec", "googleServiceAccount"); err != nil {
+		return fmt.Errorf("error setting spec.googleServiceAccount: %w", err)
+	}
+	if err := w.kubeClient.Create(ctx, ccc); err != nil {
+		return fmt.Errorf("error creating ConfigConnectorContext in namespace '%v': %w", namespace, err)
+	}
+	return nil
+}
+
+func (w *World) ensureProject(ctx context.Context, projectID string) error {
+	if w.projects[projectID] {
+		return nil
+	}
+	projectsClient, err := resourcemanager.NewProjectsRESTClient(ctx, option.WithHTTPClient(w.GCPHTTPClient), option.WithUserAgent(gcp.KCCUserAgent()))
+	if err != nil {
+		return fmt.Errorf("error building projects client: %w", err)
+	}
+	defer projectsClient.Close()
+	op, err := projectsClient.CreateProject(ctx, &resourcemanagerpb.CreateProjectRequest{
+		Project: &resourcemanagerpb.Project{ProjectId: projectID},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating project '%v' in the mock GCP: %w", projectID, err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("error waiting for project '%v' to be created in the mock GCP: %w", projectID, err)
+	}
+	w.projects[projectID] = true
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/preview"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/crd/crdloader"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func loadTestCRDs(t *testing.T, kinds ...string) []*unstructured.Unstructured {
+	var crds []*unstructured.Unstructured
+	for _, kind := range kinds {
+		crd, err := crdloader.GetCRDForKind(kind)
+		if err != nil {
+			t.Fatalf("error loading CRD for %v: %v", kind, err)
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+		if err != nil {
+			t.Fatalf("error converting CRD for %v: %v", kind, err)
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		u.SetAPIVersion("apiextensions.k8s.io/v1")
+		u.SetKind("CustomResourceDefinition")
+		crds = append(crds, u)
+	}
+	return crds
+}
+
+func newTestSecret(name string, labels map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "secretmanager.cnrm.cloud.google.com/v1beta1",
+		"kind":       "SecretManagerSecret",
+		"metadata": map[string]interface{}{
+			"name": name,
+			"annotations": map[string]interface{}{
+				"cnrm.cloud.google.com/project-id": "project1",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replication": map[string]interface{}{
+				"automatic": true,
+			},
+		},
+		// the status of an exported resource is ignored when seeding
+		"status": map[string]interface{}{
+			"observedGeneration": int64(10),
+		},
+	}}
+	u.SetLabels(labels)
+	return u
+}
+
+func newTestTopic(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "pubsub.cnrm.cloud.google.com/v1beta1",
+		"kind":       "PubSubTopic",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "project1",
+		},
+	}}
+}
+
+func TestWorld(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	world, err := NewWorld(ctx, WorldOptions{CRDs: loadTestCRDs(t, "SecretManagerSecret", "PubSubTopic")})
+	if err != nil {
+		t.Fatalf("error starting world: %v", err)
+	}
+	defer world.Close()
+
+	seed := []*unstructured.Unstructured{
+		newTestSecret("secret1", map[string]string{"env": "dev"}),
+		newTestTopic("topic1"),
+	}
+	if err := world.Seed(ctx, seed); err != nil {
+		t.Fatalf("error seeding: %v", err)
+	}
+
+	// The namespace is named after the project, and the secret is created in the mock GCP.
+	kubeClient, err := client.New(world.RESTConfig, client.Options{})
+	if err != nil {
+		t.Fatalf("error building kube client: %v", err)
+	}
+	secret := &unstructured.Unstructured{}
+	secret.SetGroupVersionKind(seed[0].GroupVersionKind())
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: "project1", Name: "secret1"}, secret); err != nil {
+		t.Fatalf("error getting seeded secret: %v", err)
+	}
+	externalRef, _, _ := unstructured.NestedString(secret.Object, "status", "externalRef")
+	if externalRef != "projects/project1/secrets/secret1" {
+		t.Errorf("expected the status of the seeded secret to hold its externalRef, got %q", externalRef)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://secretmanager.googleapis.com/v1/projects/project1/secrets/secret1", nil)
+	if err != nil {
+		t.Fatalf("error building request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+AccessToken)
+	resp, err := world.GCPHTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("error getting secret from the mock GCP: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the secret to be seeded in the mock GCP, got status %v", resp.StatusCode)
+	}
+
+	// Applying the desired state changes the objects, but keeps the status written when seeding.
+	desired := []*unstructured.Unstructured{
+		newTestSecret("secret1", map[string]string{"env": "prod"}),
+		newTestSecret("secret2", nil),
+	}
+	if err := world.Apply(ctx, desired); err != nil {
+		t.Fatalf("error applying: %v", err)
+	}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: "project1", Name: "secret1"}, secret); err != nil {
+		t.Fatalf("error getting updated secret: %v", err)
+	}
+	if got := secret.GetLabels()["env"]; got != "prod" {
+		t.Errorf("expected the secret to be updated, got label env=%q", got)
+	}
+	if got, _, _ := unstructured.NestedString(secret.Object, "status", "externalRef"); got != externalRef {
+		t.Errorf("expected the status to be kept, got externalRef %q", got)
+	}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: "project1", Name: "secret2"}, secret); err != nil {
+		t.Fatalf("error getting created secret: %v", err)
+	}
+	if _, found := secret.Object["status"]; found {
+		t.Errorf("expected the new secret to have no status, got %v", secret.Object["status"])
+	}
+
+	// The topic does not have a direct controller, so it is reported as unsupported.
+	want := []preview.GKNN{{Group: "pubsub.cnrm.cloud.google.com", Kind: "PubSubTopic", Namespace: "project1", Name: "topic1"}}
+	if diff := cmp.Diff(want, world.Unsupported()); diff != "" {
+		t.Errorf("unexpected unsupported objects (-want +got):\n%v", diff)
+	}
+}
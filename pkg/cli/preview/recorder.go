@@ -79,6 +79,8 @@ type event struct {
 	gcpAction *gcpAction
 	// object is the object that was reconciled
 	object *unstructured.Unstructured
+	// err is the error returned by the reconcile, excluding errors caused by blocked GCP actions
+	err error
 }
 
 type EventType string
@@ -204,10 +206,18 @@ func (r *Recorder) recordReconcileEnd(ctx context.Context, u *unstructured.Unstr
 		return
 	}
 
+	// Blocked GCP actions are expected to fail the reconcile, they are recorded separately.
+	if err != nil {
+		if _, blocked := ExtractBlockedGCPError(err); blocked {
+			err = nil
+		}
+	}
+
 	info := r.getObjectInfo(gknn)
 	info.events = append(info.events, event{
 		eventType: EventTypeReconcileEnd,
 		object:    u.DeepCopy(),
+		err:       err,
 	})
 	r.reconcileTrackerMutex.Lock()
 	defer r.reconcileTrackerMutex.Unlock()
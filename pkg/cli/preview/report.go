@@ -0,0 +1,353 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PlannedAction is the action that KCC would take for an object, if the preview were applied.
+type PlannedAction string
+
+const (
+	PlannedActionNoOp          PlannedAction = "no-op"
+	PlannedActionCreate        PlannedAction = "create"
+	PlannedActionUpdate        PlannedAction = "update"
+	PlannedActionDelete        PlannedAction = "delete"
+	PlannedActionError         PlannedAction = "error"
+	PlannedActionNotReconciled PlannedAction = "not-reconciled"
+	// PlannedActionUnsupported is reported for objects whose GCP state could not be reproduced,
+	// such as resources without a direct controller in an offline preview.
+	PlannedActionUnsupported PlannedAction = "unsupported"
+)
+
+// Exit codes that summarize a Report, suitable for gating CI pipelines.
+const (
+	// ExitCodeNoChanges indicates that every object was reconciled and no changes are planned.
+	ExitCodeNoChanges = 0
+	// ExitCodeErrors indicates that at least one object failed to reconcile or was not reconciled.
+	ExitCodeErrors = 2
+	// ExitCodeChanges indicates that every object was reconciled and at least one change is planned.
+	ExitCodeChanges = 3
+)
+
+// Report is the machine-readable result of a preview.
+type Report struct {
+	Summary ReportSummary  `json:"summary"`
+	Objects []ObjectReport `json:"objects"`
+}
+
+// ReportSummary holds the number of objects for each planned action.
+type ReportSummary struct {
+	Total         int `json:"total"`
+	NoOp          int `json:"noOp"`
+	Create        int `json:"create"`
+	Update        int `json:"update"`
+	Delete        int `json:"delete"`
+	Errors        int `json:"errors"`
+	NotReconciled int `json:"notReconciled"`
+	Unsupported   int `json:"unsupported,omitempty"`
+}
+
+// ObjectReport is the planned action for a single object.
+type ObjectReport struct {
+	Group      string        `json:"group"`
+	Kind       string        `json:"kind"`
+	Namespace  string        `json:"namespace"`
+	Name       string        `json:"name"`
+	Action     PlannedAction `json:"action"`
+	FieldDiffs []FieldDiff   `json:"fieldDiffs,omitempty"`
+	GCPActions []GCPAction   `json:"gcpActions,omitempty"`
+	Errors     []string      `json:"errors,omitempty"`
+}
+
+// FieldDiff is a field that would be changed in GCP.
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// GCPAction is a write request to GCP that was blocked by the preview.
+type GCPAction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// BuildReport builds a Report from the events captured by the recorder.
+func (r *Recorder) BuildReport() *Report {
+	report := &Report{}
+
+	// Objects that were listed but did not finish reconciling are reported as not reconciled, even if some of their
+	// events were captured before the preview stopped.
+	notReconciled := make(map[GKNN]bool)
+	r.reconcileTrackerMutex.Lock()
+	for gknn, reconciled := range r.ReconciledResources {
+		if !reconciled {
+			notReconciled[gknn] = true
+		}
+	}
+	r.reconcileTrackerMutex.Unlock()
+
+	r.mutex.Lock()
+	for gknn, info := range r.objects {
+		// Events that could not be associated with an object are not reportable.
+		if gknn.Kind == "" || gknn.Name == "" {
+			continue
+		}
+		if notReconciled[gknn] {
+			continue
+		}
+		report.Objects = append(report.Objects, buildObjectReport(gknn, info))
+	}
+	r.mutex.Unlock()
+
+	for gknn := range notReconciled {
+		report.Objects = append(report.Objects, ObjectReport{
+			Group:     gknn.Group,
+			Kind:      gknn.Kind,
+			Namespace: gknn.Namespace,
+			Name:      gknn.Name,
+			Action:    PlannedActionNotReconciled,
+		})
+	}
+
+	sort.Slice(report.Objects, func(i, j int) bool {
+		a, b := report.Objects[i], report.Objects[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	report.summarize()
+	return report
+}
+
+// MarkUnsupported reports the objects as unsupported, replacing the action that was planned for them.
+// It is used when the preview cannot reproduce the GCP state of the objects, so the planned action would be misleading.
+func (r *Report) MarkUnsupported(gknns []GKNN) {
+	unsupported := make(map[GKNN]bool)
+	for _, gknn := range gknns {
+		unsupported[gknn] = true
+	}
+	for i, o := range r.Objects {
+		if !unsupported[GKNN{Group: o.Group, Kind: o.Kind, Namespace: o.Namespace, Name: o.Name}] {
+			continue
+		}
+		r.Objects[i] = ObjectReport{
+			Group:     o.Group,
+			Kind:      o.Kind,
+			Namespace: o.Namespace,
+			Name:      o.Name,
+			Action:    PlannedActionUnsupported,
+		}
+	}
+	r.summarize()
+}
+
+func (r *Report) summarize() {
+	r.Summary = ReportSummary{}
+	for _, o := range r.Objects {
+		r.Summary.Total++
+		switch o.Action {
+		case PlannedActionNoOp:
+			r.Summary.NoOp++
+		case PlannedActionCreate:
+			r.Summary.Create++
+		case PlannedActionUpdate:
+			r.Summary.Update++
+		case PlannedActionDelete:
+			r.Summary.Delete++
+		case PlannedActionError:
+			r.Summary.Errors++
+		case PlannedActionNotReconciled:
+			r.Summary.NotReconciled++
+		case PlannedActionUnsupported:
+			r.Summary.Unsupported++
+		}
+	}
+}
+
+func buildObjectReport(gknn GKNN, info *objectInfo) ObjectReport {
+	o := ObjectReport{
+		Group:     gknn.Group,
+		Kind:      gknn.Kind,
+		Namespace: gknn.Namespace,
+		Name:      gknn.Name,
+	}
+	isNew := false
+	isDeleting := false
+	hasGCPDelete := false
+	for _, event := range info.events {
+		switch event.eventType {
+		case EventTypeDiff:
+			if event.diff == nil {
+				continue
+			}
+			if event.diff.IsNewObject {
+				isNew = true
+			}
+			for _, field := range event.diff.Fields {
+				if reflect.DeepEqual(field.Old, field.New) {
+					continue
+				}
+				o.FieldDiffs = append(o.FieldDiffs, FieldDiff{
+					Field: field.ID,
+					Old:   formatDiffValue(field.Old),
+					New:   formatDiffValue(field.New),
+				})
+			}
+		case EventTypeReconcileStart:
+			if event.object != nil && event.object.GetDeletionTimestamp() != nil {
+				isDeleting = true
+			}
+		case EventTypeReconcileEnd:
+			if event.err != nil {
+				o.Errors = append(o.Errors, event.err.Error())
+			}
+		case EventTypeGCPAction:
+			if isReadOnlyGCPAction(event.gcpAction) {
+				continue
+			}
+			if event.gcpAction.method == http.MethodDelete {
+				hasGCPDelete = true
+			}
+			o.GCPActions = append(o.GCPActions, GCPAction{Method: event.gcpAction.method, URL: event.gcpAction.url})
+		}
+	}
+
+	switch {
+	case len(o.Errors) != 0:
+		o.Action = PlannedActionError
+	case isDeleting || hasGCPDelete:
+		o.Action = PlannedActionDelete
+	case isNew:
+		o.Action = PlannedActionCreate
+	case len(o.FieldDiffs) != 0 || len(o.GCPActions) != 0:
+		o.Action = PlannedActionUpdate
+	default:
+		o.Action = PlannedActionNoOp
+	}
+	return o
+}
+
+// isReadOnlyGCPAction returns true for GCP actions that are blocked but do not change GCP.
+func isReadOnlyGCPAction(action *gcpAction) bool {
+	// The method is POST but it is actually a read-only call.
+	return strings.Contains(action.url, "getIamPolicy")
+}
+
+func formatDiffValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(b)
+}
+
+// HasChanges returns true if any object would be created, updated or deleted.
+func (r *Report) HasChanges() bool {
+	return r.Summary.Create+r.Summary.Update+r.Summary.Delete > 0
+}
+
+// HasErrors returns true if any object failed to reconcile or was not reconciled.
+func (r *Report) HasErrors() bool {
+	return r.Summary.Errors+r.Summary.NotReconciled > 0
+}
+
+// ExitCode returns the exit code that summarizes the report, errors take precedence over changes.
+func (r *Report) ExitCode() int {
+	if r.HasErrors() {
+		return ExitCodeErrors
+	}
+	if r.HasChanges() {
+		return ExitCodeChanges
+	}
+	return ExitCodeNoChanges
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("error encoding report as json: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes the report as Markdown, suitable for posting as a pull-request comment.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Config Connector preview\n\n")
+	sb.WriteString("| Total | No-op | Create | Update | Delete | Errors | Not reconciled | Unsupported |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&sb, "| %d | %d | %d | %d | %d | %d | %d | %d |\n\n",
+		r.Summary.Total, r.Summary.NoOp, r.Summary.Create, r.Summary.Update, r.Summary.Delete, r.Summary.Errors, r.Summary.NotReconciled, r.Summary.Unsupported)
+
+	var changed []ObjectReport
+	for _, o := range r.Objects {
+		if o.Action != PlannedActionNoOp {
+			changed = append(changed, o)
+		}
+	}
+	if len(changed) == 0 {
+		sb.WriteString("No changes.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	sb.WriteString("| Action | Namespace | Kind | Name |\n")
+	sb.WriteString("|---|---|---|---|\n")
+	for _, o := range changed {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", o.Action, o.Namespace, o.Kind, o.Name)
+	}
+	for _, o := range changed {
+		if len(o.FieldDiffs) == 0 && len(o.GCPActions) == 0 && len(o.Errors) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s %s/%s (%s)\n\n", o.Kind, o.Namespace, o.Name, o.Action)
+		for _, d := range o.FieldDiffs {
+			fmt.Fprintf(&sb, "- `%s`: `%s` → `%s`\n", d.Field, d.Old, d.New)
+		}
+		for _, a := range o.GCPActions {
+			fmt.Fprintf(&sb, "- GCP %s `%s`\n", a.Method, a.URL)
+		}
+		for _, e := range o.Errors {
+			fmt.Fprintf(&sb, "- error: %s\n", e)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
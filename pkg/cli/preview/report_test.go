@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/structuredreporting"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestObject(kind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("pubsub.cnrm.cloud.google.com/v1beta1")
+	u.SetKind(kind)
+	u.SetNamespace("ns")
+	u.SetName(name)
+	return u
+}
+
+func TestBuildReport(t *testing.T) {
+	ctx := context.TODO()
+	r := NewRecorder()
+
+	unchanged := newTestObject("PubSubTopic", "unchanged")
+	r.recordReconcileStart(ctx, unchanged, "")
+	r.recordReconcileEnd(ctx, unchanged, reconcile.Result{}, nil, "")
+
+	updated := newTestObject("PubSubTopic", "updated")
+	r.recordReconcileStart(ctx, updated, "")
+	diff := &structuredreporting.Diff{Object: updated}
+	diff.AddField("labels.env", "dev", "prod")
+	diff.AddField("unchanged", "same", "same")
+	r.recordDiff(ctx, diff)
+	blocked := &BlockedGCPError{Method: "PATCH", URL: "https://pubsub.googleapis.com/v1/projects/p/topics/updated"}
+	r.recordReconcileEnd(ctx, updated, reconcile.Result{}, blocked, "")
+
+	created := newTestObject("PubSubTopic", "created")
+	r.recordDiff(ctx, &structuredreporting.Diff{Object: created, IsNewObject: true})
+	r.recordReconcileEnd(ctx, created, reconcile.Result{}, nil, "")
+
+	failed := newTestObject("PubSubSubscription", "failed")
+	r.recordReconcileEnd(ctx, failed, reconcile.Result{}, errors.New("topic not found"), "")
+
+	r.ReconciledResources[GKNN{Group: "pubsub.cnrm.cloud.google.com", Kind: "PubSubTopic", Namespace: "ns", Name: "pending"}] = false
+
+	// started but never finished reconciling, it must only be reported once
+	unfinished := newTestObject("PubSubTopic", "unfinished")
+	r.ReconciledResources[gknnFromUnstructured(unfinished)] = false
+	r.recordReconcileStart(ctx, unfinished, "")
+
+	report := r.BuildReport()
+
+	want := ReportSummary{Total: 6, NoOp: 1, Create: 1, Update: 1, Errors: 1, NotReconciled: 2}
+	if diff := cmp.Diff(want, report.Summary); diff != "" {
+		t.Errorf("unexpected summary (-want +got):\n%v", diff)
+	}
+	var actions []string
+	for _, o := range report.Objects {
+		actions = append(actions, o.Kind+"/"+o.Name+"="+string(o.Action))
+	}
+	wantActions := []string{
+		"PubSubSubscription/failed=error",
+		"PubSubTopic/created=create",
+		"PubSubTopic/pending=not-reconciled",
+		"PubSubTopic/unchanged=no-op",
+		"PubSubTopic/unfinished=not-reconciled",
+		"PubSubTopic/updated=update",
+	}
+	if diff := cmp.Diff(wantActions, actions); diff != "" {
+		t.Errorf("unexpected actions (-want +got):\n%v", diff)
+	}
+	updatedReport := report.Objects[5]
+	if diff := cmp.Diff([]FieldDiff{{Field: "labels.env", Old: "dev", New: "prod"}}, updatedReport.FieldDiffs); diff != "" {
+		t.Errorf("unexpected field diffs (-want +got):\n%v", diff)
+	}
+	if got := report.ExitCode(); got != ExitCodeErrors {
+		t.Errorf("ExitCode() = %v, want %v", got, ExitCodeErrors)
+	}
+
+	var md bytes.Buffer
+	if err := report.WriteMarkdown(&md); err != nil {
+		t.Fatalf("error writing markdown: %v", err)
+	}
+	if !strings.Contains(md.String(), "`labels.env`: `dev` → `prod`") {
+		t.Errorf("markdown report does not contain the field diff:\n%v", md.String())
+	}
+}
+
+func TestReportExitCode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		summary ReportSummary
+		want    int
+	}{
+		{name: "no changes", summary: ReportSummary{Total: 2, NoOp: 2}, want: ExitCodeNoChanges},
+		{name: "changes", summary: ReportSummary{Total: 2, NoOp: 1, Delete: 1}, want: ExitCodeChanges},
+		{name: "errors take precedence", summary: ReportSummary{Total: 2, Update: 1, NotReconciled: 1}, want: ExitCodeErrors},
+		{name: "unsupported objects are not changes", summary: ReportSummary{Total: 2, NoOp: 1, Unsupported: 1}, want: ExitCodeNoChanges},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := &Report{Summary: tc.summary}
+			if got := report.ExitCode(); got != tc.want {
+				t.Errorf("ExitCode() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReportMarkUnsupported(t *testing.T) {
+	ctx := context.TODO()
+	r := NewRecorder()
+
+	created := newTestObject("PubSubTopic", "created")
+	r.recordDiff(ctx, &structuredreporting.Diff{Object: created, IsNewObject: true})
+	r.recordReconcileEnd(ctx, created, reconcile.Result{}, nil, "")
+
+	unsupported := newTestObject("PubSubSubscription", "unsupported")
+	r.recordDiff(ctx, &structuredreporting.Diff{Object: unsupported, IsNewObject: true})
+	r.recordReconcileEnd(ctx, unsupported, reconcile.Result{}, nil, "")
+
+	report := r.BuildReport()
+	report.MarkUnsupported([]GKNN{{Group: "pubsub.cnrm.cloud.google.com", Kind: "PubSubSubscription", Namespace: "ns", Name: "unsupported"}})
+
+	want := ReportSummary{Total: 2, Create: 1, Unsupported: 1}
+	if diff := cmp.Diff(want, report.Summary); diff != "" {
+		t.Errorf("unexpected summary (-want +got):\n%v", diff)
+	}
+	if got := report.Objects[0]; got.Name != "unsupported" || got.Action != PlannedActionUnsupported || len(got.GCPActions) != 0 {
+		t.Errorf("unexpected report for the unsupported object: %+v", got)
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"os"
 	"reflect"
-	"strings"
 	"text/tabwriter"
 )
 
@@ -92,8 +91,7 @@ func ParseEventInfo(info *objectInfo) (bool, []string) {
 			// Ignore kubeaction for now. Mostly status update.
 
 		case EventTypeGCPAction:
-			// The method is POST but it is actually a read-only call.
-			if strings.Contains(event.gcpAction.url, "getIamPolicy") {
+			if isReadOnlyGCPAction(event.gcpAction) {
 				continue
 			}
 			good = false
@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/common/gcsobjects"
+	assetpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/asset/v1"
+	kmspb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/kms/v1"
+	crmpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/cloud/resourcemanager/v3"
+	pubsubpb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/pubsub/v1"
+	storagepb "github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/generated/mockgcp/storage/v1"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/mockasset"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/mockgcp/pkg/storage"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/cli/asset"
+)
+
+// TestAssetToUnstructuredStreamFromMockGCPExport exports the assets of a project from mockasset,
+// and converts the export to KCC resources as bulk-export does.
+func TestAssetToUnstructuredStreamFromMockGCPExport(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewInMemoryStorage()
+
+	// The project number is known to the mock service client
+	objects := map[string]proto.Message{
+		"projects/project-id-1":                                  &crmpb.Project{Name: "projects/1234567890", ProjectId: "project-id-1", Parent: "organizations/1"},
+		"projects/project-id-1/topics/my-topic":                  &pubsubpb.Topic{Name: "projects/project-id-1/topics/my-topic"},
+		"projects/project-id-1/locations/us-central1/keyRings/k": &kmspb.KeyRing{Name: "projects/project-id-1/locations/us-central1/keyRings/k"},
+		"buckets/my-bucket":                                      &storagepb.Bucket{Name: proto.String("my-bucket"), Location: proto.String("US"), ProjectNumber: proto.Uint64(1234567890)},
+	}
+	for fqn, obj := range objects {
+		if err := store.Create(ctx, fqn, obj); err != nil {
+			t.Fatalf("creating %q: %v", fqn, err)
+		}
+	}
+
+	client := newMockAssetClient(t, store)
+	if _, err := client.ExportAssets(ctx, &assetpb.ExportAssetsRequest{
+		Parent:      "projects/project-id-1",
+		AssetTypes:  []string{"pubsub.googleapis.com/Topic", "cloudkms.googleapis.com/KeyRing", "storage.googleapis.com/Bucket"},
+		ContentType: assetpb.ContentType_RESOURCE,
+		OutputConfig: &assetpb.OutputConfig{
+			Destination: &assetpb.OutputConfig_GcsDestination{
+				GcsDestination: &assetpb.GcsDestination{ObjectUri: &assetpb.GcsDestination_Uri{Uri: "gs://my-bucket/export.json"}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("ExportAssets failed: %v", err)
+	}
+
+	// The export is written by a long-running operation
+	var contents []byte
+	for i := 0; ; i++ {
+		body, err := gcsobjects.Read(ctx, store, "my-bucket", "export.json")
+		if err == nil {
+			contents = body.GetData()
+			break
+		}
+		if i == 100 {
+			t.Fatalf("timed out waiting for export: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	unstructuredStream := newTestUnstructuredResourceStreamFromAsset(t, asset.NewStream(bytes.NewReader(contents)))
+	var got []string
+	for _, u := range unstructuredStreamToSlice(t, unstructuredStream) {
+		got = append(got, u.GetKind()+"/"+u.GetName())
+	}
+	sort.Strings(got)
+	want := []string{"KMSKeyRing/k", "PubSubTopic/my-topic", "StorageBucket/my-bucket"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected resources %v, want %v", got, want)
+	}
+}
+
+// newMockAssetClient serves mockasset over an in-memory grpc connection.
+func newMockAssetClient(t *testing.T, store storage.Storage) assetpb.AssetServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	mockasset.New(&common.MockEnvironment{}, store).Register(server)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			t.Logf("error serving mockasset: %v", err)
+		}
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("error connecting to mockasset: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return assetpb.NewAssetServiceClient(conn)
+}
@@ -231,7 +231,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (res
 		return reconcile.Result{}, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case v1beta1.Reconciling:
 		r.logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", req.NamespacedName)
@@ -240,6 +242,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (res
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		jitteredPeriod = resourceactuation.RequeueAfter(jitteredPeriod, resourceactuation.ResumeAfter(actuation, now))
 
 		if resource.GetDeletionTimestamp().IsZero() {
 			// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
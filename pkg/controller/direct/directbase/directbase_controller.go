@@ -199,6 +199,9 @@ type reconcileContext struct {
 	gvk            schema.GroupVersionKind
 	Reconciler     *DirectReconciler
 	NamespacedName types.NamespacedName
+
+	// resumeAfter is how long until the actuation schedules resume actuation, if they paused it.
+	resumeAfter time.Duration
 }
 
 func (r *DirectReconciler) mapSecretToResources(ctx context.Context, obj client.Object) ([]reconcile.Request, error) {
@@ -275,6 +278,7 @@ func (r *DirectReconciler) Reconcile(ctx context.Context, request reconcile.Requ
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	jitteredPeriod = resourceactuation.RequeueAfter(jitteredPeriod, runCtx.resumeAfter)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", jitteredPeriod)
 	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
 }
@@ -287,12 +291,15 @@ func (r *reconcileContext) doReconcile(ctx context.Context, u *unstructured.Unst
 		return false, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case v1beta1.Reconciling:
 		logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", r.NamespacedName)
 	case v1beta1.Paused:
 		logger.Info("Skipping actuation of resource as actuation mode is \"Paused\"", "resource", r.NamespacedName)
+		r.resumeAfter = resourceactuation.ResumeAfter(actuation, now)
 
 		// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
 		if u.GetDeletionTimestamp().IsZero() {
@@ -21,6 +21,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/config"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/registry"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // ExportUsesDirect returns true if we have a direct-implemented exporter for the URL.
@@ -32,6 +33,12 @@ func ExportUsesDirect(ctx context.Context, url string, config *config.Controller
 	return adapter != nil, nil
 }
 
+// ExportGroupKind returns the GroupKind of the direct-implemented resource for the URL,
+// or false if the URL is not recognized by a direct controller.
+func ExportGroupKind(ctx context.Context, url string) (schema.GroupKind, bool, error) {
+	return registry.GroupKindForURL(ctx, url)
+}
+
 // Export attempts to export the resource specified by url.
 // The url format should match the Cloud-Asset-Inventory format: https://cloud.google.com/asset-inventory/docs/resource-name-format
 // If url is not recognized or not implemented by a direct controller, this returns (nil, nil)
@@ -73,6 +73,24 @@ func AdapterForURL(ctx context.Context, url string) (directbase.Adapter, error)
 	return nil, nil
 }
 
+// GroupKindForURL returns the GroupKind of the direct resource that recognizes the URL,
+// or false if no direct resource recognizes it.
+func GroupKindForURL(ctx context.Context, url string) (schema.GroupKind, bool, error) {
+	for gk, registration := range singleton.registrations {
+		if registration.model == nil {
+			return schema.GroupKind{}, false, fmt.Errorf("registry was not initialized (must call registry.Init)")
+		}
+		adapter, err := registration.model.AdapterForURL(ctx, url)
+		if err != nil {
+			return schema.GroupKind{}, false, err
+		}
+		if adapter != nil {
+			return gk, true, nil
+		}
+	}
+	return schema.GroupKind{}, false, nil
+}
+
 func Init(ctx context.Context, config *config.ControllerConfig) error {
 	for _, registration := range singleton.registrations {
 		model, err := registration.factory(ctx, config)
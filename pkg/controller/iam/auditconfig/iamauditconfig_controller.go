@@ -33,6 +33,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/ratelimiter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceactuation"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourcewatcher"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/execution"
@@ -56,6 +57,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -79,6 +81,7 @@ func Add(mgr manager.Manager, deps *kontroller.Deps) error {
 	if err != nil {
 		return err
 	}
+	reconciler.sharding = deps.Sharding
 	return add(mgr, reconciler)
 }
 
@@ -103,12 +106,13 @@ func NewReconciler(mgr manager.Manager, provider *tfschema.Provider, smLoader *s
 // add adds a new Controller to mgr with r as the reconcile.Reconciler.
 func add(mgr manager.Manager, r *Reconciler) error {
 	obj := &iamv1beta1.IAMAuditConfig{}
+	r.sharding.EnqueueOnAcquire(mgr.GetAPIReader(), iamv1beta1.IAMAuditConfigGVK, r.immediateReconcileRequests)
 	_, err := builder.
 		ControllerManagedBy(mgr).
 		Named(controllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: k8s.ControllerMaxConcurrentReconciles, RateLimiter: ratelimiter.NewRateLimiter()}).
 		WatchesRawSource(source.TypedChannel(r.immediateReconcileRequests, &handler.EnqueueRequestForObject{})).
-		For(obj, builder.OnlyMetadata, builder.WithPredicates(predicate.UnderlyingResourceOutOfSyncPredicate{})).
+		For(obj, builder.OnlyMetadata, builder.WithPredicates(append([]crpredicate.Predicate{predicate.UnderlyingResourceOutOfSyncPredicate{}}, r.sharding.Predicates()...)...)).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("error creating new controller: %w", err)
@@ -131,12 +135,17 @@ type Reconciler struct {
 	resourceWatcherRoutines    *semaphore.Weighted // Used to cap number of goroutines watching unready dependencies
 
 	jitterGen jitter.Generator
+	// sharding is the shard membership of this replica, or nil if resources are not sharded.
+	sharding *sharding.Membership
 }
 
 type reconcileContext struct {
 	Reconciler     *Reconciler
 	Ctx            context.Context
 	NamespacedName types.NamespacedName
+
+	// resumeAfter is how long until the actuation schedules resume actuation, if they paused it.
+	resumeAfter time.Duration
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
@@ -159,6 +168,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// r.Get() overrides the TypeMeta to empty value, so need to configure it
 	// after r.Get().
 	auditConfig.SetGroupVersionKind(iamv1beta1.IAMAuditConfigGVK)
+	// The resource may have moved to another shard since the request was queued.
+	if !r.sharding.Owns(&auditConfig) {
+		logger.Info("skipping resource owned by another shard", "resource", request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
 	if err := r.handleDefaults(ctx, &auditConfig); err != nil {
 		return reconcile.Result{}, fmt.Errorf("error handling default values for IAM policy '%v': %w", k8s.GetNamespacedName(&auditConfig), err)
 	}
@@ -178,6 +192,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	jitteredPeriod = resourceactuation.RequeueAfter(jitteredPeriod, reconcileContext.resumeAfter)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", jitteredPeriod)
 	return reconcile.Result{RequeueAfter: jitteredPeriod}, nil
 }
@@ -209,12 +224,15 @@ func (r *reconcileContext) doReconcile(auditConfig *iamv1beta1.IAMAuditConfig) (
 		return true, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case v1beta1.Reconciling:
 		logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", r.NamespacedName)
 	case v1beta1.Paused:
 		logger.Info("Skipping actuation of resource as actuation mode is \"Paused\"", "resource", r.NamespacedName)
+		r.resumeAfter = resourceactuation.ResumeAfter(actuation, now)
 
 		// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
 		if auditConfig.GetDeletionTimestamp().IsZero() {
@@ -163,6 +163,8 @@ type reconcileContext struct {
 	NamespacedName types.NamespacedName
 
 	objRef *iamv1beta1.IAMPolicy
+	// resumeAfter is how long until the actuation schedules resume actuation, if they paused it.
+	resumeAfter time.Duration
 }
 
 func (r *ReconcileIAMPartialPolicy) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
@@ -227,7 +229,7 @@ func (r *ReconcileIAMPartialPolicy) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 	requeueDelay := r.requeueRateLimiter.When(request)
-	requeueAfter := jitteredPeriod + requeueDelay
+	requeueAfter := resourceactuation.RequeueAfter(jitteredPeriod+requeueDelay, runCtx.resumeAfter)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", requeueAfter)
 	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
@@ -259,12 +261,15 @@ func (r *reconcileContext) doReconcile(pp *iamv1beta1.IAMPartialPolicy) (requeue
 		return true, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case v1beta1.Reconciling:
 		logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", r.NamespacedName)
 	case v1beta1.Paused:
 		logger.Info("Skipping actuation of resource as actuation mode is \"Paused\"", "resource", r.NamespacedName)
+		r.resumeAfter = resourceactuation.ResumeAfter(actuation, now)
 
 		// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
 		if pp.GetDeletionTimestamp().IsZero() {
@@ -33,6 +33,7 @@ import (
 	kccratelimiter "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/ratelimiter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceactuation"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourcewatcher"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/execution"
@@ -58,6 +59,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	crpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -83,6 +85,7 @@ func Add(mgr manager.Manager, deps *kontroller.Deps) error {
 	if err != nil {
 		return err
 	}
+	reconciler.sharding = deps.Sharding
 	return add(mgr, reconciler)
 }
 
@@ -110,12 +113,13 @@ func NewReconciler(mgr manager.Manager, provider *tfschema.Provider, smLoader *s
 // add adds a new Controller to mgr with r as the reconcile.Reconciler.
 func add(mgr manager.Manager, r *Reconciler) error {
 	obj := &iamv1beta1.IAMPolicyMember{}
+	r.sharding.EnqueueOnAcquire(mgr.GetAPIReader(), iamv1beta1.IAMPolicyMemberGVK, r.immediateReconcileRequests)
 	_, err := builder.
 		ControllerManagedBy(mgr).
 		Named(controllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: k8s.ControllerMaxConcurrentReconciles, RateLimiter: kccratelimiter.NewRateLimiter()}).
 		WatchesRawSource(source.TypedChannel(r.immediateReconcileRequests, &handler.EnqueueRequestForObject{})).
-		For(obj, builder.OnlyMetadata, builder.WithPredicates(predicate.UnderlyingResourceOutOfSyncPredicate{})).
+		For(obj, builder.OnlyMetadata, builder.WithPredicates(append([]crpredicate.Predicate{predicate.UnderlyingResourceOutOfSyncPredicate{}}, r.sharding.Predicates()...)...)).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("error creating new controller: %w", err)
@@ -140,12 +144,17 @@ type Reconciler struct {
 	// rate limit requeues (periodic re-reconciliation), so we don't use the whole rate limit on re-reconciles
 	requeueRateLimiter workqueue.TypedRateLimiter[reconcile.Request]
 	jitterGen          jitter.Generator
+	// sharding is the shard membership of this replica, or nil if resources are not sharded.
+	sharding *sharding.Membership
 }
 
 type reconcileContext struct {
 	Reconciler     *Reconciler
 	Ctx            context.Context
 	NamespacedName types.NamespacedName
+
+	// resumeAfter is how long until the actuation schedules resume actuation, if they paused it.
+	resumeAfter time.Duration
 }
 
 // Reconcile checks k8s for the current state of the resource.
@@ -168,6 +177,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// r.Get() overrides the TypeMeta to empty value, so need to configure it
 	// after r.Get().
 	memberPolicy.SetGroupVersionKind(iamv1beta1.IAMPolicyMemberGVK)
+	// The resource may have moved to another shard since the request was queued.
+	if !r.sharding.Owns(&memberPolicy) {
+		logger.Info("skipping resource owned by another shard", "resource", request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
 	if err := r.handleDefaults(ctx, &memberPolicy); err != nil {
 		return reconcile.Result{}, fmt.Errorf("error handling default values for IAM policy member '%v': %w", k8s.GetNamespacedName(&memberPolicy), err)
 	}
@@ -199,7 +213,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 	requeueDelay := r.requeueRateLimiter.When(request)
-	requeueAfter := jitteredPeriod + requeueDelay
+	requeueAfter := resourceactuation.RequeueAfter(jitteredPeriod+requeueDelay, reconcileContext.resumeAfter)
 	logger.Info("successfully finished reconcile", "resource", request.NamespacedName, "time to next reconciliation", requeueAfter)
 	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
@@ -231,12 +245,15 @@ func (r *reconcileContext) doReconcile(policyMember *iamv1beta1.IAMPolicyMember)
 		return true, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case opcorev1beta1.Reconciling:
 		logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", r.NamespacedName)
 	case opcorev1beta1.Paused:
 		logger.Info("Skipping actuation of resource as actuation mode is \"Paused\"", "resource", r.NamespacedName)
+		r.resumeAfter = resourceactuation.ResumeAfter(actuation, now)
 
 		// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
 		if policyMember.GetDeletionTimestamp().IsZero() {
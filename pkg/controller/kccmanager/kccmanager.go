@@ -29,6 +29,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/direct/registry"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/kccmanager/nocache"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/registration"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/clientconfig"
 	dclconversion "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
 	dclmetadata "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/metadata"
@@ -42,6 +43,7 @@ import (
 	mcleclient "github.com/gke-labs/multicluster-leader-election/pkg/client"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -98,6 +100,10 @@ type Config struct {
 
 	// Configure manager to participate in leader election if MultiClusterLease is enabled.
 	MultiClusterLease bool
+
+	// Sharding splits the resources across the replicas of the manager, if set.
+	// Each replica reconciles only the resources of the shard it holds.
+	Sharding *sharding.Options
 }
 
 func setUpMultiClusterLease(ctx context.Context, restConfig *rest.Config, scheme *runtime.Scheme) (*leaderelection.LeaderElectionConfig, error) {
@@ -294,6 +300,22 @@ func New(ctx context.Context, restConfig *rest.Config, cfg Config) (manager.Mana
 		})
 	}()
 
+	if cfg.Sharding != nil {
+		// Leases are not cached by the manager's client, so use a client that reads from the API server.
+		c, err := crclient.New(restConfig, crclient.Options{Scheme: opts.Scheme})
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for shard leases: %w", err)
+		}
+		membership, err := sharding.NewMembership(c, *cfg.Sharding)
+		if err != nil {
+			return nil, fmt.Errorf("error setting up sharding: %w", err)
+		}
+		if err := mgr.Add(membership); err != nil {
+			return nil, fmt.Errorf("error adding shard membership to the manager: %w", err)
+		}
+		rd.Sharding = membership
+	}
+
 	// Register the registration controller, which will dynamically create controllers for
 	// all our resources.
 	if err := registration.AddDefaultControllers(ctx, mgr, &rd, controllerConfig); err != nil {
@@ -310,6 +332,9 @@ func addSchemes(scheme *runtime.Scheme) error {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return fmt.Errorf("error adding 'corev1' resources to the scheme: %w", err)
 	}
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("error adding 'coordinationv1' resources to the scheme: %w", err)
+	}
 	if err := apiextensions.AddToScheme(scheme); err != nil {
 		return fmt.Errorf("error adding 'apiextensions' resources to the scheme: %w", err)
 	}
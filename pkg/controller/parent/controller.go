@@ -30,6 +30,7 @@ import (
 	kccpredicate "github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/predicate"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/ratelimiter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceconfig"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/tf"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
 
@@ -71,14 +72,19 @@ type ParentReconciler struct {
 	mgr         manager.Manager
 	gvk         schema.GroupVersionKind
 	reconcilers Reconcilers
+	sharding    *sharding.Membership
 }
 
-func Add(mgr manager.Manager, gvk schema.GroupVersionKind, reconcilers *Reconcilers) error {
+// Add creates the parent controller for the GVK. If membership is non-nil, the controller only
+// reconciles the resources in the shard held by this replica.
+func Add(mgr manager.Manager, gvk schema.GroupVersionKind, reconcilers *Reconcilers, membership *sharding.Membership) error {
 	controllerName := fmt.Sprintf("%v-parent-controller", strings.ToLower(gvk.Kind))
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(gvk)
 	predicates := []predicate.Predicate{kccpredicate.UnderlyingResourceOutOfSyncPredicate{}}
+	predicates = append(predicates, membership.Predicates()...)
 	immediateReconcileRequests := make(chan event.GenericEvent, k8s.ImmediateReconcileRequestsBufferSize)
+	membership.EnqueueOnAcquire(mgr.GetAPIReader(), gvk, immediateReconcileRequests)
 
 	r := &ParentReconciler{
 		Client: mgr.GetClient(),
@@ -90,6 +96,7 @@ func Add(mgr manager.Manager, gvk schema.GroupVersionKind, reconcilers *Reconcil
 			Direct: reconcilers.Direct,
 			Custom: reconcilers.Custom,
 		},
+		sharding: membership,
 	}
 
 	_, err := builder.
@@ -115,6 +122,11 @@ func (r *ParentReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 	if err := r.Get(ctx, req.NamespacedName, u); err != nil {
 		return reconcile.Result{}, client.IgnoreNotFound(err)
 	}
+	// The resource may have moved to another shard since the request was queued.
+	if !r.sharding.Owns(u) {
+		logger.Info("skipping resource owned by another shard", "resource", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
 
 	controllerType, err := r.determineControllerType(ctx, u)
 	if err != nil {
@@ -187,6 +199,12 @@ func (r *ParentReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 }
 
 func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstructured.Unstructured) (k8s.ReconcilerType, error) {
+	return DetermineControllerType(ctx, r.Client, r.gvk, u)
+}
+
+// DetermineControllerType returns the type of reconciler that handles the object u,
+// based on its annotations, the controllerOverrides of the ConfigConnectorContext and the static configuration.
+func DetermineControllerType(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, u *unstructured.Unstructured) (k8s.ReconcilerType, error) {
 	// Check for resource annotation
 	annotations := u.GetAnnotations()
 	if annotations[k8s.AlphaReconcilerAnnotation] == "direct" {
@@ -194,7 +212,7 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 	}
 
 	// Special case handling. Will be removed after the resources have turned on direct as default.
-	if r.gvk.Kind == "BigQueryTable" {
+	if gvk.Kind == "BigQueryTable" {
 		obj := &bigquerykrm.BigQueryTable{}
 		if _, ok := annotations[kccpredicate.AnnotationUnmanaged]; ok {
 			return k8s.ReconcilerTypeDirect, nil
@@ -206,7 +224,7 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 			return k8s.ReconcilerTypeDirect, nil
 		}
 	}
-	if r.gvk.Kind == "ComputeForwardingRule" {
+	if gvk.Kind == "ComputeForwardingRule" {
 		obj := &computekrm.ComputeForwardingRule{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &obj); err != nil {
 			return "", fmt.Errorf("error converting to %T: %w", obj, err)
@@ -215,7 +233,7 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 			return k8s.ReconcilerTypeDirect, nil
 		}
 	}
-	if r.gvk.Kind == "ComputeTargetTCPProxy" {
+	if gvk.Kind == "ComputeTargetTCPProxy" {
 		obj := &computekrm.ComputeTargetTCPProxy{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &obj); err != nil {
 			return "", fmt.Errorf("error converting to %T: %w", obj, err)
@@ -224,7 +242,7 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 			return k8s.ReconcilerTypeDirect, nil
 		}
 	}
-	if r.gvk.Kind == "SecretManagerSecret" {
+	if gvk.Kind == "SecretManagerSecret" {
 		obj := &secretkrm.SecretManagerSecret{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &obj); err != nil {
 			return "", fmt.Errorf("error converting to %T: %w", obj, err)
@@ -233,7 +251,7 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 			return k8s.ReconcilerTypeDirect, nil
 		}
 	}
-	if r.gvk.Kind == "SpannerInstance" {
+	if gvk.Kind == "SpannerInstance" {
 		obj := &spannerkrm.SpannerInstance{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &obj); err != nil {
 			return "", fmt.Errorf("error converting to %T: %w", obj, err)
@@ -244,22 +262,22 @@ func (r *ParentReconciler) determineControllerType(ctx context.Context, u *unstr
 	}
 
 	// Check for CCC setting
-	_, ccc, err := kccstate.FetchLiveKCCState(ctx, r.Client, types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()})
+	_, ccc, err := kccstate.FetchLiveKCCState(ctx, c, types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()})
 	if err != nil {
 		return "", fmt.Errorf("error fetching kcc state: %w", err)
 	}
 	if ccc.Spec.Experiments != nil {
 		for k, v := range ccc.Spec.Experiments.ControllerOverrides {
-			if k == r.gvk.GroupKind().String() {
+			if k == gvk.GroupKind().String() {
 				return v, nil
 			}
 		}
 	}
 
 	// Fallback to static config
-	gk := r.gvk.GroupKind()
+	gk := gvk.GroupKind()
 	resourcesControllersConfig := resourceconfig.LoadConfig()
-	config, err := resourcesControllersConfig.GetControllersForGVK(r.gvk)
+	config, err := resourcesControllersConfig.GetControllersForGVK(gvk)
 	if err != nil {
 		return "", fmt.Errorf("error getting controller config found for GroupKind %v", gk)
 	}
@@ -35,6 +35,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/jitter"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/parent"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceconfig"
+	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/sharding"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/tf"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/unmanageddetector"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/dcl/conversion"
@@ -140,6 +141,7 @@ func add(mgr manager.Manager, rd *controller.Deps, regFunc registrationFunc, opt
 		defaulters:                 rd.Defaulters,
 		jitterGenerator:            rd.JitterGen,
 		dependencyTracker:          rd.DependencyTracker,
+		sharding:                   rd.Sharding,
 		reconcilers:                make(map[schema.GroupVersionKind]*parent.Reconcilers),
 		immediateReconcileRequests: make(chan event.GenericEvent, k8s.ImmediateReconcileRequestsBufferSize),
 		resourceWatcherRoutines:    semaphore.NewWeighted(k8s.MaxNumResourceWatcherRoutines),
@@ -173,6 +175,7 @@ type ReconcileRegistration struct {
 	defaulters        []k8s.Defaulter
 	jitterGenerator   jitter.Generator
 	dependencyTracker *gcpwatch.DependencyTracker
+	sharding          *sharding.Membership
 	reconcilers       map[schema.GroupVersionKind]*parent.Reconcilers
 
 	immediateReconcileRequests chan event.GenericEvent
@@ -264,6 +267,7 @@ func registerDefaultController(ctx context.Context, r *ReconcileRegistration, co
 		DCLConverter: r.dclConverter,
 		JitterGen:    r.jitterGenerator,
 		Defaulters:   r.defaulters,
+		Sharding:     r.sharding,
 		//DependencyTracker: r.dependencyTracker,
 	}
 
@@ -352,7 +356,7 @@ func registerDefaultController(ctx context.Context, r *ReconcileRegistration, co
 				}
 			}
 			r.reconcilers[gvk] = reconcilers
-			if err := parent.Add(r.mgr, gvk, reconcilers); err != nil {
+			if err := parent.Add(r.mgr, gvk, reconcilers, r.sharding); err != nil {
 				return nil, fmt.Errorf("error adding parent controller for %v to a manager: %w", crd.Spec.Names.Kind, err)
 			}
 		}
@@ -16,12 +16,14 @@ package resourceactuation
 
 import (
 	"fmt"
+	"time"
 
 	opv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	opk8s "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/reconciliationinterval"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -31,7 +33,61 @@ import (
 //
 // - If both CC & CCC specify an actuationMode in cluster mode, the CCC specification is irrelevant.
 // - If neither CC nor CCC specify a actuationMode, we defer to the default value defined in apis.
+//
+// Actuation is also paused during the freezes of the actuationSchedule of the CC, and of the CCC in Namespaced mode.
 func DecideActuationMode(cc opv1beta1.ConfigConnector, ccc opv1beta1.ConfigConnectorContext) opv1beta1.ActuationMode {
+	return DecideActuation(cc, ccc, time.Now()).Mode
+}
+
+// DecideActuation returns the actuation mode in effect at the time, as decided by DecideActuationMode,
+// along with why and when the actuation schedules next change it.
+// An invalid actuation schedule pauses actuation, rather than letting resources be actuated during a freeze.
+func DecideActuation(cc opv1beta1.ConfigConnector, ccc opv1beta1.ConfigConnectorContext, now time.Time) opv1beta1.ActuationStatus {
+	mode := decideStaticActuationMode(cc, ccc)
+	if mode == opv1beta1.Paused {
+		return opv1beta1.ActuationStatus{Mode: mode}
+	}
+
+	schedules := []*opv1beta1.ActuationSchedule{cc.Spec.ActuationSchedule}
+	if cc.Spec.Mode == opk8s.NamespacedMode {
+		schedules = append(schedules, ccc.Spec.ActuationSchedule)
+	}
+	frozen, reason, next, err := evaluateSchedules(now, schedules...)
+	if err != nil {
+		return opv1beta1.ActuationStatus{
+			Mode:   opv1beta1.Paused,
+			Reason: fmt.Sprintf("invalid actuation schedule: %v", err),
+		}
+	}
+	status := opv1beta1.ActuationStatus{Mode: mode, Reason: reason}
+	if frozen {
+		status.Mode = opv1beta1.Paused
+	}
+	if !next.IsZero() {
+		status.NextTransitionTime = &metav1.Time{Time: next}
+	}
+	return status
+}
+
+// ResumeAfter returns how long to wait until the actuation schedules resume the actuation they paused,
+// or 0 if actuation is not paused by a schedule.
+func ResumeAfter(actuation opv1beta1.ActuationStatus, now time.Time) time.Duration {
+	if actuation.Mode != opv1beta1.Paused || actuation.NextTransitionTime == nil {
+		return 0
+	}
+	return actuation.NextTransitionTime.Sub(now)
+}
+
+// RequeueAfter returns how long to wait before reconciling a resource again: the jittered period or,
+// if the resource is paused by an actuation schedule, the time until actuation resumes if that is sooner.
+func RequeueAfter(jitteredPeriod, resumeAfter time.Duration) time.Duration {
+	if resumeAfter > 0 && resumeAfter < jitteredPeriod {
+		return resumeAfter
+	}
+	return jitteredPeriod
+}
+
+func decideStaticActuationMode(cc opv1beta1.ConfigConnector, ccc opv1beta1.ConfigConnectorContext) opv1beta1.ActuationMode {
 	if ccc.Spec.Actuation != "" && cc.Spec.Mode == opk8s.NamespacedMode {
 		return ccc.Spec.Actuation
 	}
@@ -17,11 +17,14 @@ package resourceactuation_test
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	opv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
 	opk8s "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/k8s"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/controller/resourceactuation"
 	"github.com/GoogleCloudPlatform/k8s-config-connector/pkg/k8s"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -93,6 +96,195 @@ func TestDecideActuationMode(t *testing.T) {
 	}
 }
 
+func TestDecideActuation(t *testing.T) {
+	// Thursday.
+	now := time.Date(2026, 12, 24, 10, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *metav1.Time {
+		return &metav1.Time{Time: time.Date(2026, 12, day, hour, 0, 0, 0, time.UTC)}
+	}
+	endOfYear := &opv1beta1.ActuationSchedule{
+		Freezes: []opv1beta1.ActuationFreeze{
+			{Start: *at(23, 0), End: *at(31, 0), Reason: "End of year"},
+		},
+	}
+	weekends := &opv1beta1.ActuationSchedule{
+		TimeZone: "America/New_York",
+		RecurringFreezes: []opv1beta1.RecurringActuationFreeze{
+			{Days: []opv1beta1.DayOfWeek{"Friday"}, StartTime: "18:00", Duration: metav1.Duration{Duration: 62 * time.Hour}, Reason: "Weekend"},
+		},
+	}
+	tests := []struct {
+		name     string
+		cc       opv1beta1.ConfigConnector
+		ccc      opv1beta1.ConfigConnectorContext
+		now      time.Time
+		expected opv1beta1.ActuationStatus
+	}{
+		{
+			name:     "no schedule",
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Reconciling},
+		},
+		{
+			name: "CC freeze in progress",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: endOfYear},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: "End of year", NextTransitionTime: at(31, 0)},
+		},
+		{
+			name: "actuationMode Paused takes precedence over the schedule",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{Actuation: opv1beta1.Paused, ActuationSchedule: weekends},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused},
+		},
+		{
+			name: "CCC freeze applies in namespaced mode",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{Mode: opk8s.NamespacedMode},
+			},
+			ccc: opv1beta1.ConfigConnectorContext{
+				Spec: opv1beta1.ConfigConnectorContextSpec{ActuationSchedule: endOfYear},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: "End of year", NextTransitionTime: at(31, 0)},
+		},
+		{
+			name: "CCC freeze is ignored in cluster mode",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{Mode: opk8s.ClusterMode},
+			},
+			ccc: opv1beta1.ConfigConnectorContext{
+				Spec: opv1beta1.ConfigConnectorContextSpec{ActuationSchedule: endOfYear},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Reconciling},
+		},
+		{
+			name: "before a recurring freeze",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: weekends},
+			},
+			// Friday 18:00 in New York.
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Reconciling, NextTransitionTime: at(25, 23)},
+		},
+		{
+			name: "during a recurring freeze",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: weekends},
+			},
+			now: time.Date(2026, 12, 26, 12, 0, 0, 0, time.UTC),
+			// Monday 08:00 in New York.
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: "Weekend", NextTransitionTime: at(28, 13)},
+		},
+		{
+			name: "overlapping CC and CCC freezes",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{Mode: opk8s.NamespacedMode, ActuationSchedule: weekends},
+			},
+			ccc: opv1beta1.ConfigConnectorContext{
+				Spec: opv1beta1.ConfigConnectorContextSpec{ActuationSchedule: &opv1beta1.ActuationSchedule{
+					Freezes: []opv1beta1.ActuationFreeze{{Start: *at(24, 8), End: *at(26, 0)}},
+				}},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: "Frozen by the actuation schedule", NextTransitionTime: at(28, 13)},
+		},
+		{
+			name: "recurring freeze that never ends",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: &opv1beta1.ActuationSchedule{
+					RecurringFreezes: []opv1beta1.RecurringActuationFreeze{
+						{StartTime: "00:00", Duration: metav1.Duration{Duration: 24 * time.Hour}, Reason: "Always"},
+					},
+				}},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: "Always"},
+		},
+		{
+			name: "invalid schedule pauses actuation",
+			cc: opv1beta1.ConfigConnector{
+				Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: &opv1beta1.ActuationSchedule{
+					TimeZone:         "Nowhere/Land",
+					RecurringFreezes: weekends.RecurringFreezes,
+				}},
+			},
+			expected: opv1beta1.ActuationStatus{Mode: opv1beta1.Paused, Reason: `invalid actuation schedule: invalid time zone "Nowhere/Land": unknown time zone Nowhere/Land`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testNow := now
+			if !test.now.IsZero() {
+				testNow = test.now
+			}
+			actual := resourceactuation.DecideActuation(test.cc, test.ccc, testNow)
+			if !equality.Semantic.DeepEqual(test.expected, actual) {
+				t.Errorf("DecideActuation failed; got %+v, want %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Date(2026, 12, 24, 10, 0, 0, 0, time.UTC)
+	frozen := opv1beta1.ConfigConnector{
+		Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: &opv1beta1.ActuationSchedule{
+			Freezes: []opv1beta1.ActuationFreeze{
+				{Start: metav1.NewTime(now.Add(-time.Hour)), End: metav1.NewTime(now.Add(time.Hour))},
+			},
+		}},
+	}
+	upcoming := opv1beta1.ConfigConnector{
+		Spec: opv1beta1.ConfigConnectorSpec{ActuationSchedule: &opv1beta1.ActuationSchedule{
+			Freezes: []opv1beta1.ActuationFreeze{
+				{Start: metav1.NewTime(now.Add(time.Minute)), End: metav1.NewTime(now.Add(time.Hour))},
+			},
+		}},
+	}
+	paused := opv1beta1.ConfigConnector{
+		Spec: opv1beta1.ConfigConnectorSpec{Actuation: opv1beta1.Paused, ActuationSchedule: frozen.Spec.ActuationSchedule},
+	}
+	tests := []struct {
+		name           string
+		cc             opv1beta1.ConfigConnector
+		jitteredPeriod time.Duration
+		expected       time.Duration
+	}{
+		{
+			name:           "freeze ends before the jittered period",
+			cc:             frozen,
+			jitteredPeriod: 2 * time.Hour,
+			expected:       time.Hour,
+		},
+		{
+			name:           "freeze ends after the jittered period",
+			cc:             frozen,
+			jitteredPeriod: 10 * time.Minute,
+			expected:       10 * time.Minute,
+		},
+		{
+			name:           "freeze has not started",
+			cc:             upcoming,
+			jitteredPeriod: 10 * time.Minute,
+			expected:       10 * time.Minute,
+		},
+		{
+			name:           "actuationMode Paused",
+			cc:             paused,
+			jitteredPeriod: 2 * time.Hour,
+			expected:       2 * time.Hour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actuation := resourceactuation.DecideActuation(test.cc, opv1beta1.ConfigConnectorContext{}, now)
+			actual := resourceactuation.RequeueAfter(test.jitteredPeriod, resourceactuation.ResumeAfter(actuation, now))
+			if actual != test.expected {
+				t.Errorf("RequeueAfter failed; got %v, want %v", actual, test.expected)
+			}
+		})
+	}
+}
+
 func TestShouldSkip(t *testing.T) {
 	testcases := []struct {
 		name               string
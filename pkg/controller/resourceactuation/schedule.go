@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourceactuation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	opv1beta1 "github.com/GoogleCloudPlatform/k8s-config-connector/operator/pkg/apis/core/v1beta1"
+)
+
+const (
+	// recurringHorizon is how far ahead recurring freezes are expanded to find the next transition.
+	recurringHorizon = 15 * 24 * time.Hour
+	week             = 7 * 24 * time.Hour
+
+	defaultFreezeReason = "Frozen by the actuation schedule"
+)
+
+var daysOfWeek = map[opv1beta1.DayOfWeek]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// freeze is an occurrence of a freeze, in [start, end).
+type freeze struct {
+	start, end time.Time
+	reason     string
+}
+
+// expandSchedule returns the one-off freezes of the schedule, and the occurrences of its recurring freezes
+// that overlap [from, to).
+func expandSchedule(s *opv1beta1.ActuationSchedule, from, to time.Time) ([]freeze, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var freezes []freeze
+	for _, f := range s.Freezes {
+		freezes = append(freezes, freeze{start: f.Start.Time, end: f.End.Time, reason: f.Reason})
+	}
+	if len(s.RecurringFreezes) == 0 {
+		return freezes, nil
+	}
+
+	loc := time.UTC
+	if s.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(s.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", s.TimeZone, err)
+		}
+	}
+	for _, rf := range s.RecurringFreezes {
+		startTime, err := time.Parse("15:04", rf.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time %q, must be 'HH:MM': %w", rf.StartTime, err)
+		}
+		duration := rf.Duration.Duration
+		if duration <= 0 || duration > week {
+			return nil, fmt.Errorf("invalid duration %v, must be positive and at most a week", duration)
+		}
+		days := make(map[time.Weekday]bool)
+		for _, d := range rf.Days {
+			weekday, ok := daysOfWeek[d]
+			if !ok {
+				return nil, fmt.Errorf("invalid day of the week %q", d)
+			}
+			days[weekday] = true
+		}
+
+		// Start early enough to find occurrences that started before `from` and are still ongoing.
+		first := from.In(loc).Add(-duration - 24*time.Hour)
+		for i := 0; ; i++ {
+			// time.Date normalizes the day, and keeps the time of day across daylight saving time changes.
+			start := time.Date(first.Year(), first.Month(), first.Day()+i, startTime.Hour(), startTime.Minute(), 0, 0, loc)
+			if !start.Before(to) {
+				break
+			}
+			if len(days) > 0 && !days[start.Weekday()] {
+				continue
+			}
+			end := start.Add(duration)
+			if end.After(from) {
+				freezes = append(freezes, freeze{start: start, end: end, reason: rf.Reason})
+			}
+		}
+	}
+	return freezes, nil
+}
+
+// evaluateSchedules returns whether actuation is frozen by the schedules at the time, why, and when that next
+// changes. The time of the next change is zero if it is not known to change.
+func evaluateSchedules(now time.Time, schedules ...*opv1beta1.ActuationSchedule) (frozen bool, reason string, next time.Time, err error) {
+	horizon := now.Add(recurringHorizon)
+	var freezes []freeze
+	recurring := false
+	for _, s := range schedules {
+		f, err := expandSchedule(s, now, horizon)
+		if err != nil {
+			return false, "", time.Time{}, err
+		}
+		freezes = append(freezes, f...)
+		recurring = recurring || (s != nil && len(s.RecurringFreezes) > 0)
+	}
+	sort.Slice(freezes, func(i, j int) bool {
+		return freezes[i].start.Before(freezes[j].start)
+	})
+
+	var end time.Time
+	for _, f := range freezes {
+		if !f.start.After(now) && f.end.After(now) {
+			if !frozen {
+				frozen = true
+				reason = f.reason
+			}
+			if f.end.After(end) {
+				end = f.end
+			}
+		}
+	}
+	if !frozen {
+		for _, f := range freezes {
+			if f.start.After(now) && f.end.After(f.start) {
+				return false, "", f.start, nil
+			}
+		}
+		return false, "", time.Time{}, nil
+	}
+
+	// The freeze lasts until no other freeze overlaps it.
+	for _, f := range freezes {
+		if !f.start.After(end) && f.end.After(end) {
+			end = f.end
+		}
+	}
+	if reason == "" {
+		reason = defaultFreezeReason
+	}
+	if recurring && !end.Before(horizon) {
+		// Recurring freezes were only expanded up to the horizon, so the freeze may last longer.
+		return true, reason, time.Time{}, nil
+	}
+	return true, reason, end, nil
+}
@@ -79,11 +79,44 @@ func (r *ResourceWatcher) WatchResource(ctx context.Context, nn types.Namespaced
 	return watch, nil
 }
 
+// WatchResources watches all the resources of the given kind in the namespace.
+func (r *ResourceWatcher) WatchResources(ctx context.Context, namespace string, gvk schema.GroupVersionKind) (watch.Interface, error) {
+	client := r.dynamicClient.Resource(k8s.ToGVR(gvk)).Namespace(namespace)
+	watch, err := client.Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating watch on resources: %w", err)
+	}
+	return watch, nil
+}
+
 // WaitForResourceToBeReadyOrDeletedViaWatch monitors a given 'Watch' for any
 // updates to the resource that the given 'Watch' is targeting. Note that
 // an error is returned to signify a failure during the 'Watch' process,
 // while nil is returned to signify the watched resource is ready or deleted.
 func WaitForResourceToBeReadyOrDeletedViaWatch(ctx context.Context, w watch.Interface, logger logr.Logger) error {
+	return WatchResourceUntil(ctx, w, func(eventType watch.EventType, u *unstructured.Unstructured) (bool, error) {
+		if eventType == watch.Deleted {
+			logger.Info("resource has been deleted; triggering watch completion")
+			return true, nil
+		}
+		isReady, err := isResourceReady(u)
+		if err != nil {
+			return false, fmt.Errorf("error checking if resource is ready: %w", err)
+		}
+		if !isReady {
+			logger.Info("resource not ready")
+			return false, nil
+		}
+		logger.Info("resource is ready")
+		return true, nil
+	})
+}
+
+// WatchResourceUntil monitors a given 'Watch' and calls fn with each added,
+// modified or deleted resource, until fn returns true or an error. Note that
+// an error is returned to signify a failure during the 'Watch' process or
+// from fn, while nil is returned to signify that fn returned true.
+func WatchResourceUntil(ctx context.Context, w watch.Interface, fn func(eventType watch.EventType, u *unstructured.Unstructured) (bool, error)) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -95,11 +128,7 @@ func WaitForResourceToBeReadyOrDeletedViaWatch(ctx context.Context, w watch.Inte
 			if event.Type == watch.Bookmark {
 				continue // ignore
 			}
-			if event.Type == watch.Deleted {
-				logger.Info("resource has been deleted; triggering watch completion")
-				return nil
-			}
-			if event.Type != watch.Modified && event.Type != watch.Added {
+			if event.Type != watch.Modified && event.Type != watch.Added && event.Type != watch.Deleted {
 				return fmt.Errorf("unexpected watch event type %v", event.Type)
 			}
 
@@ -108,16 +137,13 @@ func WaitForResourceToBeReadyOrDeletedViaWatch(ctx context.Context, w watch.Inte
 				return fmt.Errorf("error casting event object '%v' of kind '%v' to unstructured", event.Object, event.Object.GetObjectKind())
 			}
 
-			isReady, err := isResourceReady(u)
+			done, err := fn(event.Type, u)
 			if err != nil {
-				return fmt.Errorf("error checking if resource is ready: %w", err)
+				return err
 			}
-			if !isReady {
-				logger.Info("resource not ready")
-				continue
+			if done {
+				return nil
 			}
-			logger.Info("resource is ready")
-			return nil
 		}
 	}
 }
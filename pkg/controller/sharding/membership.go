@@ -0,0 +1,429 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// ShardGroupLabel is set on the shard Leases, to the group of replicas that share the shards.
+	ShardGroupLabel = "cnrm.cloud.google.com/shard-group"
+	// ShardCountAnnotation is set on the shard Leases, to the number of shards of the replica holding the Lease.
+	ShardCountAnnotation = "cnrm.cloud.google.com/shard-count"
+
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRetryPeriod   = 5 * time.Second
+)
+
+// Options configures a Membership.
+type Options struct {
+	// Shards is the number of shards.
+	Shards int
+	// LeaseNamespace is the namespace of the shard Leases.
+	LeaseNamespace string
+	// Group identifies the replicas that share the shards, e.g. the name of their StatefulSet.
+	// The shard Leases are named <group>-shard-<index>, and each replica also holds a member Lease
+	// named <group>-member-<identity>, so that replicas without a shard are counted.
+	Group string
+	// Identity identifies this replica, e.g. its pod name.
+	Identity string
+	// WatchNamespace is the namespace whose objects are sharded, or "" for all namespaces.
+	WatchNamespace string
+
+	LeaseDuration time.Duration
+	RetryPeriod   time.Duration
+}
+
+// Membership holds the shards of this replica. It is a manager.Runnable, which claims and renews the
+// shards' Leases while the manager runs. A nil *Membership owns every object, so callers need not
+// check whether sharding is enabled.
+//
+// Each replica holds at most its share of the shards, the number of shards divided by the number of live
+// replicas rounded up. When a replica goes away, the others take over its expired Leases; when a replica
+// joins, the replicas holding more than their share release the extra shards for it to claim.
+type Membership struct {
+	client client.Client
+	opts   Options
+
+	// now is the clock, for tests.
+	now func() time.Time
+
+	mu sync.RWMutex
+	// shards are the indexes of the shards whose Leases we hold, sorted.
+	shards []int
+	// active is true if we hold a shard and every live Lease agrees on the number of shards.
+	active bool
+	// lastRenew is when we last claimed or renewed the Leases of our shards.
+	lastRenew time.Time
+	listeners []func(ctx context.Context)
+}
+
+func NewMembership(c client.Client, opts Options) (*Membership, error) {
+	if opts.Shards < 1 {
+		return nil, fmt.Errorf("number of shards must be at least 1, got %d", opts.Shards)
+	}
+	if opts.LeaseNamespace == "" || opts.Group == "" || opts.Identity == "" {
+		return nil, fmt.Errorf("lease namespace, group and identity must be set for sharding")
+	}
+	if opts.LeaseDuration == 0 {
+		opts.LeaseDuration = DefaultLeaseDuration
+	}
+	if opts.RetryPeriod == 0 {
+		opts.RetryPeriod = DefaultRetryPeriod
+	}
+	return &Membership{
+		client: c,
+		opts:   opts,
+		now:    time.Now,
+	}, nil
+}
+
+// Owns returns true if this replica should reconcile the object.
+func (m *Membership) Owns(obj metav1.Object) bool {
+	if m == nil {
+		return true
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.active && slices.Contains(m.shards, ShardFor(obj.GetUID(), m.opts.Shards))
+}
+
+// Predicates returns the predicates that filter watch events to the objects this replica owns.
+func (m *Membership) Predicates() []predicate.Predicate {
+	if m == nil {
+		return nil
+	}
+	return []predicate.Predicate{predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return m.Owns(obj)
+	})}
+}
+
+// EnqueueOnAcquire arranges for the objects of the kind that this replica owns to be sent to requests
+// whenever it starts serving shards, because their watch events were filtered out before then.
+func (m *Membership) EnqueueOnAcquire(reader client.Reader, gvk schema.GroupVersionKind, requests chan<- event.GenericEvent) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, func(ctx context.Context) {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := reader.List(ctx, list, client.InNamespace(m.opts.WatchNamespace)); err != nil {
+			klog.Errorf("error listing %v to enqueue after acquiring a shard: %v", gvk.Kind, err)
+			return
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if !m.Owns(obj) {
+				continue
+			}
+			obj.SetGroupVersionKind(gvk)
+			select {
+			case requests <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// NeedLeaderElection returns false, because every replica must claim shards.
+func (m *Membership) NeedLeaderElection() bool {
+	return false
+}
+
+// Start claims and renews shard Leases until the context is done, then releases them.
+func (m *Membership) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		if err := m.sync(ctx); err != nil {
+			klog.Warningf("error syncing shard membership: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			m.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync renews our Leases and claims or releases shards for our share, and decides whether we can serve them.
+func (m *Membership) sync(ctx context.Context) error {
+	if err := m.syncLeases(ctx); err != nil {
+		// We may have lost our Leases without knowing it, so stop serving the shards once they would have expired,
+		// before another replica can claim them.
+		m.deactivateIfExpired()
+		return err
+	}
+	return nil
+}
+
+func (m *Membership) syncLeases(ctx context.Context) error {
+	leases := &coordinationv1.LeaseList{}
+	if err := m.client.List(ctx, leases, client.InNamespace(m.opts.LeaseNamespace), client.MatchingLabels{ShardGroupLabel: m.opts.Group}); err != nil {
+		return fmt.Errorf("error listing shard leases: %w", err)
+	}
+	// While replicas with a different number of shards are still live (e.g. during a rolling update),
+	// objects could be owned by two replicas, so we wait for them to go away.
+	compatible := true
+	members := map[string]bool{m.opts.Identity: true}
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if !m.isLive(lease) {
+			continue
+		}
+		members[holderOf(lease)] = true
+		if holderOf(lease) != m.opts.Identity && lease.Annotations[ShardCountAnnotation] != strconv.Itoa(m.opts.Shards) {
+			compatible = false
+		}
+	}
+
+	renewed := m.now()
+	if _, err := m.tryClaimLease(ctx, m.memberLeaseName()); err != nil {
+		return fmt.Errorf("error renewing the member lease: %w", err)
+	}
+	m.mu.RLock()
+	held := slices.Clone(m.shards)
+	m.mu.RUnlock()
+	for _, shard := range held {
+		ok, err := m.tryClaim(ctx, shard)
+		if err != nil {
+			return fmt.Errorf("error renewing the lease for shard %d: %w", shard, err)
+		}
+		if !ok {
+			klog.Warningf("lost the lease for shard %d", shard)
+			// Another replica holds the Lease, so stop serving the shard even if we fail to claim another one.
+			m.dropShard(shard)
+		}
+	}
+	m.mu.RLock()
+	held = slices.Clone(m.shards)
+	m.mu.RUnlock()
+
+	maxShards := (m.opts.Shards + len(members) - 1) / len(members)
+	for len(held) > maxShards {
+		shard := held[len(held)-1]
+		held = held[:len(held)-1]
+		klog.Infof("releasing shard %d for the %d live replicas to share", shard, len(members))
+		m.dropShard(shard)
+		m.releaseLease(ctx, m.leaseName(shard))
+	}
+	for shard := 0; shard < m.opts.Shards && len(held) < maxShards; shard++ {
+		if slices.Contains(held, shard) {
+			continue
+		}
+		ok, err := m.tryClaim(ctx, shard)
+		if err != nil {
+			return err
+		}
+		if ok {
+			held = append(held, shard)
+		}
+	}
+	slices.Sort(held)
+
+	m.mu.Lock()
+	active := len(held) > 0 && compatible
+	acquired := false
+	for _, shard := range held {
+		if !m.active || !slices.Contains(m.shards, shard) {
+			acquired = active
+		}
+	}
+	if m.active != active || !slices.Equal(m.shards, held) {
+		klog.Infof("shard membership changed: shards %v of %d, active %v", held, m.opts.Shards, active)
+	}
+	m.shards = held
+	m.active = active
+	if len(held) > 0 {
+		m.lastRenew = renewed
+	}
+	listeners := m.listeners
+	m.mu.Unlock()
+
+	if acquired {
+		for _, listener := range listeners {
+			go listener(ctx)
+		}
+	}
+	return nil
+}
+
+// dropShard stops serving the shard.
+func (m *Membership) dropShard(shard int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shards = slices.DeleteFunc(slices.Clone(m.shards), func(s int) bool { return s == shard })
+}
+
+// deactivateIfExpired stops serving the shards if our Leases have not been renewed within their duration.
+// We keep the indexes of the shards, so that we serve them again if we can still renew their Leases.
+func (m *Membership) deactivateIfExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.active && !m.now().Before(m.lastRenew.Add(m.opts.LeaseDuration)) {
+		klog.Warningf("the leases for shards %v expired without being renewed; no longer serving the shards", m.shards)
+		m.active = false
+	}
+}
+
+// tryClaim creates or renews the Lease for the shard, returning false if another replica holds it.
+func (m *Membership) tryClaim(ctx context.Context, shard int) (bool, error) {
+	return m.tryClaimLease(ctx, m.leaseName(shard))
+}
+
+// tryClaimLease creates or renews the Lease, returning false if another replica holds it.
+func (m *Membership) tryClaimLease(ctx context.Context, name string) (bool, error) {
+	now := metav1.NewMicroTime(m.now())
+	identity := m.opts.Identity
+	durationSeconds := int32(m.opts.LeaseDuration.Seconds())
+
+	lease := &coordinationv1.Lease{}
+	nn := types.NamespacedName{Namespace: m.opts.LeaseNamespace, Name: name}
+	if err := m.client.Get(ctx, nn, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting lease %v: %w", nn, err)
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   nn.Namespace,
+				Name:        nn.Name,
+				Labels:      map[string]string{ShardGroupLabel: m.opts.Group},
+				Annotations: map[string]string{ShardCountAnnotation: strconv.Itoa(m.opts.Shards)},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := m.client.Create(ctx, lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("error creating lease %v: %w", nn, err)
+		}
+		return true, nil
+	}
+
+	if holderOf(lease) != identity {
+		if m.isLive(lease) {
+			return false, nil
+		}
+		lease.Spec.AcquireTime = &now
+		transitions := int32(1)
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions + 1
+		}
+		lease.Spec.LeaseTransitions = &transitions
+	}
+	lease.Spec.HolderIdentity = &identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+	if lease.Annotations == nil {
+		lease.Annotations = make(map[string]string)
+	}
+	lease.Annotations[ShardCountAnnotation] = strconv.Itoa(m.opts.Shards)
+	// The update fails with a conflict if another replica claimed the lease since we read it.
+	if err := m.client.Update(ctx, lease); err != nil {
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error updating lease %v: %w", nn, err)
+	}
+	return true, nil
+}
+
+// release gives up our Leases, so that other replicas can claim the shards without waiting for them to expire.
+func (m *Membership) release() {
+	m.mu.Lock()
+	shards := m.shards
+	m.shards = nil
+	m.active = false
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.opts.RetryPeriod)
+	defer cancel()
+	for _, shard := range shards {
+		m.releaseLease(ctx, m.leaseName(shard))
+	}
+	m.releaseLease(ctx, m.memberLeaseName())
+}
+
+// releaseLease gives up the Lease if we hold it.
+func (m *Membership) releaseLease(ctx context.Context, name string) {
+	lease := &coordinationv1.Lease{}
+	if err := m.client.Get(ctx, types.NamespacedName{Namespace: m.opts.LeaseNamespace, Name: name}, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Warningf("error getting lease %v to release it: %v", name, err)
+		}
+		return
+	}
+	if holderOf(lease) != m.opts.Identity {
+		return
+	}
+	empty := ""
+	lease.Spec.HolderIdentity = &empty
+	if err := m.client.Update(ctx, lease); err != nil {
+		klog.Warningf("error releasing lease %v: %v", name, err)
+	}
+}
+
+func (m *Membership) leaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", m.opts.Group, shard)
+}
+
+func (m *Membership) memberLeaseName() string {
+	return fmt.Sprintf("%s-member-%s", m.opts.Group, m.opts.Identity)
+}
+
+// isLive returns true if the Lease is held and has not expired.
+func (m *Membership) isLive(lease *coordinationv1.Lease) bool {
+	if holderOf(lease) == "" || lease.Spec.RenewTime == nil {
+		return false
+	}
+	duration := m.opts.LeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return m.now().Before(lease.Spec.RenewTime.Add(duration))
+}
+
+func holderOf(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
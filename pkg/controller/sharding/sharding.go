@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sharding splits the resources watched by a controller manager across its replicas.
+//
+// Each object belongs to one of N shards, chosen by a consistent hash of its UID, and each
+// replica reconciles only the objects of the shards it holds. Replicas claim shards through
+// Leases and hold an even share of them, so the shards of a replica that goes away are taken
+// over by the live replicas, and objects are never reconciled by two replicas that disagree on N.
+package sharding
+
+import (
+	"hash/fnv"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ShardFor returns the shard, in [0, shards), that the object with the UID belongs to.
+//
+// It uses jump consistent hashing (https://arxiv.org/abs/1406.2294), so that when the number
+// of shards changes from N to N+1 only 1/(N+1) of the objects move to another shard.
+func ShardFor(uid types.UID, shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(uid))
+	key := h.Sum64()
+
+	var b, j int64 = -1, 0
+	for j < int64(shards) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
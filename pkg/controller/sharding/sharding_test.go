@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestShardFor(t *testing.T) {
+	const objects = 10000
+	counts := make(map[int]int)
+	moved := 0
+	for i := 0; i < objects; i++ {
+		uid := types.UID(fmt.Sprintf("uid-%d", i))
+		shard := ShardFor(uid, 4)
+		if shard < 0 || shard >= 4 {
+			t.Fatalf("shard %d out of range", shard)
+		}
+		if got := ShardFor(uid, 4); got != shard {
+			t.Fatalf("unstable shard for %v: %d then %d", uid, shard, got)
+		}
+		counts[shard]++
+		if ShardFor(uid, 5) != shard {
+			moved++
+		}
+	}
+	for shard, count := range counts {
+		if count < objects/4*9/10 || count > objects/4*11/10 {
+			t.Errorf("unbalanced shard %d with %d of %d objects", shard, count, objects)
+		}
+	}
+	// Going from 4 to 5 shards should move about a fifth of the objects.
+	if moved > objects/5*12/10 {
+		t.Errorf("adding a shard moved %d of %d objects, want about %d", moved, objects, objects/5)
+	}
+}
+
+func TestMembership(t *testing.T) {
+	ctx := context.TODO()
+	c := newFakeClient(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newReplica := func(identity string, shards int) *Membership {
+		m, err := NewMembership(c, Options{Shards: shards, LeaseNamespace: "cnrm-system", Group: "cnrm-controller-manager", Identity: identity})
+		if err != nil {
+			t.Fatalf("error creating membership: %v", err)
+		}
+		m.now = func() time.Time { return now }
+		return m
+	}
+	sync := func(m *Membership) {
+		if err := m.sync(ctx); err != nil {
+			t.Fatalf("error syncing membership of %v: %v", m.opts.Identity, err)
+		}
+	}
+
+	a := newReplica("a", 2)
+	b := newReplica("b", 2)
+	var acquired atomic.Int32
+	a.listeners = append(a.listeners, func(context.Context) { acquired.Add(1) })
+	// a starts alone, so it holds both shards until b joins.
+	sync(a)
+	if !slices.Equal(a.shards, []int{0, 1}) || !a.active {
+		t.Fatalf("unexpected membership of a: (%v, %v), want ([0 1], true)", a.shards, a.active)
+	}
+	sync(b)
+	sync(a)
+	sync(b)
+	if !slices.Equal(a.shards, []int{0}) || !slices.Equal(b.shards, []int{1}) || !a.active || !b.active {
+		t.Fatalf("unexpected membership: a=(%v, %v) b=(%v, %v)", a.shards, a.active, b.shards, b.active)
+	}
+	// The listeners run in goroutines, so give them a moment.
+	time.Sleep(10 * time.Millisecond)
+	if got := acquired.Load(); got != 1 {
+		t.Errorf("listener ran %d times, want 1", got)
+	}
+
+	// Every object is owned by exactly one replica.
+	for i := 0; i < 100; i++ {
+		obj := &metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid-%d", i))}
+		if a.Owns(obj) == b.Owns(obj) {
+			t.Fatalf("object %v owned by a=%v, b=%v", obj.UID, a.Owns(obj), b.Owns(obj))
+		}
+	}
+
+	// A replica with a different number of shards waits for the others to go away.
+	c2 := newReplica("c", 3)
+	sync(c2)
+	if !slices.Equal(c2.shards, []int{2}) || c2.active {
+		t.Fatalf("unexpected membership of c: (%v, %v), want ([2], false)", c2.shards, c2.active)
+	}
+
+	// When b stops renewing its lease, c can take over its shard, but must still wait for a.
+	now = now.Add(DefaultLeaseDuration / 2)
+	sync(a)
+	sync(c2)
+	now = now.Add(DefaultLeaseDuration/2 + time.Second)
+	sync(a)
+	sync(c2)
+	if c2.active {
+		t.Errorf("c is active while a is live with a different number of shards")
+	}
+
+	if !slices.Equal(c2.shards, []int{1, 2}) {
+		t.Errorf("unexpected shards of c %v, want [1 2]", c2.shards)
+	}
+
+	// Once a releases its lease, c is active.
+	a.release()
+	lease := &coordinationv1.Lease{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "cnrm-system", Name: "cnrm-controller-manager-shard-0"}, lease); err != nil {
+		t.Fatalf("error getting lease: %v", err)
+	}
+	if holderOf(lease) != "" {
+		t.Errorf("released lease is held by %q", holderOf(lease))
+	}
+	sync(c2)
+	if !c2.active || !slices.Equal(c2.shards, []int{0, 1, 2}) {
+		t.Errorf("unexpected membership of c after a released its lease: (%v, %v), want ([0 1 2], true)", c2.shards, c2.active)
+	}
+}
+
+func TestMembershipTakeover(t *testing.T) {
+	ctx := context.TODO()
+	c := newFakeClient(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newReplica := func(identity string) *Membership {
+		m, err := NewMembership(c, Options{Shards: 2, LeaseNamespace: "cnrm-system", Group: "cnrm-controller-manager", Identity: identity})
+		if err != nil {
+			t.Fatalf("error creating membership: %v", err)
+		}
+		m.now = func() time.Time { return now }
+		return m
+	}
+	sync := func(replicas ...*Membership) {
+		for _, m := range replicas {
+			if err := m.sync(ctx); err != nil {
+				t.Fatalf("error syncing membership of %v: %v", m.opts.Identity, err)
+			}
+		}
+	}
+
+	a := newReplica("a")
+	b := newReplica("b")
+	sync(a, b, a, b)
+	if !slices.Equal(a.shards, []int{0}) || !slices.Equal(b.shards, []int{1}) {
+		t.Fatalf("unexpected shards a=%v b=%v, want [0] and [1]", a.shards, b.shards)
+	}
+
+	// When b stops renewing its leases, a takes over its shard once they expire.
+	now = now.Add(DefaultLeaseDuration)
+	sync(a)
+	if !slices.Equal(a.shards, []int{0, 1}) || !a.active {
+		t.Fatalf("unexpected membership of a after b went away: (%v, %v), want ([0 1], true)", a.shards, a.active)
+	}
+	for i := 0; i < 100; i++ {
+		if obj := (&metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid-%d", i))}); !a.Owns(obj) {
+			t.Fatalf("object %v is not owned by a, the only live replica", obj.UID)
+		}
+	}
+
+	// When b comes back, a releases the extra shard for b to claim.
+	b = newReplica("b")
+	sync(b)
+	if len(b.shards) != 0 {
+		t.Errorf("b claimed shards %v held by a", b.shards)
+	}
+	sync(a, b)
+	if !slices.Equal(a.shards, []int{0}) || !slices.Equal(b.shards, []int{1}) || !a.active || !b.active {
+		t.Errorf("unexpected membership after b came back: a=(%v, %v) b=(%v, %v)", a.shards, a.active, b.shards, b.active)
+	}
+}
+
+func TestMembershipExpiresWithoutAPIServer(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var unreachable atomic.Bool
+	failIfUnreachable := func() error {
+		if unreachable.Load() {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	}
+	c := interceptor.NewClient(newFakeClient(t).(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := failIfUnreachable(); err != nil {
+				return err
+			}
+			return client.List(ctx, list, opts...)
+		},
+		Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := failIfUnreachable(); err != nil {
+				return err
+			}
+			return client.Get(ctx, key, obj, opts...)
+		},
+	})
+	m, err := NewMembership(c, Options{Shards: 1, LeaseNamespace: "cnrm-system", Group: "cnrm-controller-manager", Identity: "a"})
+	if err != nil {
+		t.Fatalf("error creating membership: %v", err)
+	}
+	m.now = func() time.Time { return now }
+	if err := m.sync(ctx); err != nil || !m.active {
+		t.Fatalf("expected membership to be active, got active=%v err=%v", m.active, err)
+	}
+
+	// While the lease has not expired, we keep serving the shard.
+	unreachable.Store(true)
+	now = now.Add(DefaultLeaseDuration / 2)
+	if err := m.sync(ctx); err == nil {
+		t.Fatalf("expected an error syncing without the API server")
+	}
+	if !m.active {
+		t.Errorf("membership is inactive before its lease expired")
+	}
+
+	// Once it has expired, another replica can claim the shard, so we must stop serving it.
+	now = now.Add(DefaultLeaseDuration / 2)
+	if err := m.sync(ctx); err == nil {
+		t.Fatalf("expected an error syncing without the API server")
+	}
+	if m.active {
+		t.Errorf("membership is still active after its lease expired")
+	}
+
+	// When the API server is back and the lease is still ours, we serve the shard again.
+	unreachable.Store(false)
+	if err := m.sync(ctx); err != nil || !m.active || !slices.Equal(m.shards, []int{0}) {
+		t.Errorf("expected membership of shard 0 to be active again, got shards=%v active=%v err=%v", m.shards, m.active, err)
+	}
+}
+
+func TestNilMembershipOwnsEverything(t *testing.T) {
+	var m *Membership
+	if !m.Owns(&metav1.ObjectMeta{UID: "uid"}) {
+		t.Errorf("nil membership does not own the object")
+	}
+	if len(m.Predicates()) != 0 {
+		t.Errorf("nil membership has predicates")
+	}
+}
+
+func newFakeClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	if err := coordinationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("error building scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).Build()
+}
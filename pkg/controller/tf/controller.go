@@ -229,7 +229,9 @@ func (r *Reconciler) DoReconcile(ctx context.Context, req reconcile.Request) (re
 		return reconcile.Result{}, err
 	}
 
-	am := resourceactuation.DecideActuationMode(cc, ccc)
+	now := time.Now()
+	actuation := resourceactuation.DecideActuation(cc, ccc, now)
+	am := actuation.Mode
 	switch am {
 	case v1beta1.Reconciling:
 		r.logger.V(2).Info("Actuating a resource as actuation mode is \"Reconciling\"", "resource", req.NamespacedName)
@@ -238,6 +240,7 @@ func (r *Reconciler) DoReconcile(ctx context.Context, req reconcile.Request) (re
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		jitteredPeriod = resourceactuation.RequeueAfter(jitteredPeriod, resourceactuation.ResumeAfter(actuation, now))
 
 		// add finalizers for deletion defender to make sure we don't delete cloud provider resources when uninstalling
 		if resource.GetDeletionTimestamp().IsZero() {